@@ -0,0 +1,166 @@
+package action
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// blastRadiusLimit is the maximum number and percentage of the items a destructive action may act on, 0 is unlimited
+type blastRadiusLimit struct {
+	max        int
+	maxPercent float64
+}
+
+func (l blastRadiusLimit) String() string {
+	var limits []string
+	if l.max > 0 {
+		limits = append(limits, strconv.Itoa(l.max))
+	}
+	if l.maxPercent > 0 {
+		limits = append(limits, strconv.FormatFloat(l.maxPercent, 'f', -1, 64)+"%")
+	}
+	return strings.Join(limits, ",")
+}
+
+func (l blastRadiusLimit) isExceeded(count, total int) bool {
+	if l.max > 0 && count > l.max {
+		return true
+	}
+	if total < count {
+		total = count
+	}
+	return l.maxPercent > 0 && total > 0 && float64(count)*100/float64(total) > l.maxPercent
+}
+
+type blastRadiusLimits struct {
+	perRun   blastRadiusLimit
+	perCloud blastRadiusLimit
+	perType  blastRadiusLimit
+}
+
+var blastRadius blastRadiusLimits
+
+func init() {
+	initBlastRadius()
+}
+
+func initBlastRadius() {
+	blastRadius = blastRadiusLimits{
+		perRun:   getBlastRadiusLimit("BLAST_RADIUS_PER_RUN"),
+		perCloud: getBlastRadiusLimit("BLAST_RADIUS_PER_CLOUD"),
+		perType:  getBlastRadiusLimit("BLAST_RADIUS_PER_TYPE"),
+	}
+}
+
+func getBlastRadiusLimit(env string) blastRadiusLimit {
+	limit, err := parseBlastRadiusLimit(os.Getenv(env))
+	if err != nil {
+		log.Fatalf("[BLAST_RADIUS] Failed to parse %s, err: %s", env, err)
+	}
+	return limit
+}
+
+// parseBlastRadiusLimit parses the absolute and/or percentage limit, e.g. "50", "20%" or "50,20%"
+func parseBlastRadiusLimit(value string) (blastRadiusLimit, error) {
+	limit := blastRadiusLimit{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case len(part) == 0:
+			continue
+		case strings.HasSuffix(part, "%"):
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+			if err != nil || percent <= 0 || percent > 100 {
+				return limit, fmt.Errorf("invalid percentage: %s", part)
+			}
+			limit.maxPercent = percent
+		default:
+			max, err := strconv.Atoi(part)
+			if err != nil || max <= 0 {
+				return limit, fmt.Errorf("invalid number of items: %s", part)
+			}
+			limit.max = max
+		}
+	}
+	return limit, nil
+}
+
+// checkBlastRadius aborts the action before touching any item if it would act on more items than allowed.
+// The percentages are calculated from the number of items returned by the operation before filtering.
+func checkBlastRadius(action types.ActionType, op types.OpType, filters []types.FilterType, items []types.CloudItem) {
+	violations := blastRadius.check(items, ctx.OperationItems)
+	if len(violations) == 0 {
+		return
+	}
+	message := fmt.Sprintf("[%s] Action aborted, the blast radius limit is exceeded by operation %s and filters %s: %s",
+		strings.ToUpper(action.String()), op, filters, strings.Join(violations, "; "))
+	log.Error(message)
+	notifyDispatchers(message)
+	panic(message)
+}
+
+func (l blastRadiusLimits) check(items []types.CloudItem, allItems []types.CloudItem) []string {
+	var violations []string
+	if l.perRun.isExceeded(len(items), len(allItems)) {
+		violations = append(violations, fmt.Sprintf("%d of %d items, limit per run: %s", len(items), len(allItems), l.perRun))
+	}
+	violations = append(violations, checkBlastRadiusPerGroup("cloud", l.perCloud, items, allItems, func(item types.CloudItem) string {
+		return item.GetCloudType().String()
+	})...)
+	violations = append(violations, checkBlastRadiusPerGroup("type", l.perType, items, allItems, func(item types.CloudItem) string {
+		return item.GetType()
+	})...)
+	return violations
+}
+
+func checkBlastRadiusPerGroup(scope string, limit blastRadiusLimit, items []types.CloudItem, allItems []types.CloudItem, groupBy func(types.CloudItem) string) []string {
+	counts := countItems(items, groupBy)
+	totals := countItems(allItems, groupBy)
+	var violations []string
+	for _, group := range sortedKeys(counts) {
+		if limit.isExceeded(counts[group], totals[group]) {
+			violations = append(violations, fmt.Sprintf("%d of %d items on %s %s, limit per %s: %s", counts[group], totals[group], scope, group, scope, limit))
+		}
+	}
+	return violations
+}
+
+func countItems(items []types.CloudItem, groupBy func(types.CloudItem) string) map[string]int {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[groupBy(item)]++
+	}
+	return counts
+}
+
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func notifyDispatchers(message string) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(ctx.Dispatchers))
+	for n, d := range ctx.Dispatchers {
+		go func(name string, dispatcher types.Dispatcher) {
+			defer wg.Done()
+
+			if err := dispatcher.SendMessage(message); err != nil {
+				log.Errorf("[%s] Failed to send message, err: %s", name, err.Error())
+			}
+		}(n, d)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package action
+
+import (
+	"os"
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlastRadiusLimit(t *testing.T) {
+	limit, err := parseBlastRadiusLimit(" 50, 20.5% ")
+
+	assert.Nil(t, err)
+	assert.Equal(t, blastRadiusLimit{50, 20.5}, limit)
+}
+
+func TestParseBlastRadiusLimitEmpty(t *testing.T) {
+	limit, err := parseBlastRadiusLimit("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, blastRadiusLimit{}, limit)
+}
+
+func TestParseBlastRadiusLimitInvalid(t *testing.T) {
+	for _, value := range []string{"many", "0", "-1", "120%", "x%"} {
+		_, err := parseBlastRadiusLimit(value)
+
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestInitBlastRadius(t *testing.T) {
+	os.Setenv("BLAST_RADIUS_PER_CLOUD", "10%")
+	defer os.Unsetenv("BLAST_RADIUS_PER_CLOUD")
+
+	initBlastRadius()
+	defer initBlastRadius()
+
+	assert.Equal(t, blastRadiusLimits{perCloud: blastRadiusLimit{maxPercent: 10}}, blastRadius)
+}
+
+func newBlastRadiusTestItems() []types.CloudItem {
+	return []types.CloudItem{
+		&types.Instance{CloudType: types.AWS, Name: "a"},
+		&types.Instance{CloudType: types.AWS, Name: "b"},
+		&types.Instance{CloudType: types.GCP, Name: "c"},
+		&types.Disk{CloudType: types.GCP, Name: "d"},
+	}
+}
+
+func TestBlastRadiusCheckWithinLimits(t *testing.T) {
+	allItems := newBlastRadiusTestItems()
+	limits := blastRadiusLimits{blastRadiusLimit{3, 75}, blastRadiusLimit{2, 100}, blastRadiusLimit{3, 0}}
+
+	assert.Empty(t, limits.check(allItems[:3], allItems))
+}
+
+func TestBlastRadiusCheckPerRun(t *testing.T) {
+	allItems := newBlastRadiusTestItems()
+
+	assert.Equal(t, []string{"3 of 4 items, limit per run: 50%"}, blastRadiusLimits{perRun: blastRadiusLimit{maxPercent: 50}}.check(allItems[:3], allItems))
+	assert.Equal(t, []string{"3 of 4 items, limit per run: 2"}, blastRadiusLimits{perRun: blastRadiusLimit{max: 2}}.check(allItems[:3], allItems))
+}
+
+func TestBlastRadiusCheckPerCloudAndType(t *testing.T) {
+	allItems := newBlastRadiusTestItems()
+	limits := blastRadiusLimits{perCloud: blastRadiusLimit{maxPercent: 50}, perType: blastRadiusLimit{max: 2}}
+
+	violations := limits.check(allItems[:3], allItems)
+
+	assert.Equal(t, []string{"2 of 2 items on cloud AWS, limit per cloud: 50%", "3 of 3 items on type instance, limit per type: 2"}, violations)
+}
+
+func TestCheckBlastRadiusAbortsAndNotifies(t *testing.T) {
+	dispatchers := ctx.Dispatchers
+	defer func() { ctx.Dispatchers = dispatchers }()
+	dispatcher := &mockDispatcher{}
+	ctx.Dispatchers = map[string]types.Dispatcher{"mock": dispatcher}
+	blastRadius = blastRadiusLimits{perRun: blastRadiusLimit{max: 1}}
+	defer initBlastRadius()
+	provider := &mockProvider{}
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{types.AWS: func() types.CloudProvider { return provider }}
+
+	assert.Panics(t, func() {
+		terminationAction{}.Execute(types.Instances, []types.FilterType{}, newBlastRadiusTestItems())
+	})
+
+	assert.Equal(t, 1, dispatcher.calls)
+	assert.Equal(t, 0, provider.calls)
+}
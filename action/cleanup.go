@@ -13,8 +13,11 @@ import (
 
 var defaultRetentionDays = 90
 
+var defaultRetentionTags = 10
+
 type cleanupAction struct {
 	retentionDays int
+	retentionTags int
 }
 
 func init() {
@@ -31,10 +34,23 @@ func initCleanup() {
 		}
 		retentionDays = parsed
 	}
-	ctx.Actions[types.CleanupAction] = cleanupAction{retentionDays}
+	retentionTags := defaultRetentionTags
+	retentionTagsEnv := os.Getenv("RETENTION_TAGS")
+	if len(retentionTagsEnv) > 0 {
+		parsed, err := strconv.Atoi(retentionTagsEnv)
+		if err != nil {
+			log.Fatalf("[CLEANUP] Failed to parse RETENTION_TAGS, err: %s", err)
+		}
+		if parsed < 1 {
+			log.Fatalf("[CLEANUP] RETENTION_TAGS must be at least 1, got: %d", parsed)
+		}
+		retentionTags = parsed
+	}
+	ctx.Actions[types.CleanupAction] = cleanupAction{retentionDays, retentionTags}
 }
 
 func (a cleanupAction) Execute(op types.OpType, filters []types.FilterType, items []types.CloudItem) {
+	checkBlastRadius(types.CleanupAction, op, filters, a.getDeletedItems(items))
 	wg := sync.WaitGroup{}
 	wg.Add(len(ctx.CloudProviders))
 	for t, p := range ctx.CloudProviders {
@@ -57,6 +73,8 @@ func (a cleanupAction) Execute(op types.OpType, filters []types.FilterType, item
 				switch t := item.GetItem().(type) {
 				case types.Storage:
 					errors = a.cleanupStorages(provider, cloudItems)
+				case types.ContainerImage:
+					errors = a.cleanupContainerImages(provider, cloudItems)
 				default:
 					panic(fmt.Sprintf("[CLEANUP] Operation on type %T is not allowed", t))
 				}
@@ -73,6 +91,42 @@ func (a cleanupAction) Execute(op types.OpType, filters []types.FilterType, item
 	wg.Wait()
 }
 
+// getDeletedItems returns the items the blast radius is checked against. Only the outdated container images are
+// deleted, not all the listed ones.
+func (a cleanupAction) getDeletedItems(items []types.CloudItem) []types.CloudItem {
+	var containerImages []*types.ContainerImage
+	var deletedItems []types.CloudItem
+	for _, item := range items {
+		if containerImage, ok := item.GetItem().(types.ContainerImage); ok {
+			containerImages = append(containerImages, &containerImage)
+		} else {
+			deletedItems = append(deletedItems, item)
+		}
+	}
+	if len(containerImages) == 0 {
+		return deletedItems
+	}
+	container := types.NewContainerImageContainer(containerImages)
+	for _, cloudType := range getCloudTypes(containerImages) {
+		for _, containerImage := range container.GetOutdated(cloudType, a.retentionTags) {
+			deletedItems = append(deletedItems, containerImage)
+		}
+	}
+	return deletedItems
+}
+
+func getCloudTypes(containerImages []*types.ContainerImage) []types.CloudType {
+	var cloudTypes []types.CloudType
+	found := map[types.CloudType]bool{}
+	for _, containerImage := range containerImages {
+		if !found[containerImage.CloudType] {
+			found[containerImage.CloudType] = true
+			cloudTypes = append(cloudTypes, containerImage.CloudType)
+		}
+	}
+	return cloudTypes
+}
+
 func (a cleanupAction) cleanupStorages(provider types.CloudProvider, items []*types.CloudItem) []error {
 	var storages []*types.Storage
 	for _, item := range items {
@@ -81,3 +135,12 @@ func (a cleanupAction) cleanupStorages(provider types.CloudProvider, items []*ty
 	}
 	return provider.CleanupStorages(types.NewStorageContainer(storages), a.retentionDays)
 }
+
+func (a cleanupAction) cleanupContainerImages(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var containerImages []*types.ContainerImage
+	for _, item := range items {
+		containerImage := (*item).GetItem().(types.ContainerImage)
+		containerImages = append(containerImages, &containerImage)
+	}
+	return provider.CleanupContainerImages(types.NewContainerImageContainer(containerImages), a.retentionTags)
+}
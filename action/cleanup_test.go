@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestUnsetOsEnv(t *testing.T) {
@@ -31,3 +32,53 @@ func TestSetOsEnv(t *testing.T) {
 
 	assert.Equal(t, ctx.Actions[types.CleanupAction].(cleanupAction).retentionDays, 30)
 }
+
+func TestSetRetentionTagsOsEnv(t *testing.T) {
+	os.Setenv("RETENTION_TAGS", "5")
+	defer os.Unsetenv("RETENTION_TAGS")
+
+	initCleanup()
+
+	assert.Equal(t, ctx.Actions[types.CleanupAction].(cleanupAction).retentionTags, 5)
+}
+
+func TestCleanupContainerImages(t *testing.T) {
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	provider := &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return provider
+		}}
+
+	cleanupAction{retentionTags: 1}.Execute(types.ContainerImages, []types.FilterType{}, []types.CloudItem{
+		&types.ContainerImage{CloudType: types.AWS, Name: "app:1", Repository: "app", ImageTags: []string{"1"}},
+		&types.ContainerImage{CloudType: types.AWS, Name: "app:2", Repository: "app", ImageTags: []string{"2"}},
+	})
+
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestCleanupBlastRadiusCountsOutdatedContainerImages(t *testing.T) {
+	blastRadius = blastRadiusLimits{perRun: blastRadiusLimit{max: 1}}
+	defer initBlastRadius()
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	provider := &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return provider
+		}}
+	images := []types.CloudItem{
+		&types.ContainerImage{CloudType: types.AWS, Name: "app:1", Repository: "app", ImageTags: []string{"1"}, Created: time.Now().Add(-time.Hour)},
+		&types.ContainerImage{CloudType: types.AWS, Name: "app:2", Repository: "app", ImageTags: []string{"2"}, Created: time.Now()},
+		&types.ContainerImage{CloudType: types.AWS, Name: "app:untagged", Repository: "app"},
+	}
+
+	cleanupAction{retentionTags: 1}.Execute(types.ContainerImages, []types.FilterType{}, images)
+
+	assert.Equal(t, 1, provider.calls)
+	assert.Panics(t, func() {
+		cleanupAction{retentionTags: 0}.Execute(types.ContainerImages, []types.FilterType{}, images)
+	})
+}
@@ -0,0 +1,182 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type disableAction struct {
+	deleteGracePeriod time.Duration
+	stateFile         string
+}
+
+func init() {
+	initDisable()
+}
+
+func initDisable() {
+	var gracePeriod time.Duration
+	if graceEnv := os.Getenv("ACCESS_DELETE_GRACE_PERIOD"); len(graceEnv) > 0 {
+		parsed, err := time.ParseDuration(graceEnv)
+		if err != nil {
+			log.Fatalf("[DISABLE] Failed to parse ACCESS_DELETE_GRACE_PERIOD, err: %s", err)
+		}
+		gracePeriod = parsed
+	}
+	stateFile := os.Getenv("ACCESS_DISABLE_STATE_FILE")
+	if len(stateFile) == 0 {
+		stateFile = getDefaultDisableStateFile()
+	}
+	ctx.Actions[types.DisableAction] = disableAction{gracePeriod, stateFile}
+}
+
+func getDefaultDisableStateFile() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "disabled-accesses.json"
+	}
+	return filepath.Join(cacheDir, "cloud-haunter", "disabled-accesses.json")
+}
+
+// Execute disables the active credentials. If the grace period is set, the credentials that have been disabled
+// for longer than the grace period are deleted. The time of disabling is stored in the state file, because the
+// cloud providers do not tell it. Credentials disabled by someone else are deleted after the grace period from
+// the first run that saw them disabled.
+func (a disableAction) Execute(op types.OpType, filters []types.FilterType, items []types.CloudItem) {
+	var toDisable, disabled []*types.Access
+	for _, item := range items {
+		switch t := item.GetItem().(type) {
+		case types.Access:
+			if _, ok := t.Tags[ctx.IgnoreLabel]; ok && !ctx.IgnoreLabelDisabled {
+				log.Debugf("[DISABLE] Ignoring access, because it has the ignore label: %s", t.Name)
+				continue
+			}
+			access := t
+			if access.State == types.Disabled {
+				disabled = append(disabled, &access)
+			} else {
+				toDisable = append(toDisable, &access)
+			}
+		default:
+			log.Debugf("[DISABLE] Ignoring cloud item: %s, because it's not a credential: %s", t, item.GetType())
+		}
+	}
+
+	now := time.Now()
+	state := disableState{}
+	var toDelete []*types.Access
+	if a.deleteGracePeriod > 0 {
+		state = a.loadState()
+		for _, access := range disabled {
+			disabledAt, ok := state[getDisableStateKey(access)]
+			if !ok {
+				state[getDisableStateKey(access)] = now
+			} else if disabledAt.Add(a.deleteGracePeriod).Before(now) {
+				toDelete = append(toDelete, access)
+			}
+		}
+	}
+	checkBlastRadius(types.DisableAction, op, filters, append(toCloudItems(toDisable), toCloudItems(toDelete)...))
+
+	var failures []string
+	if len(toDisable) > 0 {
+		succeeded, failed := executeOnAccesses("disable", toDisable, types.CloudProvider.DisableAccesses)
+		for _, access := range succeeded {
+			state[getDisableStateKey(access)] = now
+		}
+		failures = append(failures, failed...)
+	}
+	if len(toDelete) > 0 {
+		succeeded, failed := executeOnAccesses("delete", toDelete, types.CloudProvider.DeleteAccesses)
+		for _, access := range succeeded {
+			delete(state, getDisableStateKey(access))
+		}
+		failures = append(failures, failed...)
+	}
+	if a.deleteGracePeriod > 0 && !ctx.DryRun {
+		a.saveState(state)
+	}
+	if len(failures) > 0 {
+		panic(strings.Join(failures, "; "))
+	}
+}
+
+// executeOnAccesses executes the operation per cloud and returns the accesses of the clouds it succeeded on and the
+// failures of the other clouds, so the state of the succeeded clouds can be saved before failing
+func executeOnAccesses(operation string, accesses []*types.Access, execute func(types.CloudProvider, *types.AccessContainer) []error) ([]*types.Access, []string) {
+	accessesPerCloud := map[types.CloudType][]*types.Access{}
+	for _, access := range accesses {
+		accessesPerCloud[access.CloudType] = append(accessesPerCloud[access.CloudType], access)
+	}
+	var succeeded []*types.Access
+	var failures []string
+	for cloud, cloudAccesses := range accessesPerCloud {
+		log.Infof("[DISABLE] Execute %s on %d accesses on %s: %s", operation, len(cloudAccesses), cloud, strings.Join(getAccessNames(cloudAccesses), ","))
+		if errors := execute(ctx.CloudProviders[cloud](), types.NewAccessContainer(cloudAccesses)); len(errors) != 0 {
+			for _, err := range errors {
+				log.Errorf("[DISABLE] Failed to %s accesses on cloud: %s, err: %s", operation, cloud, err.Error())
+			}
+			failures = append(failures, fmt.Sprintf("[DISABLE] Failed to %s accesses on cloud: %s", operation, cloud))
+			continue
+		}
+		succeeded = append(succeeded, cloudAccesses...)
+	}
+	return succeeded, failures
+}
+
+// disableState contains the time of disabling the credentials by cloud and ID
+type disableState map[string]time.Time
+
+func getDisableStateKey(access *types.Access) string {
+	return access.CloudType.String() + ":" + access.ID
+}
+
+func (a disableAction) loadState() disableState {
+	state := disableState{}
+	raw, err := ioutil.ReadFile(a.stateFile)
+	if os.IsNotExist(err) {
+		return state
+	} else if err != nil {
+		panic(fmt.Sprintf("[DISABLE] Failed to read the state file %s, err: %s", a.stateFile, err))
+	}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		panic(fmt.Sprintf("[DISABLE] Failed to parse the state file %s, err: %s", a.stateFile, err))
+	}
+	return state
+}
+
+func (a disableAction) saveState(state disableState) {
+	raw, _ := json.MarshalIndent(state, "", "  ")
+	if err := os.MkdirAll(filepath.Dir(a.stateFile), 0700); err != nil {
+		log.Errorf("[DISABLE] Failed to create the directory of the state file %s, err: %s", a.stateFile, err)
+		return
+	}
+	if err := ioutil.WriteFile(a.stateFile, raw, 0600); err != nil {
+		log.Errorf("[DISABLE] Failed to write the state file %s, err: %s", a.stateFile, err)
+	}
+}
+
+func getAccessNames(accesses []*types.Access) []string {
+	result := make([]string, len(accesses))
+	for i, access := range accesses {
+		result[i] = fmt.Sprintf("%s:%s", access.Owner, access.Name)
+	}
+	return result
+}
+
+func toCloudItems(accesses []*types.Access) []types.CloudItem {
+	items := make([]types.CloudItem, len(accesses))
+	for i, access := range accesses {
+		items[i] = access
+	}
+	return items
+}
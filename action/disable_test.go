@@ -0,0 +1,122 @@
+package action
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type disableSuite struct {
+	suite.Suite
+	providers    map[types.CloudType]func() types.CloudProvider
+	mockProvider *mockProvider
+	stateFile    string
+}
+
+func (s *disableSuite) SetupSuite() {
+	s.providers = ctx.CloudProviders
+}
+
+func (s *disableSuite) SetupTest() {
+	s.mockProvider = &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return s.mockProvider
+		}}
+	s.stateFile = filepath.Join(s.T().TempDir(), "state", "disabled-accesses.json")
+}
+
+func (s *disableSuite) TearDownSuite() {
+	ctx.CloudProviders = s.providers
+}
+
+func (s *disableSuite) TestInitDisable() {
+	os.Setenv("ACCESS_DELETE_GRACE_PERIOD", "720h")
+	defer os.Unsetenv("ACCESS_DELETE_GRACE_PERIOD")
+
+	initDisable()
+	defer initDisable()
+
+	s.Equal(720*time.Hour, ctx.Actions[types.DisableAction].(disableAction).deleteGracePeriod)
+}
+
+func (s *disableSuite) TestDisableActiveAccesses() {
+	items := []types.CloudItem{
+		&types.Access{CloudType: types.AWS, ID: "active", State: types.Running},
+		&types.Access{CloudType: types.AWS, ID: "ignored", State: types.Running, Tags: types.Tags{ctx.IgnoreLabel: "true"}},
+		&types.Access{CloudType: types.AWS, ID: "disabled", State: types.Disabled},
+		&types.Instance{CloudType: types.AWS, ID: "instance"},
+	}
+
+	disableAction{stateFile: s.stateFile}.Execute(types.CloudAccess, []types.FilterType{}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+	_, err := os.Stat(s.stateFile)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *disableSuite) TestDeleteAfterGracePeriod() {
+	action := disableAction{deleteGracePeriod: time.Hour, stateFile: s.stateFile}
+	action.saveState(disableState{
+		"AWS:expired":       time.Now().Add(-2 * time.Hour),
+		"AWS:in-grace":      time.Now().Add(-30 * time.Minute),
+		"AWS:other-account": time.Now().Add(-2 * time.Hour),
+	})
+	items := []types.CloudItem{
+		&types.Access{CloudType: types.AWS, ID: "expired", State: types.Disabled},
+		&types.Access{CloudType: types.AWS, ID: "in-grace", State: types.Disabled},
+		&types.Access{CloudType: types.AWS, ID: "first-seen", State: types.Disabled},
+		&types.Access{CloudType: types.AWS, ID: "active", State: types.Running},
+	}
+
+	action.Execute(types.CloudAccess, []types.FilterType{}, items)
+
+	s.Equal(2, s.mockProvider.calls)
+	state := action.loadState()
+	s.NotContains(state, "AWS:expired")
+	s.Contains(state, "AWS:in-grace")
+	s.Contains(state, "AWS:first-seen")
+	s.Contains(state, "AWS:active")
+	s.Contains(state, "AWS:other-account")
+}
+
+func (s *disableSuite) TestDryRunDoesNotSaveState() {
+	ctx.DryRun = true
+	defer func() { ctx.DryRun = false }()
+	items := []types.CloudItem{
+		&types.Access{CloudType: types.AWS, ID: "active", State: types.Running},
+	}
+
+	disableAction{deleteGracePeriod: time.Hour, stateFile: s.stateFile}.Execute(types.CloudAccess, []types.FilterType{}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+	_, err := os.Stat(s.stateFile)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *disableSuite) TestSaveStateBeforeFailing() {
+	ctx.CloudProviders[types.GCP] = func() types.CloudProvider {
+		return &mockProvider{errs: []error{errors.New("error")}}
+	}
+	action := disableAction{deleteGracePeriod: time.Hour, stateFile: s.stateFile}
+	items := []types.CloudItem{
+		&types.Access{CloudType: types.AWS, ID: "active", State: types.Running},
+		&types.Access{CloudType: types.GCP, ID: "failing", State: types.Running},
+	}
+
+	s.Panics(func() { action.Execute(types.CloudAccess, []types.FilterType{}, items) })
+
+	state := action.loadState()
+	s.Contains(state, "AWS:active")
+	s.NotContains(state, "GCP:failing")
+}
+
+func TestDisableSuite(t *testing.T) {
+	suite.Run(t, new(disableSuite))
+}
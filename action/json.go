@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
 	"github.com/blentz/cloud-haunter/types"
 	"github.com/blentz/cloud-haunter/utils"
 	log "github.com/sirupsen/logrus"
@@ -19,6 +20,32 @@ type jsonAction struct {
 
 func (a jsonAction) Execute(op types.OpType, filter []types.FilterType, items []types.CloudItem) {
 	log.Infof("[JSON] Number of items generated by operation %s and filters %s on accounts %s: %d", op.String(), filter, utils.GetCloudAccountNames(), len(items))
-	out, _ := json.MarshalIndent(items, "", "  ")
+	log.Infof("[JSON] Estimated waste of the %d items: %s", len(items), pricing.EstimateTotal(items))
+	out, _ := json.MarshalIndent(withEstimatedCost(items), "", "  ")
 	fmt.Println(string(out))
 }
+
+// withEstimatedCost adds the EstimatedCost field to the JSON representation of the items, null if it is unknown.
+// An item that cannot be converted is written without the EstimatedCost field.
+func withEstimatedCost(items []types.CloudItem) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		fields := map[string]interface{}{}
+		raw, err := json.Marshal(item)
+		if err == nil {
+			err = json.Unmarshal(raw, &fields)
+		}
+		if err != nil {
+			log.Warnf("[JSON] Failed to add the estimated cost to %s %s, err: %s", item.GetType(), item.GetName(), err)
+			result = append(result, item)
+			continue
+		}
+		if cost, ok := pricing.Estimate(item); ok {
+			fields["EstimatedCost"] = cost
+		} else {
+			fields["EstimatedCost"] = nil
+		}
+		result = append(result, fields)
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
 	"github.com/blentz/cloud-haunter/types"
 	"github.com/blentz/cloud-haunter/utils"
 	log "github.com/sirupsen/logrus"
@@ -20,6 +21,7 @@ func (a logAction) Execute(op types.OpType, filter []types.FilterType, items []t
 	log.Infof("[LOG] Number of items generated by operation %s and filters %s on accounts %s: %d", op.String(), filter, utils.GetCloudAccountNames(), len(items))
 	for _, item := range items {
 		out, _ := json.Marshal(item.GetItem())
-		log.Infof("[%s] %s", item.GetCloudType(), string(out))
+		log.Infof("[%s] %s estimated cost: %s", item.GetCloudType(), string(out), pricing.EstimateString(item))
 	}
+	log.Infof("[LOG] Estimated waste of the %d items: %s", len(items), pricing.EstimateTotal(items))
 }
@@ -14,7 +14,8 @@ func TestNotificationInit(t *testing.T) {
 }
 
 type mockDispatcher struct {
-	calls int
+	calls    int
+	messages []string
 }
 
 func (d *mockDispatcher) GetName() string {
@@ -26,6 +27,12 @@ func (d *mockDispatcher) Send(op types.OpType, filters []types.FilterType, items
 	return nil
 }
 
+func (d *mockDispatcher) SendMessage(message string) error {
+	d.calls++
+	d.messages = append(d.messages, message)
+	return nil
+}
+
 type notificationSuite struct {
 	suite.Suite
 	dispatchers    map[string]types.Dispatcher
@@ -37,7 +44,7 @@ func (s *notificationSuite) SetupSuite() {
 }
 
 func (s *notificationSuite) SetupTest() {
-	s.mockDispatcher = &mockDispatcher{0}
+	s.mockDispatcher = &mockDispatcher{}
 	ctx.Dispatchers = map[string]types.Dispatcher{"mock": s.mockDispatcher}
 }
 
@@ -17,34 +17,64 @@ func init() {
 type stopAction struct {
 }
 
-func (s stopAction) Execute(_ types.OpType, _ []types.FilterType, items []types.CloudItem) {
+func (s stopAction) Execute(op types.OpType, filters []types.FilterType, items []types.CloudItem) {
 	instancesPerCloud := map[types.CloudType][]*types.Instance{}
 	databasesPerCloud := map[types.CloudType][]*types.Database{}
+	clustersPerCloud := map[types.CloudType][]*types.Cluster{}
+	notebooksPerCloud := map[types.CloudType][]*types.Notebook{}
+	var stopped []types.CloudItem
 	for _, item := range items {
 		switch t := item.GetItem().(type) {
 		case types.Instance:
 			instancesPerCloud[item.GetCloudType()] = append(instancesPerCloud[item.GetCloudType()], item.(*types.Instance))
+			stopped = append(stopped, item)
 		case types.Database:
+			if !t.IsStoppable() {
+				log.Debugf("[STOP] Ignoring database: %s, because %s databases cannot be stopped", item.GetName(), t.Type)
+				continue
+			}
 			databasesPerCloud[item.GetCloudType()] = append(databasesPerCloud[item.GetCloudType()], item.(*types.Database))
+			stopped = append(stopped, item)
+		case types.Cluster:
+			if !t.IsStoppable() {
+				log.Debugf("[STOP] Ignoring cluster: %s, because %s clusters cannot be stopped", item.GetName(), t.Type)
+				continue
+			}
+			clustersPerCloud[item.GetCloudType()] = append(clustersPerCloud[item.GetCloudType()], item.(*types.Cluster))
+			stopped = append(stopped, item)
+		case types.Notebook:
+			notebooksPerCloud[item.GetCloudType()] = append(notebooksPerCloud[item.GetCloudType()], item.(*types.Notebook))
+			stopped = append(stopped, item)
 		default:
 			log.Debugf("[STOP] Ignoring cloud item: %s, because it's not a stoppable resource: %s", t, item.GetType())
 		}
 	}
+	checkBlastRadius(types.StopAction, op, filters, stopped)
 
+	result := &actionResult{}
 	wg := sync.WaitGroup{}
 	if len(instancesPerCloud) > 0 {
 		wg.Add(len(instancesPerCloud))
-		stopInstances(instancesPerCloud, &wg)
+		stopInstances(instancesPerCloud, &wg, result)
 	}
 	if len(databasesPerCloud) > 0 {
 		wg.Add(len(databasesPerCloud))
-		stopDatabases(databasesPerCloud, &wg)
+		stopDatabases(databasesPerCloud, &wg, result)
+	}
+	if len(clustersPerCloud) > 0 {
+		wg.Add(len(clustersPerCloud))
+		stopClusters(clustersPerCloud, &wg, result)
+	}
+	if len(notebooksPerCloud) > 0 {
+		wg.Add(len(notebooksPerCloud))
+		stopNotebooks(notebooksPerCloud, &wg, result)
 	}
 
 	wg.Wait()
+	result.reportSavings("STOP")
 }
 
-func stopInstances(instancesPerCloud map[types.CloudType][]*types.Instance, wg *sync.WaitGroup) {
+func stopInstances(instancesPerCloud map[types.CloudType][]*types.Instance, wg *sync.WaitGroup, result *actionResult) {
 	for cloud, instances := range instancesPerCloud {
 		go func(cloud types.CloudType, instances []*types.Instance) {
 			defer wg.Done()
@@ -53,13 +83,17 @@ func stopInstances(instancesPerCloud map[types.CloudType][]*types.Instance, wg *
 				for _, err := range errors {
 					log.Errorf("[STOP] Failed to stop instances on cloud: %s, err: %s", cloud, err.Error())
 				}
-				panic(fmt.Sprintf("[STOP] Failed to stop instances on cloud: %s", cloud))
+				result.fail(fmt.Sprintf("[STOP] Failed to stop instances on cloud: %s", cloud))
+				return
+			}
+			for _, inst := range instances {
+				result.succeed(inst)
 			}
 		}(cloud, instances)
 	}
 }
 
-func stopDatabases(databasesPerCloud map[types.CloudType][]*types.Database, wg *sync.WaitGroup) {
+func stopDatabases(databasesPerCloud map[types.CloudType][]*types.Database, wg *sync.WaitGroup, result *actionResult) {
 	for cloud, databases := range databasesPerCloud {
 		go func(cloud types.CloudType, databases []*types.Database) {
 			defer wg.Done()
@@ -68,12 +102,55 @@ func stopDatabases(databasesPerCloud map[types.CloudType][]*types.Database, wg *
 				for _, err := range errors {
 					log.Errorf("[STOP] Failed to stop databases on cloud: %s, err: %s", cloud, err.Error())
 				}
-				panic(fmt.Sprintf("[STOP] Failed to stop databases on cloud: %s", cloud))
+				result.fail(fmt.Sprintf("[STOP] Failed to stop databases on cloud: %s", cloud))
+				return
+			}
+			for _, db := range databases {
+				result.succeed(db)
 			}
 		}(cloud, databases)
 	}
 }
 
+// stopClusters scales the node pools of the Kubernetes clusters to zero
+func stopClusters(clustersPerCloud map[types.CloudType][]*types.Cluster, wg *sync.WaitGroup, result *actionResult) {
+	for cloud, clusters := range clustersPerCloud {
+		go func(cloud types.CloudType, clusters []*types.Cluster) {
+			defer wg.Done()
+			log.Infof("[STOP] Stop %d clusters on %s: %s", len(clusters), cloud, strings.Join(getClusterNames(clusters), ","))
+			if errors := ctx.CloudProviders[cloud]().StopClusters(types.NewClusterContainer(clusters)); len(errors) != 0 {
+				for _, err := range errors {
+					log.Errorf("[STOP] Failed to stop clusters on cloud: %s, err: %s", cloud, err.Error())
+				}
+				result.fail(fmt.Sprintf("[STOP] Failed to stop clusters on cloud: %s", cloud))
+				return
+			}
+			for _, cluster := range clusters {
+				result.succeed(cluster)
+			}
+		}(cloud, clusters)
+	}
+}
+
+func stopNotebooks(notebooksPerCloud map[types.CloudType][]*types.Notebook, wg *sync.WaitGroup, result *actionResult) {
+	for cloud, notebooks := range notebooksPerCloud {
+		go func(cloud types.CloudType, notebooks []*types.Notebook) {
+			defer wg.Done()
+			log.Infof("[STOP] Stop %d notebooks on %s: %s", len(notebooks), cloud, strings.Join(getNotebookNames(notebooks), ","))
+			if errors := ctx.CloudProviders[cloud]().StopNotebooks(types.NewNotebookContainer(notebooks)); len(errors) != 0 {
+				for _, err := range errors {
+					log.Errorf("[STOP] Failed to stop notebooks on cloud: %s, err: %s", cloud, err.Error())
+				}
+				result.fail(fmt.Sprintf("[STOP] Failed to stop notebooks on cloud: %s", cloud))
+				return
+			}
+			for _, notebook := range notebooks {
+				result.succeed(notebook)
+			}
+		}(cloud, notebooks)
+	}
+}
+
 func getInstanceNames(instances []*types.Instance) []string {
 	result := make([]string, len(instances))
 	for i, inst := range instances {
@@ -89,3 +166,19 @@ func getDatabaseNames(databases []*types.Database) []string {
 	}
 	return result
 }
+
+func getClusterNames(clusters []*types.Cluster) []string {
+	result := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		result[i] = fmt.Sprintf("%s:%s", cluster.Type, cluster.Name)
+	}
+	return result
+}
+
+func getNotebookNames(notebooks []*types.Notebook) []string {
+	result := make([]string, len(notebooks))
+	for i, notebook := range notebooks {
+		result[i] = fmt.Sprintf("%s:%s", notebook.Type, notebook.Name)
+	}
+	return result
+}
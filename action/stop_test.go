@@ -0,0 +1,97 @@
+package action
+
+import (
+	"errors"
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopClusters(t *testing.T) {
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	provider := &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return provider
+		}}
+
+	stopAction{}.Execute(types.Clusters, []types.FilterType{types.IdleFilter}, []types.CloudItem{
+		&types.Cluster{CloudType: types.AWS, Type: types.EksCluster, Name: "eks"},
+		&types.Cluster{CloudType: types.AWS, Type: types.EksCluster, Name: "other"},
+	})
+
+	assert.Equal(t, 1, provider.calls)
+
+	stopAction{}.Execute(types.Clusters, []types.FilterType{types.IdleFilter}, []types.CloudItem{
+		&types.Cluster{CloudType: types.AWS, Type: types.EmrCluster, Name: "emr"},
+	})
+
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestStopDatabasesSkipsCaches(t *testing.T) {
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	provider := &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return provider
+		}}
+
+	stopAction{}.Execute(types.Databases, []types.FilterType{types.LongRunningFilter}, []types.CloudItem{
+		&types.Database{CloudType: types.AWS, Type: types.ElastiCacheDatabase, Name: "cache"},
+	})
+
+	assert.Equal(t, 0, provider.calls)
+
+	stopAction{}.Execute(types.Databases, []types.FilterType{types.LongRunningFilter}, []types.CloudItem{
+		&types.Database{CloudType: types.AWS, Type: types.RdsDatabase, Name: "rds"},
+		&types.Database{CloudType: types.AWS, Type: types.ElastiCacheDatabase, Name: "cache"},
+	})
+
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestStopNotebooks(t *testing.T) {
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	provider := &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return provider
+		}}
+
+	stopAction{}.Execute(types.Notebooks, []types.FilterType{types.LongRunningFilter}, []types.CloudItem{
+		&types.Notebook{CloudType: types.AWS, Type: types.SageMakerNotebook, Name: "notebook", State: types.Running},
+	})
+
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestStopReportsSavingsOfSucceededItems(t *testing.T) {
+	dispatchers := ctx.Dispatchers
+	defer func() { ctx.Dispatchers = dispatchers }()
+	dispatcher := &mockDispatcher{}
+	ctx.Dispatchers = map[string]types.Dispatcher{"mock": dispatcher}
+	providers := ctx.CloudProviders
+	defer func() { ctx.CloudProviders = providers }()
+	failingProvider := &mockProvider{errs: []error{errors.New("failed")}}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider { return &mockProvider{} },
+		types.GCP: func() types.CloudProvider { return failingProvider },
+	}
+
+	assert.PanicsWithValue(t, "[STOP] Failed to stop instances on cloud: GCP", func() {
+		stopAction{}.Execute(types.Instances, []types.FilterType{types.LongRunningFilter}, []types.CloudItem{
+			&types.Instance{CloudType: types.AWS, Name: "stopped", State: types.Running},
+			&types.Instance{CloudType: types.GCP, Name: "failed", State: types.Running},
+		})
+	})
+
+	assert.Equal(t, 1, failingProvider.calls)
+	assert.Equal(t, 1, len(dispatcher.messages))
+	assert.Contains(t, dispatcher.messages[0], "[STOP] Savings realised on 1 items")
+}
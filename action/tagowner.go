@@ -0,0 +1,78 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Actions[types.TagOwnerAction] = new(tagOwnerAction)
+}
+
+type tagOwnerAction struct {
+}
+
+// Execute writes the creators inferred from the audit logs into the owner label of the instances, disks, databases
+// and stacks that do not have one. The inferred creator is also set as the owner of the items.
+func (a tagOwnerAction) Execute(op types.OpType, filters []types.FilterType, items []types.CloudItem) {
+	itemsPerCloud := map[types.CloudType][]types.CloudItem{}
+	for _, item := range items {
+		var metadata map[string]string
+		var setOwner func(string)
+		switch t := item.(type) {
+		case *types.Instance:
+			metadata, setOwner = t.Metadata, func(owner string) { t.Owner = owner }
+		case *types.Disk:
+			metadata, setOwner = t.Metadata, func(owner string) { t.Owner = owner }
+		case *types.Database:
+			metadata, setOwner = t.Metadata, func(owner string) { t.Owner = owner }
+		case *types.Stack:
+			metadata, setOwner = t.Metadata, func(owner string) { t.Owner = owner }
+		default:
+			log.Debugf("[TAG_OWNER] Ignoring cloud item: %s, because it's not a taggable resource: %s", t, item.GetType())
+			continue
+		}
+		if _, ok := item.GetTags()[ctx.IgnoreLabel]; ok && !ctx.IgnoreLabelDisabled {
+			log.Debugf("[TAG_OWNER] Ignoring %s, because it has the ignore label: %s", item.GetType(), item.GetName())
+			continue
+		}
+		if owner := item.GetTags()[ctx.OwnerLabel]; len(owner) > 0 {
+			log.Debugf("[TAG_OWNER] Ignoring %s, because it already has an owner label: %s, owner: %s", item.GetType(), item.GetName(), owner)
+			continue
+		}
+		owner := types.GetInferredOwner(metadata)
+		if len(owner) == 0 {
+			log.Debugf("[TAG_OWNER] Ignoring %s, because its creator is unknown: %s", item.GetType(), item.GetName())
+			continue
+		}
+		log.Infof("[TAG_OWNER] Set label %s=%s on %s %s on %s", ctx.OwnerLabel, owner, item.GetType(), item.GetName(), item.GetCloudType())
+		setOwner(owner)
+		itemsPerCloud[item.GetCloudType()] = append(itemsPerCloud[item.GetCloudType()], item)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(itemsPerCloud))
+	result := &actionResult{}
+	for c, i := range itemsPerCloud {
+		go func(cloud types.CloudType, cloudItems []types.CloudItem) {
+			defer wg.Done()
+
+			log.Infof("[TAG_OWNER] Tag %d items on %s", len(cloudItems), cloud)
+			if errors := ctx.CloudProviders[cloud]().TagOwners(cloudItems); len(errors) != 0 {
+				for _, err := range errors {
+					log.Errorf("[TAG_OWNER] Failed to tag items on cloud: %s, err: %s", cloud, err.Error())
+				}
+				result.fail(fmt.Sprintf("[TAG_OWNER] Failed to tag items on cloud: %s", cloud))
+			}
+		}(c, i)
+	}
+	wg.Wait()
+	if len(result.failures) > 0 {
+		panic(strings.Join(result.failures, "; "))
+	}
+}
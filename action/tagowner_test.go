@@ -0,0 +1,77 @@
+package action
+
+import (
+	"errors"
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type tagOwnerSuite struct {
+	suite.Suite
+	providers    map[types.CloudType]func() types.CloudProvider
+	mockProvider *mockProvider
+}
+
+func (s *tagOwnerSuite) SetupSuite() {
+	s.providers = ctx.CloudProviders
+}
+
+func (s *tagOwnerSuite) SetupTest() {
+	s.mockProvider = &mockProvider{}
+	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
+		types.AWS: func() types.CloudProvider {
+			return s.mockProvider
+		}}
+}
+
+func (s *tagOwnerSuite) TearDownSuite() {
+	ctx.CloudProviders = s.providers
+}
+
+func (s *tagOwnerSuite) TestTagOwners() {
+	inferred := &types.Instance{CloudType: types.AWS, Metadata: map[string]string{"IAMUser": "creator"}}
+	unknown := &types.Instance{CloudType: types.AWS}
+	labeled := &types.Disk{CloudType: types.AWS, Tags: types.Tags{ctx.OwnerLabel: "owner"}, Metadata: map[string]string{"IAMUser": "creator"}}
+	ignored := &types.Database{CloudType: types.AWS, Tags: types.Tags{ctx.IgnoreLabel: "true"}, Metadata: map[string]string{"IAMUser": "creator"}}
+	items := []types.CloudItem{inferred, unknown, labeled, ignored, &types.Access{CloudType: types.AWS}}
+
+	tagOwnerAction{}.Execute(types.Instances, []types.FilterType{}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+	s.Equal("creator", inferred.Owner)
+	s.Equal("", unknown.Owner)
+	s.Equal("", labeled.Owner)
+	s.Equal("", ignored.Owner)
+}
+
+func (s *tagOwnerSuite) TestTagOwnersWithoutInferredOwner() {
+	items := []types.CloudItem{&types.Stack{CloudType: types.AWS}}
+
+	tagOwnerAction{}.Execute(types.Stacks, []types.FilterType{}, items)
+
+	s.Equal(0, s.mockProvider.calls)
+}
+
+func (s *tagOwnerSuite) TestTagOwnersFailsAfterAllClouds() {
+	s.mockProvider.errs = []error{errors.New("error")}
+	gcpProvider := &mockProvider{}
+	ctx.CloudProviders[types.GCP] = func() types.CloudProvider {
+		return gcpProvider
+	}
+	items := []types.CloudItem{
+		&types.Instance{CloudType: types.AWS, Metadata: map[string]string{"IAMUser": "creator"}},
+		&types.Instance{CloudType: types.GCP, Metadata: map[string]string{"PrincipalEmail": "creator"}},
+	}
+
+	s.PanicsWithValue("[TAG_OWNER] Failed to tag items on cloud: AWS", func() {
+		tagOwnerAction{}.Execute(types.Instances, []types.FilterType{}, items)
+	})
+	s.Equal(1, gcpProvider.calls)
+}
+
+func TestTagOwnerSuite(t *testing.T) {
+	suite.Run(t, new(tagOwnerSuite))
+}
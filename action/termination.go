@@ -1,10 +1,12 @@
 package action
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 
-	"fmt"
 	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
 	"github.com/blentz/cloud-haunter/types"
 	log "github.com/sirupsen/logrus"
 )
@@ -17,6 +19,8 @@ type terminationAction struct {
 }
 
 func (a terminationAction) Execute(op types.OpType, filters []types.FilterType, items []types.CloudItem) {
+	checkBlastRadius(types.TerminationAction, op, filters, items)
+	result := &actionResult{}
 	wg := sync.WaitGroup{}
 	wg.Add(len(ctx.CloudProviders))
 	for t, p := range ctx.CloudProviders {
@@ -47,6 +51,18 @@ func (a terminationAction) Execute(op types.OpType, filters []types.FilterType,
 					errors = deleteImages(provider, cloudItems)
 				case types.Alert:
 					errors = deleteAlerts(provider, cloudItems)
+				case types.Snapshot:
+					errors = deleteSnapshots(provider, cloudItems)
+				case types.Address:
+					errors = releaseAddresses(provider, cloudItems)
+				case types.LoadBalancer:
+					errors = deleteLoadBalancers(provider, cloudItems)
+				case types.Gateway:
+					errors = deleteGateways(provider, cloudItems)
+				case types.Function:
+					errors = deleteFunctions(provider, cloudItems)
+				case types.Network:
+					errors = deleteNetworks(provider, cloudItems)
 				default:
 					panic(fmt.Sprintf("[TERMINATION] Operation on type %T is not allowed", t))
 				}
@@ -55,12 +71,56 @@ func (a terminationAction) Execute(op types.OpType, filters []types.FilterType,
 					for _, err := range errors {
 						log.Errorf("[TERMINATION] Failed to terminate %ss on %s, err: %s", item.GetType(), cType, err.Error())
 					}
-					panic(fmt.Sprintf("[TERMINATION] Failed to terminate %ss on %s", item.GetType(), cType))
+					result.fail(fmt.Sprintf("[TERMINATION] Failed to terminate %ss on %s", item.GetType(), cType))
+					return
+				}
+				for _, cloudItem := range cloudItems {
+					result.succeed(*cloudItem)
 				}
 			}
 		}(t, p())
 	}
 	wg.Wait()
+	result.reportSavings("TERMINATION")
+}
+
+// actionResult collects the items the provider calls succeeded on and the failures of the calls from the goroutines
+// of the clouds. The errors of a provider call do not tell which items failed, so none of its items are counted.
+type actionResult struct {
+	mutex     sync.Mutex
+	succeeded []types.CloudItem
+	failures  []string
+}
+
+func (r *actionResult) succeed(items ...types.CloudItem) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.succeeded = append(r.succeeded, items...)
+}
+
+func (r *actionResult) fail(message string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failures = append(r.failures, message)
+}
+
+// reportSavings logs and sends to the dispatchers the estimated monthly cost that is no longer paid after the action
+// succeeded on the items, then panics if any of the provider calls failed
+func (r *actionResult) reportSavings(action string) {
+	if len(r.succeeded) > 0 {
+		savings := pricing.EstimateTotal(r.succeeded)
+		var message string
+		if ctx.DryRun {
+			message = fmt.Sprintf("[%s] Savings that would be realised on %d items (dry run): %s", action, len(r.succeeded), savings)
+		} else {
+			message = fmt.Sprintf("[%s] Savings realised on %d items: %s", action, len(r.succeeded), savings)
+		}
+		log.Info(message)
+		notifyDispatchers(message)
+	}
+	if len(r.failures) > 0 {
+		panic(strings.Join(r.failures, "; "))
+	}
 }
 
 func terminateInstances(provider types.CloudProvider, items []*types.CloudItem) []error {
@@ -107,3 +167,57 @@ func deleteAlerts(provider types.CloudProvider, items []*types.CloudItem) []erro
 	}
 	return provider.DeleteAlerts(types.NewAlertContainer(alerts))
 }
+
+func deleteSnapshots(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var snapshots []*types.Snapshot
+	for _, item := range items {
+		snapshot := (*item).GetItem().(types.Snapshot)
+		snapshots = append(snapshots, &snapshot)
+	}
+	return provider.DeleteSnapshots(types.NewSnapshotContainer(snapshots))
+}
+
+func releaseAddresses(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var addresses []*types.Address
+	for _, item := range items {
+		address := (*item).GetItem().(types.Address)
+		addresses = append(addresses, &address)
+	}
+	return provider.ReleaseAddresses(types.NewAddressContainer(addresses))
+}
+
+func deleteLoadBalancers(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var loadBalancers []*types.LoadBalancer
+	for _, item := range items {
+		loadBalancer := (*item).GetItem().(types.LoadBalancer)
+		loadBalancers = append(loadBalancers, &loadBalancer)
+	}
+	return provider.DeleteLoadBalancers(types.NewLoadBalancerContainer(loadBalancers))
+}
+
+func deleteGateways(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var gateways []*types.Gateway
+	for _, item := range items {
+		gateway := (*item).GetItem().(types.Gateway)
+		gateways = append(gateways, &gateway)
+	}
+	return provider.DeleteGateways(types.NewGatewayContainer(gateways))
+}
+
+func deleteFunctions(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var functions []*types.Function
+	for _, item := range items {
+		function := (*item).GetItem().(types.Function)
+		functions = append(functions, &function)
+	}
+	return provider.DeleteFunctions(types.NewFunctionContainer(functions))
+}
+
+func deleteNetworks(provider types.CloudProvider, items []*types.CloudItem) []error {
+	var networks []*types.Network
+	for _, item := range items {
+		network := (*item).GetItem().(types.Network)
+		networks = append(networks, &network)
+	}
+	return provider.DeleteNetworks(types.NewNetworkContainer(networks))
+}
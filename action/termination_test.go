@@ -10,6 +10,7 @@ import (
 
 type mockProvider struct {
 	calls int
+	errs  []error
 }
 
 func (p *mockProvider) GetAccountName() string {
@@ -32,17 +33,33 @@ func (p *mockProvider) TerminateStacks(*types.StackContainer) []error {
 
 func (p *mockProvider) StopInstances(*types.InstanceContainer) []error {
 	p.calls++
-	return nil
+	return p.errs
 }
 
-func (p mockProvider) StopDatabases(_ *types.DatabaseContainer) (e []error) {
+func (p *mockProvider) StopDatabases(_ *types.DatabaseContainer) (e []error) {
+	p.calls++
 	return
 }
 
+func (p *mockProvider) StopClusters(*types.ClusterContainer) []error {
+	p.calls++
+	return nil
+}
+
 func (p *mockProvider) GetAccesses() ([]*types.Access, error) {
 	return nil, nil
 }
 
+func (p *mockProvider) DisableAccesses(*types.AccessContainer) []error {
+	p.calls++
+	return p.errs
+}
+
+func (p *mockProvider) DeleteAccesses(*types.AccessContainer) []error {
+	p.calls++
+	return nil
+}
+
 func (p *mockProvider) GetDatabases() ([]*types.Database, error) {
 	return nil, nil
 }
@@ -87,6 +104,87 @@ func (p *mockProvider) GetClusters() ([]*types.Cluster, error) {
 	return nil, nil
 }
 
+func (p *mockProvider) GetMetricValues(types.CloudItem, types.MetricQuery) ([]float64, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) TagOwners([]types.CloudItem) []error {
+	p.calls++
+	return p.errs
+}
+
+func (p *mockProvider) GetSnapshots() ([]*types.Snapshot, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) DeleteSnapshots(*types.SnapshotContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetAddresses() ([]*types.Address, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) ReleaseAddresses(*types.AddressContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetLoadBalancers() ([]*types.LoadBalancer, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) DeleteLoadBalancers(*types.LoadBalancerContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetGateways() ([]*types.Gateway, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) DeleteGateways(*types.GatewayContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetNotebooks() ([]*types.Notebook, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) StopNotebooks(*types.NotebookContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetContainerImages() ([]*types.ContainerImage, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) CleanupContainerImages(containerImageContainer *types.ContainerImageContainer, retainedImages int) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetFunctions() ([]*types.Function, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) DeleteFunctions(*types.FunctionContainer) []error {
+	p.calls++
+	return nil
+}
+
+func (p *mockProvider) GetNetworks() ([]*types.Network, error) {
+	return nil, nil
+}
+
+func (p *mockProvider) DeleteNetworks(*types.NetworkContainer) []error {
+	p.calls++
+	return nil
+}
+
 type terminationSuite struct {
 	suite.Suite
 	providers    map[types.CloudType]func() types.CloudProvider
@@ -98,7 +196,7 @@ func (s *terminationSuite) SetupSuite() {
 }
 
 func (s *terminationSuite) SetupTest() {
-	s.mockProvider = &mockProvider{0}
+	s.mockProvider = &mockProvider{}
 	ctx.CloudProviders = map[types.CloudType]func() types.CloudProvider{
 		types.AWS: func() types.CloudProvider {
 			return s.mockProvider
@@ -122,6 +220,72 @@ func (s *terminationSuite) TestTermination() {
 	s.Equal(1, s.mockProvider.calls)
 }
 
+func (s *terminationSuite) TestSnapshotTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.Snapshot{CloudType: types.AWS, State: types.Orphaned},
+	}
+
+	action.Execute(types.Snapshots, []types.FilterType{types.UnusedFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
+func (s *terminationSuite) TestAddressTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.Address{CloudType: types.AWS, State: types.Unused},
+	}
+
+	action.Execute(types.Addresses, []types.FilterType{types.UnusedFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
+func (s *terminationSuite) TestLoadBalancerTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.LoadBalancer{CloudType: types.AWS, State: types.Unused},
+	}
+
+	action.Execute(types.LoadBalancers, []types.FilterType{types.UnusedFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
+func (s *terminationSuite) TestGatewayTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.Gateway{CloudType: types.AWS, Type: types.NatGateway, State: types.Running},
+	}
+
+	action.Execute(types.Gateways, []types.FilterType{types.IdleFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
+func (s *terminationSuite) TestFunctionTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.Function{CloudType: types.AWS, Type: types.LambdaFunction, State: types.Unused},
+	}
+
+	action.Execute(types.Functions, []types.FilterType{types.UnusedFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
+func (s *terminationSuite) TestNetworkTermination() {
+	action := terminationAction{}
+	items := []types.CloudItem{
+		types.Network{CloudType: types.AWS, Metadata: map[string]string{types.ResourceCountMetadataKey: "0"}},
+	}
+
+	action.Execute(types.Networks, []types.FilterType{types.EmptyFilter}, items)
+
+	s.Equal(1, s.mockProvider.calls)
+}
+
 func TestTerminationSuite(t *testing.T) {
 	suite.Run(t, new(terminationSuite))
 }
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p awsProvider) GetAddresses() ([]*types.Address, error) {
+	log.Debug("[AWS] Fetch elastic IPs")
+	ec2Clients, _ := p.getEc2AndCTClientsByRegion()
+	return getAddresses(ec2Clients)
+}
+
+func (p awsProvider) ReleaseAddresses(addresses *types.AddressContainer) []error {
+	log.Debug("[AWS] Release elastic IPs")
+	ec2Clients, _ := p.getEc2AndCTClientsByRegion()
+	return releaseAddresses(ec2Clients, addresses.Get(types.AWS))
+}
+
+func getAddresses(ec2Clients map[string]ec2Client) ([]*types.Address, error) {
+	addressChan := make(chan *types.Address)
+	wg := sync.WaitGroup{}
+	wg.Add(len(ec2Clients))
+
+	for r, c := range ec2Clients {
+		log.Debugf("[AWS] Fetching elastic IPs from region: %s", r)
+		go func(region string, ec2Client ec2Client) {
+			defer wg.Done()
+
+			result, err := ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{})
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the elastic IPs in region: %s, err: %s", region, err)
+				return
+			}
+			log.Debugf("[AWS] Processing elastic IPs (%d) in region: %s", len(result.Addresses), region)
+			for _, address := range result.Addresses {
+				addressChan <- newAddress(address, region)
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(addressChan)
+	}()
+
+	var addresses []*types.Address
+	for address := range addressChan {
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+func releaseAddresses(ec2Clients map[string]ec2Client, addresses []*types.Address) []error {
+	regionAddresses := map[string][]*types.Address{}
+	for _, address := range addresses {
+		regionAddresses[address.Region] = append(regionAddresses[address.Region], address)
+	}
+	log.Debugf("[AWS] Release elastic IPs: %v", regionAddresses)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionAddresses))
+	errChan := make(chan error)
+
+	for r, a := range regionAddresses {
+		go func(ec2Client ec2Client, region string, addresses []*types.Address) {
+			defer wg.Done()
+
+			for _, address := range addresses {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, elastic IP is not released: %s:%s, region: %s", address.Name, address.IP, region)
+					continue
+				}
+				log.Infof("[AWS] Release elastic IP: %s:%s, region: %s", address.Name, address.IP, region)
+				input := &ec2.ReleaseAddressInput{AllocationId: aws.String(address.ID)}
+				if address.Metadata["domain"] == ec2.DomainTypeStandard {
+					// EC2-Classic addresses have no allocation ID
+					input = &ec2.ReleaseAddressInput{PublicIp: aws.String(address.IP)}
+				}
+				if _, err := ec2Client.ReleaseAddress(input); err != nil {
+					log.Errorf("[AWS] Failed to release elastic IP: %s, err: %s", address.IP, err)
+					errChan <- err
+				}
+			}
+		}(ec2Clients[r], r, a)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// newAddress converts the elastic IP, its allocation time is not known
+func newAddress(address *ec2.Address, region string) *types.Address {
+	tags := getEc2Tags(address.Tags)
+	name, ok := tags["Name"]
+	if !ok {
+		name = aws.StringValue(address.PublicIp)
+	}
+	state := types.InUse
+	if address.AssociationId == nil && address.InstanceId == nil && address.NetworkInterfaceId == nil {
+		state = types.Unused
+	}
+	return &types.Address{
+		ID:        aws.StringValue(address.AllocationId),
+		Name:      name,
+		IP:        aws.StringValue(address.PublicIp),
+		State:     state,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		Region:    region,
+		Metadata:  map[string]string{"domain": aws.StringValue(address.Domain)},
+		Tags:      tags,
+	}
+}
@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAddresses(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	addresses, err := getAddresses(map[string]ec2Client{"eu-west-1": mockEc2Client{operationChannel: operationChannel}})
+	close(operationChannel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(addresses))
+	assert.Equal(t, "ip-1", addresses[0].ID)
+	assert.Equal(t, "eu-west-1", addresses[0].Region)
+	assert.Equal(t, types.Unused, addresses[0].State)
+	assert.Equal(t, "DescribeAddresses", <-operationChannel)
+}
+
+func TestNewAddressAssociated(t *testing.T) {
+	address := newAddress(&ec2.Address{
+		AllocationId:  aws.String("eipalloc-1"),
+		AssociationId: aws.String("eipassoc-1"),
+		PublicIp:      aws.String("1.2.3.4"),
+		Tags:          []*ec2.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+	}, "eu-west-1")
+
+	assert.Equal(t, types.InUse, address.State)
+	assert.Equal(t, "1.2.3.4", address.Name)
+	assert.Equal(t, "1.2.3.4", address.IP)
+	assert.Equal(t, "owner", address.Owner)
+}
+
+func TestReleaseAddresses(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := releaseAddresses(map[string]ec2Client{"eu-west-1": mockEc2Client{operationChannel: operationChannel}}, []*types.Address{
+		{ID: "eipalloc-1", IP: "1.2.3.4", Region: "eu-west-1", Metadata: map[string]string{"domain": "vpc"}},
+	})
+	close(operationChannel)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, "ReleaseAddress:eipalloc-1", <-operationChannel)
+}
@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	classicelb "github.com/aws/aws-sdk-go/service/elb"
 	elb "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/blentz/cloud-haunter/utils"
 
@@ -23,8 +25,15 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/emr"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
 	log "github.com/sirupsen/logrus"
@@ -53,7 +62,15 @@ type awsProvider struct {
 	cloudFormationClient map[string]*cloudformation.CloudFormation
 	rdsClients           map[string]*rds.RDS
 	elbClients           map[string]*elb.ELBV2
+	classicElbClients    map[string]*classicelb.ELB
 	cloudWatchClients    map[string]*cloudwatch.CloudWatch
+	emrClients           map[string]*emr.EMR
+	eksClients           map[string]*eks.EKS
+	elastiCacheClients   map[string]*elasticache.ElastiCache
+	redshiftClients      map[string]*redshift.Redshift
+	sageMakerClients     map[string]*sagemaker.SageMaker
+	ecrClients           map[string]*ecr.ECR
+	lambdaClients        map[string]*lambda.Lambda
 	iamClient            *iam.IAM
 }
 
@@ -98,9 +115,17 @@ func (p *awsProvider) init(getRegions func() ([]string, error)) error {
 	p.autoScalingClients = map[string]*autoscaling.AutoScaling{}
 	p.rdsClients = map[string]*rds.RDS{}
 	p.elbClients = map[string]*elb.ELBV2{}
+	p.classicElbClients = map[string]*classicelb.ELB{}
 	p.cloudTrailClient = map[string]*cloudtrail.CloudTrail{}
 	p.cloudFormationClient = map[string]*cloudformation.CloudFormation{}
 	p.cloudWatchClients = map[string]*cloudwatch.CloudWatch{}
+	p.emrClients = map[string]*emr.EMR{}
+	p.eksClients = map[string]*eks.EKS{}
+	p.elastiCacheClients = map[string]*elasticache.ElastiCache{}
+	p.redshiftClients = map[string]*redshift.Redshift{}
+	p.sageMakerClients = map[string]*sagemaker.SageMaker{}
+	p.ecrClients = map[string]*ecr.ECR{}
+	p.lambdaClients = map[string]*lambda.Lambda{}
 
 	for _, region := range regions {
 		if client, err := newEc2Client(region); err != nil {
@@ -127,6 +152,12 @@ func (p *awsProvider) init(getRegions func() ([]string, error)) error {
 			p.elbClients[region] = elbClient
 		}
 
+		if classicElbClient, err := newClassicElbClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create classic ELB client in region %s, err: %s", region, err.Error()))
+		} else {
+			p.classicElbClients[region] = classicElbClient
+		}
+
 		if ctClient, err := newCloudTrailClient(region); err != nil {
 			panic(fmt.Sprintf("[AWS] Failed to create CloudTrail client, err: %s", err.Error()))
 		} else {
@@ -144,6 +175,48 @@ func (p *awsProvider) init(getRegions func() ([]string, error)) error {
 		} else {
 			p.cloudWatchClients[region] = cwClient
 		}
+
+		if emrClient, err := newEmrClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create EMR client, err: %s", err.Error()))
+		} else {
+			p.emrClients[region] = emrClient
+		}
+
+		if eksClient, err := newEksClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create EKS client, err: %s", err.Error()))
+		} else {
+			p.eksClients[region] = eksClient
+		}
+
+		if elastiCacheClient, err := newElastiCacheClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create ElastiCache client, err: %s", err.Error()))
+		} else {
+			p.elastiCacheClients[region] = elastiCacheClient
+		}
+
+		if redshiftClient, err := newRedshiftClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create Redshift client, err: %s", err.Error()))
+		} else {
+			p.redshiftClients[region] = redshiftClient
+		}
+
+		if sageMakerClient, err := newSageMakerClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create SageMaker client, err: %s", err.Error()))
+		} else {
+			p.sageMakerClients[region] = sageMakerClient
+		}
+
+		if ecrClient, err := newEcrClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create ECR client, err: %s", err.Error()))
+		} else {
+			p.ecrClients[region] = ecrClient
+		}
+
+		if lambdaClient, err := newLambdaClient(region); err != nil {
+			panic(fmt.Sprintf("[AWS] Failed to create Lambda client, err: %s", err.Error()))
+		} else {
+			p.lambdaClients[region] = lambdaClient
+		}
 	}
 	if iamClient, err := newIamClient(); err != nil {
 		panic(fmt.Sprintf("[AWS] Failed to create IAM client, err: %s", err.Error()))
@@ -172,7 +245,12 @@ func (p awsProvider) GetAccountName() string {
 func (p awsProvider) GetInstances() ([]*types.Instance, error) {
 	log.Debug("[AWS] Fetching instances")
 	ec2Clients, ctClients := p.getEc2AndCTClientsByRegion()
-	return getInstances(ec2Clients, ctClients)
+	instances, err := getInstances(ec2Clients)
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(ctClients, newInstanceOwnerResources(instances))
+	return instances, nil
 }
 
 func (p awsProvider) GetStacks() ([]*types.Stack, error) {
@@ -182,9 +260,10 @@ func (p awsProvider) GetStacks() ([]*types.Stack, error) {
 	if cfError != nil {
 		return nil, cfError
 	}
+	ec2Clients, ctClients := p.getEc2AndCTClientsByRegion()
+	p.inferOwners(ctClients, newStackOwnerResources(cfStacks))
 	log.Debug("[AWS] Fetching native stacks")
 	elbClients := p.getElbClientsByRegion()
-	ec2Clients, _ := p.getEc2AndCTClientsByRegion()
 	cloudWatchClients := p.getCloudWatchClientsByRegion()
 	nativeStacks, nativeError := getNativeStacks(ec2Clients, elbClients, cloudWatchClients)
 	if nativeError != nil {
@@ -201,13 +280,32 @@ func (p awsProvider) GetDatabases() ([]*types.Database, error) {
 		rdsClients[k] = p.rdsClients[k]
 		ctClients[k] = p.cloudTrailClient[k]
 	}
-	return getDatabases(rdsClients, ctClients)
+	databases, err := getDatabases(rdsClients)
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(ctClients, newDatabaseOwnerResources(databases))
+
+	cacheClusters, err := getCacheClusters(p.getElastiCacheClientsByRegion())
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := getRedshiftClusters(p.getRedshiftClientsByRegion())
+	if err != nil {
+		return nil, err
+	}
+	return append(append(databases, cacheClusters...), warehouses...), nil
 }
 
 func (p awsProvider) GetDisks() ([]*types.Disk, error) {
 	log.Debug("[AWS] Fetch volumes")
-	ec2Clients, _ := p.getEc2AndCTClientsByRegion()
-	return getDisks(ec2Clients)
+	ec2Clients, ctClients := p.getEc2AndCTClientsByRegion()
+	disks, err := getDisks(ec2Clients)
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(ctClients, newDiskOwnerResources(disks))
+	return disks, nil
 }
 
 func (p awsProvider) getEc2AndCTClientsByRegion() (map[string]ec2Client, map[string]cloudTrailClient) {
@@ -415,11 +513,27 @@ func (p awsProvider) StopDatabases(databases *types.DatabaseContainer) []error {
 		go func(region string, databases []*types.Database) {
 			defer wg.Done()
 			for _, db := range databases {
-				log.Infof("[AWS] Stop database: %s", db.Name)
-				if _, err := p.rdsClients[region].StopDBInstance(&rds.StopDBInstanceInput{
-					DBInstanceIdentifier: &db.Name,
-				}); err != nil {
-					errChan <- err
+				if ctx.DryRun && db.IsStoppable() {
+					log.Infof("[AWS] Dry-run set, %s database is not stopped: %s", db.Type, db.Name)
+					continue
+				}
+				switch db.Type {
+				case types.RdsDatabase:
+					log.Infof("[AWS] Stop database: %s", db.Name)
+					if _, err := p.rdsClients[region].StopDBInstance(&rds.StopDBInstanceInput{
+						DBInstanceIdentifier: &db.Name,
+					}); err != nil {
+						errChan <- err
+					}
+				case types.RedshiftDatabase:
+					log.Infof("[AWS] Pause Redshift cluster: %s", db.Name)
+					if _, err := p.redshiftClients[region].PauseCluster(&redshift.PauseClusterInput{
+						ClusterIdentifier: &db.Name,
+					}); err != nil {
+						errChan <- err
+					}
+				default:
+					log.Debugf("[AWS] Skipping database: %s, because %s databases cannot be stopped", db.Name, db.Type)
 				}
 			}
 		}(r, db)
@@ -917,6 +1031,22 @@ func (p awsProvider) GetAccesses() ([]*types.Access, error) {
 	return getAccesses(p.iamClient)
 }
 
+func (p awsProvider) DisableAccesses(accesses *types.AccessContainer) []error {
+	log.Debug("[AWS] Disabling access keys")
+	return disableAccesses(p.iamClient, accesses.Get(types.AWS))
+}
+
+func (p awsProvider) DeleteAccesses(accesses *types.AccessContainer) []error {
+	log.Debug("[AWS] Deleting access keys")
+	return deleteAccesses(p.iamClient, accesses.Get(types.AWS))
+}
+
+func (p awsProvider) TagOwners(items []types.CloudItem) []error {
+	log.Debug("[AWS] Tagging owners")
+	ec2Clients, _ := p.getEc2AndCTClientsByRegion()
+	return tagOwners(ec2Clients, p.getRdsClientsByRegion(), p.getCFClientsByRegion(), items)
+}
+
 func (p awsProvider) GetAlerts() ([]*types.Alert, error) {
 	log.Debug("[AWS] Fetch alerts")
 	cloudWatchClients := p.getCloudWatchClientsByRegion()
@@ -949,6 +1079,7 @@ type ec2Client interface {
 	DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
 	DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
 	DeleteVpc(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error)
+	CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
 	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 	DeleteSubnet(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error)
 	DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
@@ -964,6 +1095,7 @@ type cfClient interface {
 	DeleteStack(input *cloudformation.DeleteStackInput) (*cloudformation.DeleteStackOutput, error)
 	DescribeStackResource(input *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error)
 	DescribeStackResources(input *cloudformation.DescribeStackResourcesInput) (*cloudformation.DescribeStackResourcesOutput, error)
+	ListStackResources(input *cloudformation.ListStackResourcesInput) (*cloudformation.ListStackResourcesOutput, error)
 	WaitUntilStackDeleteComplete(input *cloudformation.DescribeStacksInput) error
 }
 
@@ -974,12 +1106,16 @@ type cloudTrailClient interface {
 type iamClient interface {
 	ListUsers(*iam.ListUsersInput) (*iam.ListUsersOutput, error)
 	ListAccessKeys(*iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error)
+	GetAccessKeyLastUsed(*iam.GetAccessKeyLastUsedInput) (*iam.GetAccessKeyLastUsedOutput, error)
+	UpdateAccessKey(*iam.UpdateAccessKeyInput) (*iam.UpdateAccessKeyOutput, error)
+	DeleteAccessKey(*iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error)
 }
 
 type rdsClient interface {
 	DescribeDBInstances(input *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error)
 	ListTagsForResource(input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error)
 	ModifyDBInstance(input *rds.ModifyDBInstanceInput) (*rds.ModifyDBInstanceOutput, error)
+	AddTagsToResource(input *rds.AddTagsToResourceInput) (*rds.AddTagsToResourceOutput, error)
 }
 
 type elbClient interface {
@@ -991,16 +1127,17 @@ type elbClient interface {
 type cloudWatchClient interface {
 	DescribeAlarms(input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error)
 	DeleteAlarms(input *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error)
+	GetMetricStatistics(input *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error)
 }
 
-func getInstances(ec2Clients map[string]ec2Client, cloudTrailClients map[string]cloudTrailClient) ([]*types.Instance, error) {
+func getInstances(ec2Clients map[string]ec2Client) ([]*types.Instance, error) {
 	instChan := make(chan *types.Instance, 5)
 	wg := sync.WaitGroup{}
 	wg.Add(len(ec2Clients))
 
 	for r, c := range ec2Clients {
 		log.Debugf("[AWS] Fetching instances from: %s", r)
-		go func(region string, ec2Client ec2Client, cloudTrailClient cloudTrailClient) {
+		go func(region string, ec2Client ec2Client) {
 			defer wg.Done()
 
 			request := &ec2.DescribeInstancesInput{}
@@ -1013,14 +1150,7 @@ func getInstances(ec2Clients map[string]ec2Client, cloudTrailClients map[string]
 				log.Debugf("[AWS] Processing instances (%d): [%s] in region: %s", len(instanceResult.Reservations), instanceResult.Reservations, region)
 				for _, res := range instanceResult.Reservations {
 					for _, inst := range res.Instances {
-						i := newInstance(inst)
-						if len(i.Owner) == 0 && i.State == types.Running {
-							log.Debugf("[AWS] instance %s does not have an %s tag, check CloudTrail logs", i.Name, ctx.OwnerLabel)
-							if iamUser := getIAMUserFromCloudTrail(*inst.InstanceId, cloudTrailClient); iamUser != nil {
-								i.Metadata = map[string]string{"IAMUser": *iamUser}
-							}
-						}
-						instChan <- i
+						instChan <- newInstance(inst)
 					}
 				}
 				if instanceResult.NextToken != nil {
@@ -1030,7 +1160,7 @@ func getInstances(ec2Clients map[string]ec2Client, cloudTrailClients map[string]
 				}
 			}
 
-		}(r, c, cloudTrailClients[r])
+		}(r, c)
 	}
 
 	go func() {
@@ -1069,6 +1199,11 @@ func getCFStacks(cfClients map[string]cfClient) ([]*types.Stack, error) {
 				log.Debugf("[AWS] Processing stacks (%d) in region: %s: [%s]", len(stackResult.Stacks), region, stackResult.Stacks)
 				for _, s := range stackResult.Stacks {
 					stack := newStack(s, region)
+					if resourceCount, err := getCFResourceCount(cfClient, *s.StackName); err != nil {
+						log.Errorf("[AWS] Failed to count the resources of CloudFormation stack: %s in region: %s, err: %s", *s.StackName, region, err)
+					} else {
+						stack.Metadata[types.ResourceCountMetadataKey] = strconv.Itoa(resourceCount)
+					}
 					cfChan <- stack
 				}
 				if stackResult.NextToken != nil {
@@ -1110,6 +1245,27 @@ type AwsNativeStack struct {
 	Tags           types.Tags
 }
 
+// getCFResourceCount returns the number of resources of the stack that are not deleted yet
+func getCFResourceCount(cfClient cfClient, stackName string) (int, error) {
+	resourceCount := 0
+	request := &cloudformation.ListStackResourcesInput{StackName: &stackName}
+	for {
+		result, err := cfClient.ListStackResources(request)
+		if err != nil {
+			return 0, err
+		}
+		for _, summary := range result.StackResourceSummaries {
+			if aws.StringValue(summary.ResourceStatus) != cloudformation.ResourceStatusDeleteComplete {
+				resourceCount++
+			}
+		}
+		if result.NextToken == nil {
+			return resourceCount, nil
+		}
+		request.SetNextToken(*result.NextToken)
+	}
+}
+
 func getNativeStacks(ec2Clients map[string]ec2Client, elbClients map[string]elbClient, cloudWatchClients map[string]cloudWatchClient) ([]*types.Stack, error) {
 	stackChan := make(chan AwsNativeStack, 5)
 	wg := sync.WaitGroup{}
@@ -1351,7 +1507,7 @@ func getNativeStacks(ec2Clients map[string]ec2Client, elbClients map[string]elbC
 
 	var stacks []*types.Stack
 	for awsStack := range stackChan {
-		stacks = append(stacks, &types.Stack{
+		stack := &types.Stack{
 			ID:        awsStack.ID,
 			Name:      awsStack.ID,
 			Created:   awsStack.Created,
@@ -1369,7 +1525,10 @@ func getNativeStacks(ec2Clients map[string]ec2Client, elbClients map[string]elbC
 				METADATA_ELASTIC_IPS:     strings.Join(awsStack.ElasticIps, ","),
 				METADATA_ALARMS:          strings.Join(awsStack.Alarms, ","),
 			},
-		})
+		}
+		stack.Metadata[types.ResourceCountMetadataKey] = strconv.Itoa(len(awsStack.Instances) + len(awsStack.Volumes) +
+			len(awsStack.LoadBalancers) + len(awsStack.ElasticIps) + len(awsStack.Alarms))
+		stacks = append(stacks, stack)
 	}
 	return stacks, nil
 }
@@ -1461,36 +1620,6 @@ func getDisks(ec2Clients map[string]ec2Client) ([]*types.Disk, error) {
 	return disks, nil
 }
 
-type cloudTrailEvent struct {
-	UserIdentity struct {
-		T string `json:"type"`
-	} `json:"userIdentity"`
-}
-
-func getIAMUserFromCloudTrail(resourceID string, cloudTrailClient cloudTrailClient) *string {
-	attributes := []*cloudtrail.LookupAttribute{{
-		AttributeKey:   &(&types.S{S: "ResourceName"}).S,
-		AttributeValue: &(&types.S{S: resourceID}).S,
-	}}
-	events, err := cloudTrailClient.LookupEvents(&cloudtrail.LookupEventsInput{LookupAttributes: attributes})
-	if err != nil {
-		log.Errorf("[AWS] Failed to retrieve CloudTrail events for resource %s, err: %s", resourceID, err.Error())
-		return nil
-	}
-	for _, event := range events.Events {
-		var eventSource cloudTrailEvent
-		if err := json.Unmarshal([]byte(*event.CloudTrailEvent), &eventSource); err != nil {
-			log.Errorf("[AWS] Failed to unmarshal the CloudTrail event source, err: %s", err.Error())
-			return nil
-		}
-		idType := eventSource.UserIdentity.T
-		if idType == "IAMUser" || idType == "AssumedRole" {
-			return event.Username
-		}
-	}
-	return nil
-}
-
 func getAccesses(iamClient iamClient) ([]*types.Access, error) {
 	users, err := iamClient.ListUsers(&iam.ListUsersInput{MaxItems: &(&types.I64{I: 1000}).I})
 	if err != nil {
@@ -1512,22 +1641,77 @@ func getAccesses(iamClient iamClient) ([]*types.Access, error) {
 		for _, akm := range resp.AccessKeyMetadata {
 			accessKey := *akm.AccessKeyId
 			name := accessKey[0:10] + "..."
-			if *akm.Status != "Active" {
+			state := types.Running
+			if *akm.Status != iam.StatusTypeActive {
 				log.Debugf("[AWS] Access key is not active: %s", name)
-				continue
+				state = types.Disabled
+			}
+			var lastUsedDate *time.Time
+			usageUnknown := false
+			lastUsed, err := iamClient.GetAccessKeyLastUsed(&iam.GetAccessKeyLastUsedInput{AccessKeyId: akm.AccessKeyId})
+			if err != nil {
+				log.Warnf("[AWS] The usage of access key %s is unknown, the iam:GetAccessKeyLastUsed permission is required, err: %s", name, err)
+				usageUnknown = true
+			} else if lastUsed.AccessKeyLastUsed != nil {
+				lastUsedDate = lastUsed.AccessKeyLastUsed.LastUsedDate
 			}
 			accesses = append(accesses, &types.Access{
-				CloudType: types.AWS,
-				Name:      name,
-				Owner:     *akm.UserName,
-				Created:   getCreated(akm.CreateDate),
-				Tags:      types.Tags{},
+				CloudType:    types.AWS,
+				ID:           accessKey,
+				Name:         name,
+				Owner:        *akm.UserName,
+				Created:      getCreated(akm.CreateDate),
+				LastUsed:     lastUsedDate,
+				UsageUnknown: usageUnknown,
+				State:        state,
+				Tags:         types.Tags{},
 			})
 		}
 	}
 	return accesses, nil
 }
 
+func disableAccesses(iamClient iamClient, accesses []*types.Access) []error {
+	var errs []error
+	for _, access := range accesses {
+		if ctx.DryRun {
+			log.Infof("[AWS] Dry-run set, access key %s of user %s is not disabled", access.Name, access.Owner)
+			continue
+		}
+		log.Infof("[AWS] Disabling access key %s of user %s", access.Name, access.Owner)
+		_, err := iamClient.UpdateAccessKey(&iam.UpdateAccessKeyInput{
+			AccessKeyId: &access.ID,
+			UserName:    &access.Owner,
+			Status:      aws.String(iam.StatusTypeInactive),
+		})
+		if err != nil {
+			log.Errorf("[AWS] Failed to disable access key %s of user %s, err: %s", access.Name, access.Owner, err)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func deleteAccesses(iamClient iamClient, accesses []*types.Access) []error {
+	var errs []error
+	for _, access := range accesses {
+		if ctx.DryRun {
+			log.Infof("[AWS] Dry-run set, access key %s of user %s is not deleted", access.Name, access.Owner)
+			continue
+		}
+		log.Infof("[AWS] Deleting access key %s of user %s", access.Name, access.Owner)
+		_, err := iamClient.DeleteAccessKey(&iam.DeleteAccessKeyInput{
+			AccessKeyId: &access.ID,
+			UserName:    &access.Owner,
+		})
+		if err != nil {
+			log.Errorf("[AWS] Failed to delete access key %s of user %s, err: %s", access.Name, access.Owner, err)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 func getAlerts(cloudWatchClients map[string]cloudWatchClient, ec2Clients map[string]ec2Client) ([]*types.Alert, error) {
 	alertChan := make(chan *types.Alert)
 	wg := sync.WaitGroup{}
@@ -1660,14 +1844,14 @@ func deleteAlerts(cloudWatchClients map[string]cloudWatchClient, alerts []*types
 	return errors
 }
 
-func getDatabases(rdsClients map[string]rdsClient, cloudTrailClients map[string]cloudTrailClient) ([]*types.Database, error) {
+func getDatabases(rdsClients map[string]rdsClient) ([]*types.Database, error) {
 	dbChan := make(chan *types.Database)
 	wg := sync.WaitGroup{}
 	wg.Add(len(rdsClients))
 
 	for r, c := range rdsClients {
 		log.Debugf("[AWS] Fetching RDS instances from: %s", r)
-		go func(region string, rdsClient rdsClient, cloudTrailClient cloudTrailClient) {
+		go func(region string, rdsClient rdsClient) {
 			defer wg.Done()
 
 			result, err := rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
@@ -1684,17 +1868,10 @@ func getDatabases(rdsClients map[string]rdsClient, cloudTrailClients map[string]
 				if err != nil {
 					log.Debugf("[AWS] Cannot list tags for DB: %s", *db.DBName)
 				}
-				d := newDatabase(*db, tags)
-				if d.State == types.Running && len(d.Owner) == 0 {
-					log.Debugf("[AWS] Check CloudTrail for database: %s", d.Name)
-					if iamUser := getIAMUserFromCloudTrail(d.Name, cloudTrailClient); iamUser != nil {
-						d.Metadata = map[string]string{"IAMUser": *iamUser}
-					}
-				}
-				dbChan <- d
+				dbChan <- newDatabase(*db, tags)
 			}
 
-		}(r, c, cloudTrailClients[r])
+		}(r, c)
 	}
 
 	go func() {
@@ -1820,6 +1997,76 @@ func newCloudWatchClient(region string) (*cloudwatch.CloudWatch, error) {
 	return cloudwatch.New(awsSession), nil
 }
 
+func newEmrClient(region string) (*emr.EMR, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emr.New(awsSession), nil
+}
+
+func newEksClient(region string) (*eks.EKS, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eks.New(awsSession), nil
+}
+
+func newElastiCacheClient(region string) (*elasticache.ElastiCache, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return elasticache.New(awsSession), nil
+}
+
+func newRedshiftClient(region string) (*redshift.Redshift, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return redshift.New(awsSession), nil
+}
+
+func newSageMakerClient(region string) (*sagemaker.SageMaker, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sagemaker.New(awsSession), nil
+}
+
+func newEcrClient(region string) (*ecr.ECR, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ecr.New(awsSession), nil
+}
+
+func newLambdaClient(region string) (*lambda.Lambda, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lambda.New(awsSession), nil
+}
+
 func newElbClient(region string) (*elb.ELBV2, error) {
 	awsSession, err := newSession(func(config *aws.Config) {
 		config.Region = &region
@@ -1830,6 +2077,16 @@ func newElbClient(region string) (*elb.ELBV2, error) {
 	return elb.New(awsSession), nil
 }
 
+func newClassicElbClient(region string) (*classicelb.ELB, error) {
+	awsSession, err := newSession(func(config *aws.Config) {
+		config.Region = &region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return classicelb.New(awsSession), nil
+}
+
 func newSession(configure func(*aws.Config)) (*session.Session, error) {
 	httpClient := &http.Client{
 		Transport: &http.Transport{
@@ -1968,13 +2225,16 @@ func newDatabase(rds rds.DBInstance, tagList *rds.ListTagsForResourceOutput) *ty
 	return &types.Database{
 		ID:           *rds.DbiResourceId,
 		Name:         *rds.DBInstanceIdentifier,
+		Type:         types.RdsDatabase,
 		Created:      getCreated(rds.InstanceCreateTime),
 		Region:       getRegionFromAvailabilityZone(rds.AvailabilityZone),
 		InstanceType: *rds.DBInstanceClass,
+		NodeCount:    1,
 		State:        getDatabaseState(rds),
 		Owner:        tags[ctx.OwnerLabel],
 		Tags:         tags,
 		CloudType:    types.AWS,
+		Metadata:     map[string]string{"arn": aws.StringValue(rds.DBInstanceArn)},
 	}
 }
 
@@ -2053,6 +2313,3 @@ func getRegionFromAvailabilityZone(az *string) string {
 	return (*az)[0 : len(*az)-1]
 }
 
-func (p awsProvider) GetClusters() ([]*types.Cluster, error) {
-	return nil, errors.New("NotImplemented")
-}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -36,9 +38,8 @@ func TestProviderInit(t *testing.T) {
 
 func TestGetRunningInstances(t *testing.T) {
 	ec2Clients := map[string]ec2Client{"region": mockEc2Client{operationChannel: make(chan string, 10)}}
-	ctClients := map[string]cloudTrailClient{"region": mockCtClient{}}
 
-	instances, _ := getInstances(ec2Clients, ctClients)
+	instances, _ := getInstances(ec2Clients)
 
 	assert.Equal(t, 1, len(instances))
 }
@@ -46,7 +47,34 @@ func TestGetRunningInstances(t *testing.T) {
 func TestGetAccesses(t *testing.T) {
 	accesses, _ := getAccesses(mockIamClient{})
 
-	assert.Equal(t, 1, len(accesses))
+	assert.Equal(t, 2, len(accesses))
+	assert.Equal(t, "ACCESSKEY_1", accesses[0].ID)
+	assert.Equal(t, types.Running, accesses[0].State)
+	assert.Equal(t, types.Disabled, accesses[1].State)
+	assert.Equal(t, NOW.Add(-time.Hour), *accesses[0].LastUsed)
+	assert.False(t, accesses[0].UsageUnknown)
+}
+
+func TestGetAccessesWithUnknownUsage(t *testing.T) {
+	accesses, err := getAccesses(mockIamClient{lastUsedErr: errors.New("access denied")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(accesses))
+	assert.Nil(t, accesses[0].LastUsed)
+	assert.True(t, accesses[0].UsageUnknown)
+}
+
+func TestDisableAndDeleteAccesses(t *testing.T) {
+	accessKeyChannel := make(chan string, 10)
+	accesses := []*types.Access{
+		{CloudType: types.AWS, ID: "ACCESSKEY_1", Owner: "user"},
+	}
+
+	disableAccesses(mockIamClient{accessKeyChannel: accessKeyChannel}, accesses)
+	deleteAccesses(mockIamClient{accessKeyChannel: accessKeyChannel}, accesses)
+
+	assert.Equal(t, "ACCESSKEY_1:Inactive", <-accessKeyChannel)
+	assert.Equal(t, "ACCESSKEY_1:deleted", <-accessKeyChannel)
 }
 
 func TestGetRegions(t *testing.T) {
@@ -311,6 +339,17 @@ func TestGetNativeStacks(t *testing.T) {
 	assert.Equal(t, "vol-2", stack.Metadata[METADATA_VOLUMES])
 	assert.Equal(t, "default-group-id,custom-group-id", stack.Metadata[METADATA_SECURITY_GROUPS])
 	assert.Equal(t, "ip-1", stack.Metadata[METADATA_ELASTIC_IPS])
+	assert.Equal(t, "5", stack.Metadata[types.ResourceCountMetadataKey])
+}
+
+func TestGetCFResourceCount(t *testing.T) {
+	operationChannel := make(chan string, 1)
+
+	resourceCount, err := getCFResourceCount(mockCfClient{operationChannel: operationChannel}, "stack")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, resourceCount)
+	assert.Equal(t, "ListStackResources", <-operationChannel)
 }
 
 type mockEc2Client struct {
@@ -395,6 +434,11 @@ func (t mockEc2Client) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*
 	return nil, nil
 }
 
+func (t mockEc2Client) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	t.operationChannel <- "CreateTags:" + strings.Join(aws.StringValueSlice(input.Resources), ",") + ":" + *input.Tags[0].Value
+	return nil, nil
+}
+
 func (t mockEc2Client) DeleteVpc(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
 	t.operationChannel <- "DeleteVpc"
 	return nil, nil
@@ -477,6 +521,8 @@ func (t mockCtClient) LookupEvents(input *cloudtrail.LookupEventsInput) (*cloudt
 }
 
 type mockIamClient struct {
+	accessKeyChannel chan string
+	lastUsedErr      error
 }
 
 func (t mockIamClient) ListUsers(*iam.ListUsersInput) (*iam.ListUsersOutput, error) {
@@ -509,6 +555,27 @@ func (t mockIamClient) ListAccessKeys(*iam.ListAccessKeysInput) (*iam.ListAccess
 	}, nil
 }
 
+func (t mockIamClient) GetAccessKeyLastUsed(input *iam.GetAccessKeyLastUsedInput) (*iam.GetAccessKeyLastUsedOutput, error) {
+	if t.lastUsedErr != nil {
+		return nil, t.lastUsedErr
+	}
+	lastUsed := NOW.Add(-time.Hour)
+	return &iam.GetAccessKeyLastUsedOutput{
+		AccessKeyLastUsed: &iam.AccessKeyLastUsed{LastUsedDate: &lastUsed},
+		UserName:          &(&types.S{S: "user"}).S,
+	}, nil
+}
+
+func (t mockIamClient) UpdateAccessKey(input *iam.UpdateAccessKeyInput) (*iam.UpdateAccessKeyOutput, error) {
+	t.accessKeyChannel <- *input.AccessKeyId + ":" + *input.Status
+	return &iam.UpdateAccessKeyOutput{}, nil
+}
+
+func (t mockIamClient) DeleteAccessKey(input *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
+	t.accessKeyChannel <- *input.AccessKeyId + ":deleted"
+	return &iam.DeleteAccessKeyOutput{}, nil
+}
+
 func newTestInstance() *ec2.Instance {
 	return &ec2.Instance{
 		InstanceId:   &(&types.S{S: "ID"}).S,
@@ -584,6 +651,22 @@ func (t mockCfClient) DescribeStackResources(input *cloudformation.DescribeStack
 	}, nil
 }
 
+func (t mockCfClient) ListStackResources(input *cloudformation.ListStackResourcesInput) (*cloudformation.ListStackResourcesOutput, error) {
+	t.operationChannel <- "ListStackResources"
+	return &cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []*cloudformation.StackResourceSummary{
+			{
+				ResourceType:   &(&types.S{S: "AWS::EC2::VPC"}).S,
+				ResourceStatus: &(&types.S{S: cloudformation.ResourceStatusCreateComplete}).S,
+			},
+			{
+				ResourceType:   &(&types.S{S: "AWS::EC2::Instance"}).S,
+				ResourceStatus: &(&types.S{S: cloudformation.ResourceStatusDeleteComplete}).S,
+			},
+		},
+	}, nil
+}
+
 func (t mockCfClient) WaitUntilStackDeleteComplete(input *cloudformation.DescribeStacksInput) error {
 	t.operationChannel <- "WaitUntilStackDeleteComplete"
 	return nil
@@ -603,6 +686,11 @@ func (t mockRdsClient) ListTagsForResource(input *rds.ListTagsForResourceInput)
 	return nil, nil
 }
 
+func (t mockRdsClient) AddTagsToResource(input *rds.AddTagsToResourceInput) (*rds.AddTagsToResourceOutput, error) {
+	t.operationChannel <- "AddTagsToResource:" + *input.ResourceName + ":" + *input.Tags[0].Value
+	return nil, nil
+}
+
 func (t mockRdsClient) ModifyDBInstance(input *rds.ModifyDBInstanceInput) (*rds.ModifyDBInstanceOutput, error) {
 	t.operationChannel <- "ModifyDBInstance"
 	return nil, nil
@@ -661,6 +749,16 @@ func (t mockCwClient) DescribeAlarms(input *cloudwatch.DescribeAlarmsInput) (*cl
 	}, nil
 }
 
+func (t mockCwClient) GetMetricStatistics(input *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	t.operationChannel <- fmt.Sprintf("GetMetricStatistics:%s:%d", *input.MetricName, *input.Period)
+	return &cloudwatch.GetMetricStatisticsOutput{
+		Datapoints: []*cloudwatch.Datapoint{
+			{Timestamp: aws.Time(input.StartTime.Add(time.Hour)), Average: aws.Float64(2), Sum: aws.Float64(20)},
+			{Timestamp: input.StartTime, Average: aws.Float64(1), Sum: aws.Float64(10)},
+		},
+	}, nil
+}
+
 func (t mockCwClient) DeleteAlarms(input *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) {
 	t.operationChannel <- "DeleteAlarms:" + strings.Join(aws.StringValueSlice(input.AlarmNames), ",")
 	return nil, nil
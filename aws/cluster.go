@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/emr"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// activeEmrClusterStates are the states of the EMR clusters that are billed
+var activeEmrClusterStates = []string{emr.ClusterStateStarting, emr.ClusterStateBootstrapping, emr.ClusterStateRunning, emr.ClusterStateWaiting}
+
+type emrClient interface {
+	ListClusters(input *emr.ListClustersInput) (*emr.ListClustersOutput, error)
+	DescribeCluster(input *emr.DescribeClusterInput) (*emr.DescribeClusterOutput, error)
+}
+
+func (p awsProvider) GetClusters() ([]*types.Cluster, error) {
+	log.Debug("[AWS] Fetch EMR and EKS clusters")
+	emrClients := map[string]emrClient{}
+	for k := range p.emrClients {
+		emrClients[k] = p.emrClients[k]
+	}
+	clusters, err := getClusters(emrClients)
+	if err != nil {
+		return nil, err
+	}
+	eksClusters, err := getEksClusters(p.getEksClientsByRegion())
+	if err != nil {
+		return nil, err
+	}
+	return append(clusters, eksClusters...), nil
+}
+
+// StopClusters scales the node groups of the EKS clusters to zero, EMR clusters cannot be stopped
+func (p awsProvider) StopClusters(clusters *types.ClusterContainer) []error {
+	log.Debug("[AWS] Stop clusters")
+	var eksClusters []*types.Cluster
+	for _, cluster := range clusters.Get(types.AWS) {
+		if cluster.Type != types.EksCluster {
+			log.Debugf("[AWS] Skipping cluster: %s, because %s clusters cannot be stopped", cluster.Name, cluster.Type)
+			continue
+		}
+		eksClusters = append(eksClusters, cluster)
+	}
+	return stopEksClusters(p.getEksClientsByRegion(), eksClusters)
+}
+
+func getClusters(emrClients map[string]emrClient) ([]*types.Cluster, error) {
+	clusterChan := make(chan *types.Cluster)
+	wg := sync.WaitGroup{}
+	wg.Add(len(emrClients))
+
+	for r, c := range emrClients {
+		log.Debugf("[AWS] Fetching EMR clusters from: %s", r)
+		go func(region string, emrClient emrClient) {
+			defer wg.Done()
+
+			input := &emr.ListClustersInput{ClusterStates: aws.StringSlice(activeEmrClusterStates)}
+			for {
+				result, err := emrClient.ListClusters(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the EMR clusters in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing EMR clusters (%d) in region: %s", len(result.Clusters), region)
+				for _, summary := range result.Clusters {
+					// the tags are not part of the summary
+					cluster, err := emrClient.DescribeCluster(&emr.DescribeClusterInput{ClusterId: summary.Id})
+					if err != nil {
+						log.Errorf("[AWS] Failed to describe the EMR cluster %s in region: %s, err: %s", aws.StringValue(summary.Id), region, err)
+						continue
+					}
+					clusterChan <- newCluster(cluster.Cluster, region)
+				}
+				if result.Marker == nil {
+					break
+				}
+				input.Marker = result.Marker
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(clusterChan)
+	}()
+
+	var clusters []*types.Cluster
+	for cluster := range clusterChan {
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+func newCluster(cluster *emr.Cluster, region string) *types.Cluster {
+	tags := getEmrTags(cluster.Tags)
+	var created *time.Time
+	if cluster.Status != nil && cluster.Status.Timeline != nil {
+		created = cluster.Status.Timeline.CreationDateTime
+	}
+	return &types.Cluster{
+		Uuid:      aws.StringValue(cluster.Id),
+		Name:      aws.StringValue(cluster.Name),
+		Type:      types.EmrCluster,
+		Created:   getCreated(created),
+		Tags:      tags,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		State:     getClusterState(cluster.Status),
+		Region:    region,
+		Metadata:  map[string]string{"arn": aws.StringValue(cluster.ClusterArn)},
+	}
+}
+
+func getEmrTags(emrTags []*emr.Tag) types.Tags {
+	tags := make(types.Tags, 0)
+	for _, t := range emrTags {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return tags
+}
+
+func getClusterState(status *emr.ClusterStatus) types.State {
+	if status == nil {
+		return types.Unknown
+	}
+	switch aws.StringValue(status.State) {
+	case emr.ClusterStateStarting, emr.ClusterStateBootstrapping:
+		return types.Starting
+	case emr.ClusterStateRunning, emr.ClusterStateWaiting:
+		return types.Running
+	case emr.ClusterStateTerminating:
+		return types.Deleting
+	case emr.ClusterStateTerminated:
+		return types.Terminated
+	case emr.ClusterStateTerminatedWithErrors:
+		return types.Error
+	}
+	return types.Unknown
+}
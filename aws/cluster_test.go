@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/emr"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEmrClient struct {
+	listInputs []*emr.ListClustersInput
+}
+
+func (c *mockEmrClient) ListClusters(input *emr.ListClustersInput) (*emr.ListClustersOutput, error) {
+	c.listInputs = append(c.listInputs, input)
+	if input.Marker == nil {
+		return &emr.ListClustersOutput{Clusters: []*emr.ClusterSummary{{Id: aws.String("j-1")}}, Marker: aws.String("next")}, nil
+	}
+	return &emr.ListClustersOutput{Clusters: []*emr.ClusterSummary{{Id: aws.String("j-2")}}}, nil
+}
+
+func (c *mockEmrClient) DescribeCluster(input *emr.DescribeClusterInput) (*emr.DescribeClusterOutput, error) {
+	return &emr.DescribeClusterOutput{Cluster: &emr.Cluster{
+		Id:   input.ClusterId,
+		Name: aws.String("cluster-" + *input.ClusterId),
+		Tags: []*emr.Tag{{Key: aws.String("Owner"), Value: aws.String("owner")}},
+		Status: &emr.ClusterStatus{
+			State:    aws.String(emr.ClusterStateWaiting),
+			Timeline: &emr.ClusterTimeline{CreationDateTime: aws.Time(time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC))},
+		},
+	}}, nil
+}
+
+func TestGetClusters(t *testing.T) {
+	client := &mockEmrClient{}
+
+	clusters, err := getClusters(map[string]emrClient{"region": client})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(client.listInputs))
+	assert.Equal(t, activeEmrClusterStates, aws.StringValueSlice(client.listInputs[0].ClusterStates))
+	assert.Equal(t, 2, len(clusters))
+	assert.Equal(t, "j-1", clusters[0].Uuid)
+	assert.Equal(t, "cluster-j-1", clusters[0].Name)
+	assert.Equal(t, types.Running, clusters[0].State)
+	assert.Equal(t, types.AWS, clusters[0].CloudType)
+	assert.Equal(t, "region", clusters[0].Region)
+	assert.Equal(t, "owner", clusters[0].Tags["Owner"])
+	assert.Equal(t, time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC), clusters[0].Created)
+}
+
+func TestGetClusterState(t *testing.T) {
+	assert.Equal(t, types.Starting, getClusterState(&emr.ClusterStatus{State: aws.String(emr.ClusterStateBootstrapping)}))
+	assert.Equal(t, types.Deleting, getClusterState(&emr.ClusterStatus{State: aws.String(emr.ClusterStateTerminating)}))
+	assert.Equal(t, types.Unknown, getClusterState(nil))
+}
@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type ecrClient interface {
+	DescribeRepositories(input *ecr.DescribeRepositoriesInput) (*ecr.DescribeRepositoriesOutput, error)
+	DescribeImages(input *ecr.DescribeImagesInput) (*ecr.DescribeImagesOutput, error)
+	ListTagsForResource(input *ecr.ListTagsForResourceInput) (*ecr.ListTagsForResourceOutput, error)
+	BatchDeleteImage(input *ecr.BatchDeleteImageInput) (*ecr.BatchDeleteImageOutput, error)
+}
+
+func (p awsProvider) getEcrClientsByRegion() map[string]ecrClient {
+	ecrClients := map[string]ecrClient{}
+	for k := range p.ecrClients {
+		ecrClients[k] = p.ecrClients[k]
+	}
+	return ecrClients
+}
+
+func (p awsProvider) GetContainerImages() ([]*types.ContainerImage, error) {
+	log.Debug("[AWS] Fetch ECR images")
+	return getContainerImages(p.getEcrClientsByRegion())
+}
+
+func (p awsProvider) CleanupContainerImages(containerImages *types.ContainerImageContainer, retainedImages int) []error {
+	log.Debugf("[AWS] Cleaning up ECR images, keeping the %d most recent tagged images per repository", retainedImages)
+	return deleteContainerImages(p.getEcrClientsByRegion(), containerImages.GetOutdated(types.AWS, retainedImages))
+}
+
+func getContainerImages(ecrClients map[string]ecrClient) ([]*types.ContainerImage, error) {
+	imageChan := make(chan *types.ContainerImage)
+	wg := sync.WaitGroup{}
+	wg.Add(len(ecrClients))
+
+	for r, c := range ecrClients {
+		log.Debugf("[AWS] Fetching ECR images from: %s", r)
+		go func(region string, ecrClient ecrClient) {
+			defer wg.Done()
+
+			input := &ecr.DescribeRepositoriesInput{}
+			for {
+				result, err := ecrClient.DescribeRepositories(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the ECR repositories in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing ECR repositories (%d) in region: %s", len(result.Repositories), region)
+				for _, repository := range result.Repositories {
+					tags := types.Tags{}
+					tagList, err := ecrClient.ListTagsForResource(&ecr.ListTagsForResourceInput{ResourceArn: repository.RepositoryArn})
+					if err != nil {
+						log.Debugf("[AWS] Cannot list tags for ECR repository: %s", aws.StringValue(repository.RepositoryName))
+					} else {
+						for _, tag := range tagList.Tags {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+					}
+					imageInput := &ecr.DescribeImagesInput{RepositoryName: repository.RepositoryName, RegistryId: repository.RegistryId}
+					for {
+						images, err := ecrClient.DescribeImages(imageInput)
+						if err != nil {
+							log.Errorf("[AWS] Failed to fetch the images of ECR repository %s in region: %s, err: %s", aws.StringValue(repository.RepositoryName), region, err)
+							break
+						}
+						for _, image := range images.ImageDetails {
+							imageChan <- newContainerImage(image, repository, tags, region)
+						}
+						if images.NextToken == nil {
+							break
+						}
+						imageInput.NextToken = images.NextToken
+					}
+				}
+				if result.NextToken == nil {
+					break
+				}
+				input.NextToken = result.NextToken
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(imageChan)
+	}()
+
+	var images []*types.ContainerImage
+	for image := range imageChan {
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// deleteContainerImages deletes the images by digest, so all the tags of an image are removed
+func deleteContainerImages(ecrClients map[string]ecrClient, containerImages []*types.ContainerImage) []error {
+	repositoryImages := map[string]map[string][]*types.ContainerImage{}
+	for _, image := range containerImages {
+		if _, ok := repositoryImages[image.Region]; !ok {
+			repositoryImages[image.Region] = map[string][]*types.ContainerImage{}
+		}
+		repositoryImages[image.Region][image.Repository] = append(repositoryImages[image.Region][image.Repository], image)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(repositoryImages))
+	errChan := make(chan error)
+
+	for r, i := range repositoryImages {
+		go func(region string, repositories map[string][]*types.ContainerImage) {
+			defer wg.Done()
+
+			ecrClient, ok := ecrClients[region]
+			if !ok {
+				errChan <- fmt.Errorf("there is no ECR client in region: %s", region)
+				return
+			}
+			for repository, images := range repositories {
+				var imageIds []*ecr.ImageIdentifier
+				for _, image := range images {
+					if ctx.DryRun {
+						log.Infof("[AWS] Dry-run set, ECR image is not deleted: %s, region: %s", image.Name, region)
+						continue
+					}
+					log.Infof("[AWS] Delete ECR image: %s, region: %s", image.Name, region)
+					imageIds = append(imageIds, &ecr.ImageIdentifier{ImageDigest: aws.String(image.ID)})
+				}
+				// BatchDeleteImage accepts at most 100 images
+				for len(imageIds) > 0 {
+					batch := imageIds
+					if len(batch) > 100 {
+						batch = imageIds[:100]
+					}
+					imageIds = imageIds[len(batch):]
+					result, err := ecrClient.BatchDeleteImage(&ecr.BatchDeleteImageInput{RepositoryName: aws.String(repository), ImageIds: batch})
+					if err != nil {
+						log.Errorf("[AWS] Failed to delete the images of ECR repository: %s, err: %s", repository, err)
+						errChan <- err
+						continue
+					}
+					for _, failure := range result.Failures {
+						err := fmt.Errorf("failed to delete ECR image: %s, err: %s", aws.StringValue(failure.ImageId.ImageDigest), aws.StringValue(failure.FailureReason))
+						log.Errorf("[AWS] %s", err)
+						errChan <- err
+					}
+				}
+			}
+		}(r, i)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newContainerImage(image *ecr.ImageDetail, repository *ecr.Repository, tags types.Tags, region string) *types.ContainerImage {
+	imageTags := aws.StringValueSlice(image.ImageTags)
+	name := aws.StringValue(image.RepositoryName) + "@" + aws.StringValue(image.ImageDigest)
+	if len(imageTags) > 0 {
+		name = aws.StringValue(image.RepositoryName) + ":" + strings.Join(imageTags, ",")
+	}
+	return &types.ContainerImage{
+		ID:         aws.StringValue(image.ImageDigest),
+		Name:       name,
+		Repository: aws.StringValue(image.RepositoryName),
+		ImageTags:  imageTags,
+		Created:    aws.TimeValue(image.ImagePushedAt),
+		LastPulled: aws.TimeValue(image.LastRecordedPullTime),
+		Size:       aws.Int64Value(image.ImageSizeInBytes),
+		Owner:      tags[ctx.OwnerLabel],
+		CloudType:  types.AWS,
+		Region:     region,
+		Metadata:   map[string]string{"uri": aws.StringValue(repository.RepositoryUri)},
+		Tags:       tags,
+	}
+}
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEcrClient struct {
+	operationChannel chan string
+}
+
+func (t mockEcrClient) DescribeRepositories(*ecr.DescribeRepositoriesInput) (*ecr.DescribeRepositoriesOutput, error) {
+	return &ecr.DescribeRepositoriesOutput{
+		Repositories: []*ecr.Repository{
+			{
+				RepositoryArn:  aws.String("arn-app"),
+				RepositoryName: aws.String("app"),
+				RepositoryUri:  aws.String("123.dkr.ecr.eu-west-1.amazonaws.com/app"),
+			},
+		},
+	}, nil
+}
+
+func (t mockEcrClient) DescribeImages(input *ecr.DescribeImagesInput) (*ecr.DescribeImagesOutput, error) {
+	return &ecr.DescribeImagesOutput{
+		ImageDetails: []*ecr.ImageDetail{
+			{
+				RepositoryName:       input.RepositoryName,
+				ImageDigest:          aws.String("sha256:tagged"),
+				ImageTags:            aws.StringSlice([]string{"1.0", "latest"}),
+				ImagePushedAt:        aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+				LastRecordedPullTime: aws.Time(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+				ImageSizeInBytes:     aws.Int64(1024),
+			},
+			{
+				RepositoryName: input.RepositoryName,
+				ImageDigest:    aws.String("sha256:untagged"),
+			},
+		},
+	}, nil
+}
+
+func (t mockEcrClient) ListTagsForResource(*ecr.ListTagsForResourceInput) (*ecr.ListTagsForResourceOutput, error) {
+	return &ecr.ListTagsForResourceOutput{
+		Tags: []*ecr.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+	}, nil
+}
+
+func (t mockEcrClient) BatchDeleteImage(input *ecr.BatchDeleteImageInput) (*ecr.BatchDeleteImageOutput, error) {
+	for _, id := range input.ImageIds {
+		t.operationChannel <- "BatchDeleteImage:" + *input.RepositoryName + "@" + *id.ImageDigest
+	}
+	return &ecr.BatchDeleteImageOutput{}, nil
+}
+
+func TestGetContainerImages(t *testing.T) {
+	images, err := getContainerImages(map[string]ecrClient{"eu-west-1": mockEcrClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(images))
+	tagged := images[0]
+	assert.Equal(t, "sha256:tagged", tagged.ID)
+	assert.Equal(t, "app:1.0,latest", tagged.Name)
+	assert.Equal(t, "app", tagged.Repository)
+	assert.Equal(t, []string{"1.0", "latest"}, tagged.ImageTags)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), tagged.Created)
+	assert.Equal(t, time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), tagged.LastPulled)
+	assert.Equal(t, int64(1024), tagged.Size)
+	assert.Equal(t, "owner", tagged.Owner)
+	assert.Equal(t, "eu-west-1", tagged.Region)
+	assert.Equal(t, "app@sha256:untagged", images[1].Name)
+}
+
+func TestDeleteContainerImages(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteContainerImages(map[string]ecrClient{"eu-west-1": mockEcrClient{operationChannel: operationChannel}}, []*types.ContainerImage{
+		{ID: "sha256:1", Name: "app:1", Repository: "app", Region: "eu-west-1"},
+		{ID: "sha256:2", Name: "app:2", Repository: "app", Region: "eu-west-1"},
+		{ID: "sha256:3", Name: "app:3", Repository: "app", Region: "us-east-1"},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, []string{"BatchDeleteImage:app@sha256:1", "BatchDeleteImage:app@sha256:2"}, operations)
+}
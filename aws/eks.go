@@ -0,0 +1,233 @@
+package aws
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type eksClient interface {
+	ListClusters(input *eks.ListClustersInput) (*eks.ListClustersOutput, error)
+	DescribeCluster(input *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error)
+	ListNodegroups(input *eks.ListNodegroupsInput) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(input *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error)
+	UpdateNodegroupConfig(input *eks.UpdateNodegroupConfigInput) (*eks.UpdateNodegroupConfigOutput, error)
+	TagResource(input *eks.TagResourceInput) (*eks.TagResourceOutput, error)
+}
+
+func (p awsProvider) getEksClientsByRegion() map[string]eksClient {
+	eksClients := map[string]eksClient{}
+	for k := range p.eksClients {
+		eksClients[k] = p.eksClients[k]
+	}
+	return eksClients
+}
+
+// getEksClusters returns the EKS clusters with their managed node groups
+func getEksClusters(eksClients map[string]eksClient) ([]*types.Cluster, error) {
+	clusterChan := make(chan *types.Cluster)
+	wg := sync.WaitGroup{}
+	wg.Add(len(eksClients))
+
+	for r, c := range eksClients {
+		log.Debugf("[AWS] Fetching EKS clusters from: %s", r)
+		go func(region string, eksClient eksClient) {
+			defer wg.Done()
+
+			input := &eks.ListClustersInput{}
+			for {
+				result, err := eksClient.ListClusters(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the EKS clusters in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing EKS clusters (%d) in region: %s", len(result.Clusters), region)
+				for _, name := range result.Clusters {
+					cluster, err := eksClient.DescribeCluster(&eks.DescribeClusterInput{Name: name})
+					if err != nil {
+						log.Errorf("[AWS] Failed to describe the EKS cluster %s in region: %s, err: %s", aws.StringValue(name), region, err)
+						continue
+					}
+					nodegroups, err := getNodegroups(eksClient, name)
+					if err != nil {
+						log.Errorf("[AWS] Failed to fetch the node groups of EKS cluster %s in region: %s, err: %s", aws.StringValue(name), region, err)
+						continue
+					}
+					clusterChan <- newEksCluster(cluster.Cluster, nodegroups, region)
+				}
+				if result.NextToken == nil {
+					break
+				}
+				input.NextToken = result.NextToken
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(clusterChan)
+	}()
+
+	var clusters []*types.Cluster
+	for cluster := range clusterChan {
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+func getNodegroups(eksClient eksClient, clusterName *string) ([]*eks.Nodegroup, error) {
+	var nodegroups []*eks.Nodegroup
+	input := &eks.ListNodegroupsInput{ClusterName: clusterName}
+	for {
+		result, err := eksClient.ListNodegroups(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range result.Nodegroups {
+			nodegroup, err := eksClient.DescribeNodegroup(&eks.DescribeNodegroupInput{ClusterName: clusterName, NodegroupName: name})
+			if err != nil {
+				return nil, err
+			}
+			nodegroups = append(nodegroups, nodegroup.Nodegroup)
+		}
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+	return nodegroups, nil
+}
+
+// stopEksClusters scales the managed node groups of the clusters to zero, the control plane keeps running. The scaling
+// of the node groups is saved in a tag first, a node group is not scaled if its scaling could not be saved.
+func stopEksClusters(eksClients map[string]eksClient, clusters []*types.Cluster) []error {
+	wg := sync.WaitGroup{}
+	wg.Add(len(clusters))
+	errChan := make(chan error)
+
+	for _, c := range clusters {
+		go func(cluster *types.Cluster) {
+			defer wg.Done()
+
+			eksClient, ok := eksClients[cluster.Region]
+			if !ok {
+				errChan <- fmt.Errorf("there is no EKS client in region: %s", cluster.Region)
+				return
+			}
+			if cluster.Config == nil {
+				return
+			}
+			for _, pool := range cluster.Config.NodePools {
+				if pool.NodeCount == 0 {
+					continue
+				}
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, node group %s of EKS cluster %s is not scaled to zero", pool.Name, cluster.Name)
+					continue
+				}
+				if err := saveNodegroupScaling(eksClient, cluster.Name, pool.Name); err != nil {
+					log.Errorf("[AWS] Failed to save the scaling of node group %s of EKS cluster %s, err: %s", pool.Name, cluster.Name, err)
+					errChan <- err
+					continue
+				}
+				log.Infof("[AWS] Scale node group %s of EKS cluster %s to zero, region: %s", pool.Name, cluster.Name, cluster.Region)
+				_, err := eksClient.UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String(cluster.Name),
+					NodegroupName: aws.String(pool.Name),
+					ScalingConfig: &eks.NodegroupScalingConfig{MinSize: aws.Int64(0), DesiredSize: aws.Int64(0)},
+				})
+				if err != nil {
+					log.Errorf("[AWS] Failed to scale node group %s of EKS cluster %s to zero, err: %s", pool.Name, cluster.Name, err)
+					errChan <- err
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// saveNodegroupScaling tags the node group with its current scaling, so it can be restored after the cluster is stopped
+func saveNodegroupScaling(eksClient eksClient, clusterName, nodegroupName string) error {
+	result, err := eksClient.DescribeNodegroup(&eks.DescribeNodegroupInput{ClusterName: aws.String(clusterName), NodegroupName: aws.String(nodegroupName)})
+	if err != nil {
+		return err
+	}
+	scaling := types.NodePoolScaling{}
+	if config := result.Nodegroup.ScalingConfig; config != nil {
+		scaling.Desired = int(aws.Int64Value(config.DesiredSize))
+		scaling.Min = int(aws.Int64Value(config.MinSize))
+		scaling.Max = int(aws.Int64Value(config.MaxSize))
+	}
+	log.Infof("[AWS] Save the scaling of node group %s of EKS cluster %s: %s", nodegroupName, clusterName, scaling)
+	_, err = eksClient.TagResource(&eks.TagResourceInput{
+		ResourceArn: result.Nodegroup.NodegroupArn,
+		Tags:        map[string]*string{types.ScalingTag: aws.String(scaling.String())},
+	})
+	return err
+}
+
+func newEksCluster(cluster *eks.Cluster, nodegroups []*eks.Nodegroup, region string) *types.Cluster {
+	tags := types.Tags{}
+	for k, v := range cluster.Tags {
+		tags[k] = aws.StringValue(v)
+	}
+	config := &types.ClusterConfig{}
+	for _, nodegroup := range nodegroups {
+		pool := &types.NodePool{Name: aws.StringValue(nodegroup.NodegroupName)}
+		if nodegroup.ScalingConfig != nil {
+			pool.NodeCount = int(aws.Int64Value(nodegroup.ScalingConfig.DesiredSize))
+		}
+		if len(nodegroup.InstanceTypes) > 0 {
+			pool.InstanceType = aws.StringValue(nodegroup.InstanceTypes[0])
+		}
+		config.NodePools = append(config.NodePools, pool)
+	}
+	return &types.Cluster{
+		Uuid:      aws.StringValue(cluster.Arn),
+		Name:      aws.StringValue(cluster.Name),
+		Type:      types.EksCluster,
+		Created:   aws.TimeValue(cluster.CreatedAt),
+		Tags:      tags,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		State:     getEksClusterState(aws.StringValue(cluster.Status), config),
+		Region:    region,
+		Config:    config,
+		Metadata:  map[string]string{"version": aws.StringValue(cluster.Version)},
+	}
+}
+
+// getEksClusterState returns Stopped for the active clusters that have node groups, all scaled to zero
+func getEksClusterState(status string, config *types.ClusterConfig) types.State {
+	switch status {
+	case eks.ClusterStatusCreating, eks.ClusterStatusPending:
+		return types.Creating
+	case eks.ClusterStatusActive:
+		if config.IsScaledToZero() {
+			return types.Stopped
+		}
+		return types.Running
+	case eks.ClusterStatusUpdating:
+		return types.Updating
+	case eks.ClusterStatusDeleting:
+		return types.Deleting
+	case eks.ClusterStatusFailed:
+		return types.Failed
+	}
+	return types.Unknown
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEksClient struct {
+	operationChannel chan string
+}
+
+func (t mockEksClient) ListClusters(*eks.ListClustersInput) (*eks.ListClustersOutput, error) {
+	return &eks.ListClustersOutput{Clusters: []*string{aws.String("cluster")}}, nil
+}
+
+func (t mockEksClient) DescribeCluster(input *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+	return &eks.DescribeClusterOutput{
+		Cluster: &eks.Cluster{
+			Arn:       aws.String("arn-" + *input.Name),
+			Name:      input.Name,
+			Status:    aws.String(eks.ClusterStatusActive),
+			Version:   aws.String("1.23"),
+			CreatedAt: aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Tags:      map[string]*string{ctx.OwnerLabel: aws.String("owner")},
+		},
+	}, nil
+}
+
+func (t mockEksClient) ListNodegroups(*eks.ListNodegroupsInput) (*eks.ListNodegroupsOutput, error) {
+	return &eks.ListNodegroupsOutput{Nodegroups: []*string{aws.String("workers"), aws.String("idle")}}, nil
+}
+
+func (t mockEksClient) DescribeNodegroup(input *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+	size := int64(2)
+	if *input.NodegroupName == "idle" {
+		size = 0
+	}
+	return &eks.DescribeNodegroupOutput{
+		Nodegroup: &eks.Nodegroup{
+			NodegroupName: input.NodegroupName,
+			NodegroupArn:  aws.String("arn-" + *input.NodegroupName),
+			InstanceTypes: []*string{aws.String("m5.large")},
+			ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(size), MinSize: aws.Int64(1), MaxSize: aws.Int64(3)},
+		},
+	}, nil
+}
+
+func (t mockEksClient) UpdateNodegroupConfig(input *eks.UpdateNodegroupConfigInput) (*eks.UpdateNodegroupConfigOutput, error) {
+	t.operationChannel <- "UpdateNodegroupConfig:" + *input.NodegroupName
+	return nil, nil
+}
+
+func (t mockEksClient) TagResource(input *eks.TagResourceInput) (*eks.TagResourceOutput, error) {
+	t.operationChannel <- "TagResource:" + *input.ResourceArn + ":" + *input.Tags[types.ScalingTag]
+	return nil, nil
+}
+
+func TestGetEksClusters(t *testing.T) {
+	clusters, err := getEksClusters(map[string]eksClient{"eu-west-1": mockEksClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(clusters))
+	cluster := clusters[0]
+	assert.Equal(t, types.EksCluster, cluster.Type)
+	assert.Equal(t, types.Running, cluster.State)
+	assert.Equal(t, "owner", cluster.Owner)
+	assert.Equal(t, "eu-west-1", cluster.Region)
+	assert.Equal(t, 2, cluster.Config.GetNodeCount())
+	assert.Equal(t, []string{"m5.large"}, cluster.Config.GetInstanceTypes())
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), cluster.Created)
+}
+
+func TestGetEksClusterState(t *testing.T) {
+	config := &types.ClusterConfig{NodePools: []*types.NodePool{{NodeCount: 1}}}
+
+	assert.Equal(t, types.Running, getEksClusterState(eks.ClusterStatusActive, config))
+	assert.Equal(t, types.Stopped, getEksClusterState(eks.ClusterStatusActive, &types.ClusterConfig{NodePools: []*types.NodePool{{Name: "group"}}}))
+	assert.Equal(t, types.Running, getEksClusterState(eks.ClusterStatusActive, &types.ClusterConfig{}))
+	assert.Equal(t, types.Creating, getEksClusterState(eks.ClusterStatusCreating, config))
+	assert.Equal(t, types.Failed, getEksClusterState(eks.ClusterStatusFailed, config))
+}
+
+func TestStopEksClusters(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := stopEksClusters(map[string]eksClient{"eu-west-1": mockEksClient{operationChannel: operationChannel}}, []*types.Cluster{
+		{
+			Name:   "cluster",
+			Region: "eu-west-1",
+			Config: &types.ClusterConfig{NodePools: []*types.NodePool{{Name: "workers", NodeCount: 2}, {Name: "idle"}}},
+		},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"TagResource:arn-workers:desired-2_min-1_max-3_autoscaling-off", "UpdateNodegroupConfig:workers"}, operations)
+}
@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type elastiCacheClient interface {
+	DescribeCacheClusters(input *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error)
+	ListTagsForResource(input *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error)
+}
+
+func (p awsProvider) getElastiCacheClientsByRegion() map[string]elastiCacheClient {
+	elastiCacheClients := map[string]elastiCacheClient{}
+	for k := range p.elastiCacheClients {
+		elastiCacheClients[k] = p.elastiCacheClients[k]
+	}
+	return elastiCacheClients
+}
+
+// getCacheClusters returns the ElastiCache clusters. The nodes of a Redis replication group are separate cache
+// clusters, so they are collected one by one with the replication group in their metadata.
+func getCacheClusters(elastiCacheClients map[string]elastiCacheClient) ([]*types.Database, error) {
+	dbChan := make(chan *types.Database)
+	wg := sync.WaitGroup{}
+	wg.Add(len(elastiCacheClients))
+
+	for r, c := range elastiCacheClients {
+		log.Debugf("[AWS] Fetching ElastiCache clusters from: %s", r)
+		go func(region string, elastiCacheClient elastiCacheClient) {
+			defer wg.Done()
+
+			input := &elasticache.DescribeCacheClustersInput{}
+			for {
+				result, err := elastiCacheClient.DescribeCacheClusters(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the ElastiCache clusters in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing ElastiCache clusters (%d) in region: %s", len(result.CacheClusters), region)
+				for _, cluster := range result.CacheClusters {
+					state := getCacheClusterState(aws.StringValue(cluster.CacheClusterStatus))
+					if state == types.Terminated {
+						continue
+					}
+					tags := types.Tags{}
+					tagList, err := elastiCacheClient.ListTagsForResource(&elasticache.ListTagsForResourceInput{ResourceName: cluster.ARN})
+					if err != nil {
+						log.Debugf("[AWS] Cannot list tags for ElastiCache cluster: %s", aws.StringValue(cluster.CacheClusterId))
+					} else {
+						for _, tag := range tagList.TagList {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+					}
+					dbChan <- newCacheCluster(cluster, tags, state, region)
+				}
+				if result.Marker == nil {
+					break
+				}
+				input.Marker = result.Marker
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(dbChan)
+	}()
+
+	var databases []*types.Database
+	for db := range dbChan {
+		databases = append(databases, db)
+	}
+	return databases, nil
+}
+
+func newCacheCluster(cluster *elasticache.CacheCluster, tags types.Tags, state types.State, region string) *types.Database {
+	return &types.Database{
+		ID:           aws.StringValue(cluster.ARN),
+		Name:         aws.StringValue(cluster.CacheClusterId),
+		Type:         types.ElastiCacheDatabase,
+		Created:      getCreated(cluster.CacheClusterCreateTime),
+		Region:       region,
+		InstanceType: aws.StringValue(cluster.CacheNodeType),
+		NodeCount:    int(aws.Int64Value(cluster.NumCacheNodes)),
+		State:        state,
+		Owner:        tags[ctx.OwnerLabel],
+		Tags:         tags,
+		CloudType:    types.AWS,
+		Metadata: map[string]string{
+			"arn":                aws.StringValue(cluster.ARN),
+			"engine":             aws.StringValue(cluster.Engine),
+			"replicationGroupId": aws.StringValue(cluster.ReplicationGroupId),
+		},
+	}
+}
+
+// getCacheClusterState returns Terminated for the deleted clusters, ElastiCache clusters cannot be stopped
+func getCacheClusterState(status string) types.State {
+	switch status {
+	case "available", "snapshotting":
+		return types.Running
+	case "creating":
+		return types.Creating
+	case "modifying", "rebooting cluster nodes":
+		return types.Updating
+	case "deleting":
+		return types.Deleting
+	case "deleted":
+		return types.Terminated
+	case "incompatible-network", "restore-failed":
+		return types.Failed
+	}
+	return types.Unknown
+}
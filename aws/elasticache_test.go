@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockElastiCacheClient struct {
+}
+
+func (t mockElastiCacheClient) DescribeCacheClusters(*elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+	return &elasticache.DescribeCacheClustersOutput{
+		CacheClusters: []*elasticache.CacheCluster{
+			{
+				ARN:                    aws.String("arn-cache"),
+				CacheClusterId:         aws.String("cache"),
+				CacheClusterStatus:     aws.String("available"),
+				CacheNodeType:          aws.String("cache.m5.large"),
+				NumCacheNodes:          aws.Int64(2),
+				Engine:                 aws.String("memcached"),
+				CacheClusterCreateTime: aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			{ARN: aws.String("arn-deleted"), CacheClusterId: aws.String("deleted"), CacheClusterStatus: aws.String("deleted")},
+		},
+	}, nil
+}
+
+func (t mockElastiCacheClient) ListTagsForResource(*elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error) {
+	return &elasticache.TagListMessage{
+		TagList: []*elasticache.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+	}, nil
+}
+
+func TestGetCacheClusters(t *testing.T) {
+	databases, err := getCacheClusters(map[string]elastiCacheClient{"eu-west-1": mockElastiCacheClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(databases))
+	cache := databases[0]
+	assert.Equal(t, "cache", cache.Name)
+	assert.Equal(t, types.ElastiCacheDatabase, cache.Type)
+	assert.Equal(t, "cache.m5.large", cache.InstanceType)
+	assert.Equal(t, 2, cache.NodeCount)
+	assert.Equal(t, types.Running, cache.State)
+	assert.Equal(t, "owner", cache.Owner)
+	assert.Equal(t, "eu-west-1", cache.Region)
+	assert.Equal(t, "memcached", cache.Metadata["engine"])
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), cache.Created)
+}
+
+func TestGetCacheClusterState(t *testing.T) {
+	assert.Equal(t, types.Running, getCacheClusterState("snapshotting"))
+	assert.Equal(t, types.Updating, getCacheClusterState("rebooting cluster nodes"))
+	assert.Equal(t, types.Terminated, getCacheClusterState("deleted"))
+	assert.Equal(t, types.Failed, getCacheClusterState("restore-failed"))
+}
@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type gatewayClient interface {
+	DescribeNatGateways(input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error)
+	DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	DeleteNatGateway(input *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error)
+	DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+}
+
+func (p awsProvider) getGatewayClientsByRegion() map[string]gatewayClient {
+	gatewayClients := map[string]gatewayClient{}
+	for k := range p.ec2Clients {
+		gatewayClients[k] = p.ec2Clients[k]
+	}
+	return gatewayClients
+}
+
+func (p awsProvider) GetGateways() ([]*types.Gateway, error) {
+	log.Debug("[AWS] Fetch NAT gateways and VPC endpoints")
+	return getGateways(p.getGatewayClientsByRegion())
+}
+
+func (p awsProvider) DeleteGateways(gateways *types.GatewayContainer) []error {
+	log.Debug("[AWS] Delete NAT gateways and VPC endpoints")
+	return deleteGateways(p.getGatewayClientsByRegion(), gateways.Get(types.AWS))
+}
+
+// getGateways returns the NAT gateways and the VPC endpoints billed by the hour. Gateway endpoints (S3, DynamoDB)
+// are free, so they are not collected.
+func getGateways(gatewayClients map[string]gatewayClient) ([]*types.Gateway, error) {
+	gatewayChan := make(chan *types.Gateway)
+	wg := sync.WaitGroup{}
+	wg.Add(len(gatewayClients))
+
+	for r, c := range gatewayClients {
+		log.Debugf("[AWS] Fetching NAT gateways and VPC endpoints from region: %s", r)
+		go func(region string, gatewayClient gatewayClient) {
+			defer wg.Done()
+
+			natRequest := &ec2.DescribeNatGatewaysInput{}
+			for {
+				result, err := gatewayClient.DescribeNatGateways(natRequest)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the NAT gateways in region: %s, err: %s", region, err)
+					break
+				}
+				log.Debugf("[AWS] Processing NAT gateways (%d) in region: %s", len(result.NatGateways), region)
+				for _, natGateway := range result.NatGateways {
+					if state := getNatGatewayState(natGateway); state != types.Terminated {
+						gatewayChan <- newNatGateway(natGateway, state, region)
+					}
+				}
+				if result.NextToken == nil {
+					break
+				}
+				natRequest.SetNextToken(*result.NextToken)
+			}
+
+			endpointRequest := &ec2.DescribeVpcEndpointsInput{}
+			for {
+				result, err := gatewayClient.DescribeVpcEndpoints(endpointRequest)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the VPC endpoints in region: %s, err: %s", region, err)
+					break
+				}
+				log.Debugf("[AWS] Processing VPC endpoints (%d) in region: %s", len(result.VpcEndpoints), region)
+				for _, endpoint := range result.VpcEndpoints {
+					if aws.StringValue(endpoint.VpcEndpointType) == ec2.VpcEndpointTypeGateway {
+						continue
+					}
+					if state := getVpcEndpointState(endpoint); state != types.Terminated {
+						gatewayChan <- newVpcEndpoint(endpoint, state, region)
+					}
+				}
+				if result.NextToken == nil {
+					break
+				}
+				endpointRequest.SetNextToken(*result.NextToken)
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(gatewayChan)
+	}()
+
+	var gateways []*types.Gateway
+	for gateway := range gatewayChan {
+		gateways = append(gateways, gateway)
+	}
+	return gateways, nil
+}
+
+func deleteGateways(gatewayClients map[string]gatewayClient, gateways []*types.Gateway) []error {
+	regionGateways := map[string][]*types.Gateway{}
+	for _, gateway := range gateways {
+		regionGateways[gateway.Region] = append(regionGateways[gateway.Region], gateway)
+	}
+	log.Debugf("[AWS] Delete NAT gateways and VPC endpoints: %v", regionGateways)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionGateways))
+	errChan := make(chan error)
+
+	for r, g := range regionGateways {
+		go func(gatewayClient gatewayClient, region string, gateways []*types.Gateway) {
+			defer wg.Done()
+
+			var endpointIds []*string
+			for _, gateway := range gateways {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, %s is not deleted: %s, region: %s", gateway.Type, gateway.Name, region)
+					continue
+				}
+				switch gateway.Type {
+				case types.NatGateway:
+					log.Infof("[AWS] Delete NAT gateway: %s, region: %s", gateway.Name, region)
+					if _, err := gatewayClient.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: aws.String(gateway.ID)}); err != nil {
+						log.Errorf("[AWS] Failed to delete NAT gateway: %s, err: %s", gateway.ID, err)
+						errChan <- err
+					}
+				case types.VpcEndpoint:
+					log.Infof("[AWS] Delete VPC endpoint: %s, region: %s", gateway.Name, region)
+					endpointIds = append(endpointIds, aws.String(gateway.ID))
+				}
+			}
+			if len(endpointIds) == 0 {
+				return
+			}
+			result, err := gatewayClient.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{VpcEndpointIds: endpointIds})
+			if err != nil {
+				log.Errorf("[AWS] Failed to delete VPC endpoints in region: %s, err: %s", region, err)
+				errChan <- err
+				return
+			}
+			for _, unsuccessful := range result.Unsuccessful {
+				err := fmt.Errorf("failed to delete VPC endpoint: %s, err: %s", aws.StringValue(unsuccessful.ResourceId), aws.StringValue(unsuccessful.Error.Message))
+				log.Errorf("[AWS] %s", err)
+				errChan <- err
+			}
+		}(gatewayClients[r], r, g)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newNatGateway(natGateway *ec2.NatGateway, state types.State, region string) *types.Gateway {
+	tags := getEc2Tags(natGateway.Tags)
+	name, ok := tags["Name"]
+	if !ok {
+		name = aws.StringValue(natGateway.NatGatewayId)
+	}
+	return &types.Gateway{
+		ID:        aws.StringValue(natGateway.NatGatewayId),
+		Name:      name,
+		Type:      types.NatGateway,
+		Created:   aws.TimeValue(natGateway.CreateTime),
+		State:     state,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		Region:    region,
+		VpcID:     aws.StringValue(natGateway.VpcId),
+		Metadata:  map[string]string{"subnetId": aws.StringValue(natGateway.SubnetId)},
+		Tags:      tags,
+	}
+}
+
+func newVpcEndpoint(endpoint *ec2.VpcEndpoint, state types.State, region string) *types.Gateway {
+	tags := getEc2Tags(endpoint.Tags)
+	name, ok := tags["Name"]
+	if !ok {
+		name = aws.StringValue(endpoint.VpcEndpointId)
+	}
+	return &types.Gateway{
+		ID:        aws.StringValue(endpoint.VpcEndpointId),
+		Name:      name,
+		Type:      types.VpcEndpoint,
+		Created:   aws.TimeValue(endpoint.CreationTimestamp),
+		State:     state,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		Region:    region,
+		VpcID:     aws.StringValue(endpoint.VpcId),
+		Metadata: map[string]string{
+			"endpointType": aws.StringValue(endpoint.VpcEndpointType),
+			"serviceName":  aws.StringValue(endpoint.ServiceName),
+		},
+		Tags: tags,
+	}
+}
+
+// getNatGatewayState returns Terminated for the NAT gateways that are not billed anymore
+func getNatGatewayState(natGateway *ec2.NatGateway) types.State {
+	switch aws.StringValue(natGateway.State) {
+	case ec2.NatGatewayStatePending:
+		return types.Creating
+	case ec2.NatGatewayStateAvailable:
+		return types.Running
+	case ec2.NatGatewayStateDeleting, ec2.NatGatewayStateDeleted, ec2.NatGatewayStateFailed:
+		return types.Terminated
+	}
+	return types.Unknown
+}
+
+// getVpcEndpointState returns Terminated for the VPC endpoints that are not billed anymore
+func getVpcEndpointState(endpoint *ec2.VpcEndpoint) types.State {
+	switch strings.ToLower(aws.StringValue(endpoint.State)) {
+	case "pending", "pendingacceptance":
+		return types.Creating
+	case "available":
+		return types.Running
+	case "deleting", "deleted", "rejected", "failed", "expired":
+		return types.Terminated
+	}
+	return types.Unknown
+}
@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGatewayClient struct {
+	operationChannel chan string
+}
+
+func (t mockGatewayClient) DescribeNatGateways(*ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	return &ec2.DescribeNatGatewaysOutput{
+		NatGateways: []*ec2.NatGateway{
+			{
+				NatGatewayId: aws.String("nat-1"),
+				State:        aws.String(ec2.NatGatewayStateAvailable),
+				VpcId:        aws.String("vpc-1"),
+				CreateTime:   aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Tags:         []*ec2.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+			},
+			{NatGatewayId: aws.String("nat-2"), State: aws.String(ec2.NatGatewayStateDeleted)},
+		},
+	}, nil
+}
+
+func (t mockGatewayClient) DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return &ec2.DescribeVpcEndpointsOutput{
+		VpcEndpoints: []*ec2.VpcEndpoint{
+			{
+				VpcEndpointId:   aws.String("vpce-1"),
+				VpcEndpointType: aws.String(ec2.VpcEndpointTypeInterface),
+				ServiceName:     aws.String("com.amazonaws.eu-west-1.ssm"),
+				State:           aws.String("available"),
+				VpcId:           aws.String("vpc-1"),
+				Tags:            []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("ssm")}},
+			},
+			{VpcEndpointId: aws.String("vpce-2"), VpcEndpointType: aws.String(ec2.VpcEndpointTypeGateway), State: aws.String("available")},
+		},
+	}, nil
+}
+
+func (t mockGatewayClient) DeleteNatGateway(input *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	t.operationChannel <- "DeleteNatGateway:" + *input.NatGatewayId
+	return nil, nil
+}
+
+func (t mockGatewayClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+	t.operationChannel <- "DeleteVpcEndpoints:" + strings.Join(aws.StringValueSlice(input.VpcEndpointIds), ",")
+	return &ec2.DeleteVpcEndpointsOutput{}, nil
+}
+
+func TestGetGateways(t *testing.T) {
+	gateways, err := getGateways(map[string]gatewayClient{"eu-west-1": mockGatewayClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(gateways))
+
+	natGateway := gateways[0]
+	assert.Equal(t, "nat-1", natGateway.ID)
+	assert.Equal(t, "nat-1", natGateway.Name)
+	assert.Equal(t, types.NatGateway, natGateway.Type)
+	assert.Equal(t, types.Running, natGateway.State)
+	assert.Equal(t, "owner", natGateway.Owner)
+	assert.Equal(t, "vpc-1", natGateway.VpcID)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), natGateway.Created)
+
+	endpoint := gateways[1]
+	assert.Equal(t, "vpce-1", endpoint.ID)
+	assert.Equal(t, "ssm", endpoint.Name)
+	assert.Equal(t, types.VpcEndpoint, endpoint.Type)
+	assert.Equal(t, "Interface", endpoint.Metadata["endpointType"])
+	assert.Equal(t, "com.amazonaws.eu-west-1.ssm", endpoint.Metadata["serviceName"])
+}
+
+func TestDeleteGateways(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteGateways(map[string]gatewayClient{"eu-west-1": mockGatewayClient{operationChannel: operationChannel}}, []*types.Gateway{
+		{ID: "nat-1", Type: types.NatGateway, Region: "eu-west-1"},
+		{ID: "vpce-1", Type: types.VpcEndpoint, Region: "eu-west-1"},
+		{ID: "vpce-2", Type: types.VpcEndpoint, Region: "eu-west-1"},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"DeleteNatGateway:nat-1", "DeleteVpcEndpoints:vpce-1,vpce-2"}, operations)
+}
@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// lambdaTimeLayout is the format of the last modification time of the Lambda functions
+const lambdaTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+type lambdaClient interface {
+	ListFunctions(input *lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error)
+	ListTags(input *lambda.ListTagsInput) (*lambda.ListTagsOutput, error)
+	ListProvisionedConcurrencyConfigs(input *lambda.ListProvisionedConcurrencyConfigsInput) (*lambda.ListProvisionedConcurrencyConfigsOutput, error)
+	DeleteFunction(input *lambda.DeleteFunctionInput) (*lambda.DeleteFunctionOutput, error)
+}
+
+func (p awsProvider) getLambdaClientsByRegion() map[string]lambdaClient {
+	lambdaClients := map[string]lambdaClient{}
+	for k := range p.lambdaClients {
+		lambdaClients[k] = p.lambdaClients[k]
+	}
+	return lambdaClients
+}
+
+func (p awsProvider) GetFunctions() ([]*types.Function, error) {
+	log.Debug("[AWS] Fetch Lambda functions")
+	end := time.Now()
+	start := end.Add(-utils.GetInvocationLookback())
+	cloudWatchClients := p.getCloudWatchClientsByRegion()
+	return getFunctions(p.getLambdaClientsByRegion(), start, func(function *types.Function) (int64, error) {
+		return getInvocations(cloudWatchClients, function, start, end)
+	})
+}
+
+func (p awsProvider) DeleteFunctions(functions *types.FunctionContainer) []error {
+	log.Debug("[AWS] Delete Lambda functions")
+	return deleteFunctions(p.getLambdaClientsByRegion(), functions.Get(types.AWS))
+}
+
+// getInvocations returns the number of invocations of the function between the start and end time
+func getInvocations(cloudWatchClients map[string]cloudWatchClient, function *types.Function, start, end time.Time) (int64, error) {
+	values, err := getMetricValues(cloudWatchClients, function, types.MetricQuery{Name: "Invocations", Statistic: types.SumStatistic, Period: 24 * time.Hour, Start: start, End: end})
+	if err != nil {
+		return 0, err
+	}
+	var invocations float64
+	for _, value := range values {
+		invocations += value
+	}
+	return int64(math.Round(invocations)), nil
+}
+
+// getFunctions returns the Lambda functions with their invocations since the start time, the functions whose
+// invocations cannot be counted are in Unknown state
+func getFunctions(lambdaClients map[string]lambdaClient, start time.Time, countInvocations func(*types.Function) (int64, error)) ([]*types.Function, error) {
+	functionChan := make(chan *types.Function)
+	wg := sync.WaitGroup{}
+	wg.Add(len(lambdaClients))
+
+	for r, c := range lambdaClients {
+		log.Debugf("[AWS] Fetching Lambda functions from: %s", r)
+		go func(region string, lambdaClient lambdaClient) {
+			defer wg.Done()
+
+			input := &lambda.ListFunctionsInput{}
+			for {
+				result, err := lambdaClient.ListFunctions(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the Lambda functions in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing Lambda functions (%d) in region: %s", len(result.Functions), region)
+				for _, configuration := range result.Functions {
+					tags := types.Tags{}
+					tagList, err := lambdaClient.ListTags(&lambda.ListTagsInput{Resource: configuration.FunctionArn})
+					if err != nil {
+						log.Debugf("[AWS] Cannot list tags for Lambda function: %s", aws.StringValue(configuration.FunctionName))
+					} else {
+						for k, v := range tagList.Tags {
+							tags[k] = aws.StringValue(v)
+						}
+					}
+					function := newFunction(configuration, tags, region)
+					function.Metadata["provisionedConcurrency"] = strconv.FormatInt(getProvisionedConcurrency(lambdaClient, configuration.FunctionName), 10)
+					if invocations, err := countInvocations(function); err != nil {
+						log.Errorf("[AWS] Failed to count the invocations of Lambda function: %s, err: %s", function.Name, err)
+					} else {
+						function.Invocations = invocations
+						function.State = types.GetInvocationState(invocations, function.LastModified, start)
+					}
+					functionChan <- function
+				}
+				if result.NextMarker == nil {
+					break
+				}
+				input.Marker = result.NextMarker
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(functionChan)
+	}()
+
+	var functions []*types.Function
+	for function := range functionChan {
+		functions = append(functions, function)
+	}
+	return functions, nil
+}
+
+// getProvisionedConcurrency returns the number of provisioned executions of all the versions and aliases of the
+// function, they are billed even if the function is not invoked
+func getProvisionedConcurrency(lambdaClient lambdaClient, functionName *string) int64 {
+	var provisioned int64
+	input := &lambda.ListProvisionedConcurrencyConfigsInput{FunctionName: functionName}
+	for {
+		result, err := lambdaClient.ListProvisionedConcurrencyConfigs(input)
+		if err != nil {
+			log.Debugf("[AWS] Cannot list the provisioned concurrency of Lambda function: %s, err: %s", aws.StringValue(functionName), err)
+			return provisioned
+		}
+		for _, config := range result.ProvisionedConcurrencyConfigs {
+			provisioned += aws.Int64Value(config.RequestedProvisionedConcurrentExecutions)
+		}
+		if result.NextMarker == nil {
+			return provisioned
+		}
+		input.Marker = result.NextMarker
+	}
+}
+
+func deleteFunctions(lambdaClients map[string]lambdaClient, functions []*types.Function) []error {
+	wg := sync.WaitGroup{}
+	wg.Add(len(functions))
+	errChan := make(chan error)
+
+	for _, f := range functions {
+		go func(function *types.Function) {
+			defer wg.Done()
+
+			lambdaClient, ok := lambdaClients[function.Region]
+			if !ok {
+				errChan <- fmt.Errorf("there is no Lambda client in region: %s", function.Region)
+				return
+			}
+			if ctx.DryRun {
+				log.Infof("[AWS] Dry-run set, Lambda function is not deleted: %s, region: %s", function.Name, function.Region)
+				return
+			}
+			log.Infof("[AWS] Delete Lambda function: %s, region: %s", function.Name, function.Region)
+			if _, err := lambdaClient.DeleteFunction(&lambda.DeleteFunctionInput{FunctionName: aws.String(function.Name)}); err != nil {
+				log.Errorf("[AWS] Failed to delete Lambda function: %s, err: %s", function.Name, err)
+				errChan <- err
+			}
+		}(f)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// newFunction converts the Lambda function, Lambda does not return the creation time so the last modification is used
+func newFunction(configuration *lambda.FunctionConfiguration, tags types.Tags, region string) *types.Function {
+	lastModified, err := utils.ConvertTimeLayout(lambdaTimeLayout, aws.StringValue(configuration.LastModified))
+	if err != nil {
+		log.Debugf("[AWS] Failed to parse the last modification of Lambda function: %s, err: %s", aws.StringValue(configuration.FunctionName), err)
+	}
+	runtime := aws.StringValue(configuration.Runtime)
+	if len(runtime) == 0 {
+		runtime = aws.StringValue(configuration.PackageType)
+	}
+	return &types.Function{
+		ID:           aws.StringValue(configuration.FunctionArn),
+		Name:         aws.StringValue(configuration.FunctionName),
+		Type:         types.LambdaFunction,
+		Runtime:      runtime,
+		MemorySize:   aws.Int64Value(configuration.MemorySize),
+		Created:      lastModified,
+		LastModified: lastModified,
+		State:        types.Unknown,
+		Owner:        tags[ctx.OwnerLabel],
+		CloudType:    types.AWS,
+		Region:       region,
+		Metadata:     map[string]string{},
+		Tags:         tags,
+	}
+}
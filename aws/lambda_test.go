@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLambdaClient struct {
+	operationChannel chan string
+}
+
+func (t mockLambdaClient) ListFunctions(*lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error) {
+	return &lambda.ListFunctionsOutput{
+		Functions: []*lambda.FunctionConfiguration{
+			{
+				FunctionArn:  aws.String("arn-unused"),
+				FunctionName: aws.String("unused"),
+				Runtime:      aws.String("python3.9"),
+				MemorySize:   aws.Int64(512),
+				LastModified: aws.String("2022-01-01T10:00:00.000+0000"),
+			},
+			{
+				FunctionArn:  aws.String("arn-uncounted"),
+				FunctionName: aws.String("uncounted"),
+				PackageType:  aws.String("Image"),
+				LastModified: aws.String("2022-01-01T10:00:00.000+0000"),
+			},
+		},
+	}, nil
+}
+
+func (t mockLambdaClient) ListTags(*lambda.ListTagsInput) (*lambda.ListTagsOutput, error) {
+	return &lambda.ListTagsOutput{Tags: map[string]*string{ctx.OwnerLabel: aws.String("owner")}}, nil
+}
+
+func (t mockLambdaClient) ListProvisionedConcurrencyConfigs(*lambda.ListProvisionedConcurrencyConfigsInput) (*lambda.ListProvisionedConcurrencyConfigsOutput, error) {
+	return &lambda.ListProvisionedConcurrencyConfigsOutput{
+		ProvisionedConcurrencyConfigs: []*lambda.ProvisionedConcurrencyConfigListItem{
+			{RequestedProvisionedConcurrentExecutions: aws.Int64(2)},
+		},
+	}, nil
+}
+
+func (t mockLambdaClient) DeleteFunction(input *lambda.DeleteFunctionInput) (*lambda.DeleteFunctionOutput, error) {
+	t.operationChannel <- "DeleteFunction:" + *input.FunctionName
+	return nil, nil
+}
+
+func TestGetFunctions(t *testing.T) {
+	start := time.Now().Add(-720 * time.Hour)
+
+	functions, err := getFunctions(map[string]lambdaClient{"eu-west-1": mockLambdaClient{}}, start, func(function *types.Function) (int64, error) {
+		if function.Name == "uncounted" {
+			return 0, errors.New("error")
+		}
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(functions))
+	unused := functions[0]
+	assert.Equal(t, "arn-unused", unused.ID)
+	assert.Equal(t, types.LambdaFunction, unused.Type)
+	assert.Equal(t, "python3.9", unused.Runtime)
+	assert.Equal(t, int64(512), unused.MemorySize)
+	assert.Equal(t, time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC), unused.LastModified.UTC())
+	assert.Equal(t, "owner", unused.Owner)
+	assert.Equal(t, "2", unused.Metadata["provisionedConcurrency"])
+	assert.Equal(t, types.Unused, unused.State)
+	uncounted := functions[1]
+	assert.Equal(t, "Image", uncounted.Runtime)
+	assert.Equal(t, types.Unknown, uncounted.State)
+}
+
+func TestGetInvocations(t *testing.T) {
+	operationChannel := make(chan string, 10)
+	end := time.Now()
+
+	invocations, err := getInvocations(map[string]cloudWatchClient{"region": mockCwClient{operationChannel: operationChannel}},
+		&types.Function{Name: "function", Region: "region"}, end.Add(-720*time.Hour), end)
+	close(operationChannel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(30), invocations)
+	assert.Equal(t, "GetMetricStatistics:Invocations:86400", <-operationChannel)
+}
+
+func TestDeleteFunctions(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteFunctions(map[string]lambdaClient{"eu-west-1": mockLambdaClient{operationChannel: operationChannel}}, []*types.Function{
+		{Name: "function", Region: "eu-west-1"},
+		{Name: "unknown", Region: "us-east-1"},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, []string{"DeleteFunction:function"}, operations)
+}
@@ -0,0 +1,327 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	classicelb "github.com/aws/aws-sdk-go/service/elb"
+	elb "github.com/aws/aws-sdk-go/service/elbv2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const classicLoadBalancerType = "classic"
+
+type loadBalancerClient interface {
+	DescribeLoadBalancers(input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error)
+	DescribeTags(input *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error)
+	DescribeTargetGroups(input *elb.DescribeTargetGroupsInput) (*elb.DescribeTargetGroupsOutput, error)
+	DescribeTargetHealth(input *elb.DescribeTargetHealthInput) (*elb.DescribeTargetHealthOutput, error)
+	DeleteLoadBalancer(input *elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error)
+}
+
+type classicLoadBalancerClient interface {
+	DescribeLoadBalancers(input *classicelb.DescribeLoadBalancersInput) (*classicelb.DescribeLoadBalancersOutput, error)
+	DescribeTags(input *classicelb.DescribeTagsInput) (*classicelb.DescribeTagsOutput, error)
+	DescribeInstanceHealth(input *classicelb.DescribeInstanceHealthInput) (*classicelb.DescribeInstanceHealthOutput, error)
+	DeleteLoadBalancer(input *classicelb.DeleteLoadBalancerInput) (*classicelb.DeleteLoadBalancerOutput, error)
+}
+
+func (p awsProvider) getLoadBalancerClientsByRegion() (map[string]loadBalancerClient, map[string]classicLoadBalancerClient) {
+	elbClients := map[string]loadBalancerClient{}
+	for k := range p.elbClients {
+		elbClients[k] = p.elbClients[k]
+	}
+	classicElbClients := map[string]classicLoadBalancerClient{}
+	for k := range p.classicElbClients {
+		classicElbClients[k] = p.classicElbClients[k]
+	}
+	return elbClients, classicElbClients
+}
+
+func (p awsProvider) GetLoadBalancers() ([]*types.LoadBalancer, error) {
+	log.Debug("[AWS] Fetch load balancers")
+	return getLoadBalancers(p.getLoadBalancerClientsByRegion())
+}
+
+func (p awsProvider) DeleteLoadBalancers(loadBalancers *types.LoadBalancerContainer) []error {
+	log.Debug("[AWS] Delete load balancers")
+	elbClients, classicElbClients := p.getLoadBalancerClientsByRegion()
+	return deleteLoadBalancers(elbClients, classicElbClients, loadBalancers.Get(types.AWS))
+}
+
+// getLoadBalancers returns the application, network and classic load balancers. A load balancer is unused
+// if none of its targets are healthy.
+func getLoadBalancers(elbClients map[string]loadBalancerClient, classicElbClients map[string]classicLoadBalancerClient) ([]*types.LoadBalancer, error) {
+	loadBalancerChan := make(chan *types.LoadBalancer)
+	wg := sync.WaitGroup{}
+	wg.Add(len(elbClients))
+
+	for r, c := range elbClients {
+		log.Debugf("[AWS] Fetching load balancers from region: %s", r)
+		go func(region string, elbClient loadBalancerClient, classicElbClient classicLoadBalancerClient) {
+			defer wg.Done()
+
+			loadBalancers, err := getRegionLoadBalancers(elbClient, region)
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the load balancers in region: %s, err: %s", region, err)
+				return
+			}
+			for _, loadBalancer := range loadBalancers {
+				loadBalancerChan <- loadBalancer
+			}
+
+			if classicElbClient == nil {
+				return
+			}
+			classicLoadBalancers, err := getRegionClassicLoadBalancers(classicElbClient, region)
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the classic load balancers in region: %s, err: %s", region, err)
+				return
+			}
+			for _, loadBalancer := range classicLoadBalancers {
+				loadBalancerChan <- loadBalancer
+			}
+		}(r, c, classicElbClients[r])
+	}
+
+	go func() {
+		wg.Wait()
+		close(loadBalancerChan)
+	}()
+
+	var loadBalancers []*types.LoadBalancer
+	for loadBalancer := range loadBalancerChan {
+		loadBalancers = append(loadBalancers, loadBalancer)
+	}
+	return loadBalancers, nil
+}
+
+func getRegionLoadBalancers(elbClient loadBalancerClient, region string) ([]*types.LoadBalancer, error) {
+	var loadBalancers []*types.LoadBalancer
+	request := &elb.DescribeLoadBalancersInput{}
+	for {
+		result, err := elbClient.DescribeLoadBalancers(request)
+		if err != nil {
+			return nil, err
+		}
+		log.Debugf("[AWS] Processing load balancers (%d) in region: %s", len(result.LoadBalancers), region)
+		for _, loadBalancer := range result.LoadBalancers {
+			targets, healthyTargets, err := getTargetCount(elbClient, loadBalancer.LoadBalancerArn)
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the targets of load balancer: %s, err: %s", aws.StringValue(loadBalancer.LoadBalancerName), err)
+				continue
+			}
+			tags := types.Tags{}
+			tagsResult, err := elbClient.DescribeTags(&elb.DescribeTagsInput{ResourceArns: []*string{loadBalancer.LoadBalancerArn}})
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the tags of load balancer: %s, err: %s", aws.StringValue(loadBalancer.LoadBalancerName), err)
+			} else {
+				for _, tagDescription := range tagsResult.TagDescriptions {
+					tags = getElbTags(tagDescription.Tags)
+				}
+			}
+			loadBalancers = append(loadBalancers, newLoadBalancer(loadBalancer, targets, healthyTargets, tags, region))
+		}
+		if result.NextMarker == nil {
+			break
+		}
+		request.SetMarker(*result.NextMarker)
+	}
+	return loadBalancers, nil
+}
+
+// getTargetCount returns the number of registered and healthy targets in the target groups of the load balancer. The
+// targets of target groups without health checks are unavailable and the health checks of Lambda target groups are
+// disabled by default, so these are counted as healthy, like the serverless network endpoint groups on GCP.
+func getTargetCount(elbClient loadBalancerClient, loadBalancerArn *string) (int, int, error) {
+	targets, healthyTargets := 0, 0
+	request := &elb.DescribeTargetGroupsInput{LoadBalancerArn: loadBalancerArn}
+	for {
+		result, err := elbClient.DescribeTargetGroups(request)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, targetGroup := range result.TargetGroups {
+			health, err := elbClient.DescribeTargetHealth(&elb.DescribeTargetHealthInput{TargetGroupArn: targetGroup.TargetGroupArn})
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, target := range health.TargetHealthDescriptions {
+				targets++
+				if isTargetHealthy(targetGroup, target) {
+					healthyTargets++
+				}
+			}
+		}
+		if result.NextMarker == nil {
+			break
+		}
+		request.SetMarker(*result.NextMarker)
+	}
+	return targets, healthyTargets, nil
+}
+
+func isTargetHealthy(targetGroup *elb.TargetGroup, target *elb.TargetHealthDescription) bool {
+	if aws.StringValue(targetGroup.TargetType) == elb.TargetTypeEnumLambda {
+		return true
+	}
+	if target.TargetHealth == nil {
+		return false
+	}
+	state := aws.StringValue(target.TargetHealth.State)
+	return state == elb.TargetHealthStateEnumHealthy || state == elb.TargetHealthStateEnumUnavailable
+}
+
+func getRegionClassicLoadBalancers(classicElbClient classicLoadBalancerClient, region string) ([]*types.LoadBalancer, error) {
+	var loadBalancers []*types.LoadBalancer
+	request := &classicelb.DescribeLoadBalancersInput{}
+	for {
+		result, err := classicElbClient.DescribeLoadBalancers(request)
+		if err != nil {
+			return nil, err
+		}
+		log.Debugf("[AWS] Processing classic load balancers (%d) in region: %s", len(result.LoadBalancerDescriptions), region)
+		for _, loadBalancer := range result.LoadBalancerDescriptions {
+			healthyTargets := 0
+			if len(loadBalancer.Instances) > 0 {
+				health, err := classicElbClient.DescribeInstanceHealth(&classicelb.DescribeInstanceHealthInput{LoadBalancerName: loadBalancer.LoadBalancerName})
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the instance health of load balancer: %s, err: %s", aws.StringValue(loadBalancer.LoadBalancerName), err)
+					continue
+				}
+				for _, instance := range health.InstanceStates {
+					if aws.StringValue(instance.State) == "InService" {
+						healthyTargets++
+					}
+				}
+			}
+			tags := types.Tags{}
+			tagsResult, err := classicElbClient.DescribeTags(&classicelb.DescribeTagsInput{LoadBalancerNames: []*string{loadBalancer.LoadBalancerName}})
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the tags of load balancer: %s, err: %s", aws.StringValue(loadBalancer.LoadBalancerName), err)
+			} else {
+				for _, tagDescription := range tagsResult.TagDescriptions {
+					tags = getClassicElbTags(tagDescription.Tags)
+				}
+			}
+			loadBalancers = append(loadBalancers, newClassicLoadBalancer(loadBalancer, healthyTargets, tags, region))
+		}
+		if result.NextMarker == nil {
+			break
+		}
+		request.SetMarker(*result.NextMarker)
+	}
+	return loadBalancers, nil
+}
+
+func deleteLoadBalancers(elbClients map[string]loadBalancerClient, classicElbClients map[string]classicLoadBalancerClient, loadBalancers []*types.LoadBalancer) []error {
+	regionLoadBalancers := map[string][]*types.LoadBalancer{}
+	for _, loadBalancer := range loadBalancers {
+		regionLoadBalancers[loadBalancer.Region] = append(regionLoadBalancers[loadBalancer.Region], loadBalancer)
+	}
+	log.Debugf("[AWS] Delete load balancers: %v", regionLoadBalancers)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionLoadBalancers))
+	errChan := make(chan error)
+
+	for r, l := range regionLoadBalancers {
+		go func(elbClient loadBalancerClient, classicElbClient classicLoadBalancerClient, region string, loadBalancers []*types.LoadBalancer) {
+			defer wg.Done()
+
+			for _, loadBalancer := range loadBalancers {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, load balancer is not deleted: %s, region: %s", loadBalancer.Name, region)
+					continue
+				}
+				log.Infof("[AWS] Delete load balancer: %s, region: %s", loadBalancer.Name, region)
+				var err error
+				if loadBalancer.Type == classicLoadBalancerType {
+					_, err = classicElbClient.DeleteLoadBalancer(&classicelb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(loadBalancer.ID)})
+				} else {
+					_, err = elbClient.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(loadBalancer.ID)})
+				}
+				if err != nil {
+					log.Errorf("[AWS] Failed to delete load balancer: %s, err: %s", loadBalancer.Name, err)
+					errChan <- err
+				}
+			}
+		}(elbClients[r], classicElbClients[r], r, l)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newLoadBalancer(loadBalancer *elb.LoadBalancer, targets, healthyTargets int, tags types.Tags, region string) *types.LoadBalancer {
+	return &types.LoadBalancer{
+		ID:             aws.StringValue(loadBalancer.LoadBalancerArn),
+		Name:           aws.StringValue(loadBalancer.LoadBalancerName),
+		Type:           aws.StringValue(loadBalancer.Type),
+		Created:        aws.TimeValue(loadBalancer.CreatedTime),
+		State:          getLoadBalancerState(targets, healthyTargets),
+		Owner:          tags[ctx.OwnerLabel],
+		CloudType:      types.AWS,
+		Region:         region,
+		Targets:        targets,
+		HealthyTargets: healthyTargets,
+		Metadata:       map[string]string{"dnsName": aws.StringValue(loadBalancer.DNSName)},
+		Tags:           tags,
+	}
+}
+
+// newClassicLoadBalancer converts the classic load balancer, which is identified by its name
+func newClassicLoadBalancer(loadBalancer *classicelb.LoadBalancerDescription, healthyTargets int, tags types.Tags, region string) *types.LoadBalancer {
+	return &types.LoadBalancer{
+		ID:             aws.StringValue(loadBalancer.LoadBalancerName),
+		Name:           aws.StringValue(loadBalancer.LoadBalancerName),
+		Type:           classicLoadBalancerType,
+		Created:        aws.TimeValue(loadBalancer.CreatedTime),
+		State:          getLoadBalancerState(len(loadBalancer.Instances), healthyTargets),
+		Owner:          tags[ctx.OwnerLabel],
+		CloudType:      types.AWS,
+		Region:         region,
+		Targets:        len(loadBalancer.Instances),
+		HealthyTargets: healthyTargets,
+		Metadata:       map[string]string{"dnsName": aws.StringValue(loadBalancer.DNSName)},
+		Tags:           tags,
+	}
+}
+
+// getLoadBalancerState returns Unused for the load balancers without registered targets. The registered targets that
+// are not healthy may be initializing, draining or failing their health checks, so these load balancers are Unhealthy.
+func getLoadBalancerState(targets, healthyTargets int) types.State {
+	switch {
+	case targets == 0:
+		return types.Unused
+	case healthyTargets == 0:
+		return types.Unhealthy
+	}
+	return types.InUse
+}
+
+func getElbTags(elbTags []*elb.Tag) types.Tags {
+	tags := make(types.Tags, 0)
+	for _, t := range elbTags {
+		tags[*t.Key] = aws.StringValue(t.Value)
+	}
+	return tags
+}
+
+func getClassicElbTags(elbTags []*classicelb.Tag) types.Tags {
+	tags := make(types.Tags, 0)
+	for _, t := range elbTags {
+		tags[*t.Key] = aws.StringValue(t.Value)
+	}
+	return tags
+}
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	classicelb "github.com/aws/aws-sdk-go/service/elb"
+	elb "github.com/aws/aws-sdk-go/service/elbv2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLoadBalancerClient struct {
+	operationChannel chan string
+}
+
+func (t mockLoadBalancerClient) DescribeLoadBalancers(*elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancers: []*elb.LoadBalancer{
+			{
+				LoadBalancerArn:  aws.String("arn-healthy"),
+				LoadBalancerName: aws.String("healthy"),
+				Type:             aws.String(elb.LoadBalancerTypeEnumApplication),
+				CreatedTime:      aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			{
+				LoadBalancerArn:  aws.String("arn-unhealthy"),
+				LoadBalancerName: aws.String("unhealthy"),
+				Type:             aws.String(elb.LoadBalancerTypeEnumNetwork),
+			},
+			{
+				LoadBalancerArn:  aws.String("arn-lambda"),
+				LoadBalancerName: aws.String("lambda"),
+				Type:             aws.String(elb.LoadBalancerTypeEnumApplication),
+			},
+			{
+				LoadBalancerArn:  aws.String("arn-unavailable"),
+				LoadBalancerName: aws.String("unavailable"),
+				Type:             aws.String(elb.LoadBalancerTypeEnumNetwork),
+			},
+		},
+	}, nil
+}
+
+func (t mockLoadBalancerClient) DescribeTags(input *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
+	return &elb.DescribeTagsOutput{
+		TagDescriptions: []*elb.TagDescription{
+			{
+				ResourceArn: input.ResourceArns[0],
+				Tags:        []*elb.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+			},
+		},
+	}, nil
+}
+
+func (t mockLoadBalancerClient) DescribeTargetGroups(input *elb.DescribeTargetGroupsInput) (*elb.DescribeTargetGroupsOutput, error) {
+	targetType := elb.TargetTypeEnumInstance
+	if *input.LoadBalancerArn == "arn-lambda" {
+		targetType = elb.TargetTypeEnumLambda
+	}
+	return &elb.DescribeTargetGroupsOutput{
+		TargetGroups: []*elb.TargetGroup{{TargetGroupArn: aws.String("tg-" + *input.LoadBalancerArn), TargetType: aws.String(targetType)}},
+	}, nil
+}
+
+func (t mockLoadBalancerClient) DescribeTargetHealth(input *elb.DescribeTargetHealthInput) (*elb.DescribeTargetHealthOutput, error) {
+	state := elb.TargetHealthStateEnumUnhealthy
+	switch *input.TargetGroupArn {
+	case "tg-arn-healthy":
+		state = elb.TargetHealthStateEnumHealthy
+	case "tg-arn-unavailable":
+		state = elb.TargetHealthStateEnumUnavailable
+	}
+	return &elb.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elb.TargetHealthDescription{
+			{TargetHealth: &elb.TargetHealth{State: aws.String(state)}},
+			{TargetHealth: &elb.TargetHealth{State: aws.String(elb.TargetHealthStateEnumUnhealthy)}},
+		},
+	}, nil
+}
+
+func (t mockLoadBalancerClient) DeleteLoadBalancer(input *elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error) {
+	t.operationChannel <- "DeleteLoadBalancer:" + *input.LoadBalancerArn
+	return nil, nil
+}
+
+type mockClassicLoadBalancerClient struct {
+	operationChannel chan string
+}
+
+func (t mockClassicLoadBalancerClient) DescribeLoadBalancers(*classicelb.DescribeLoadBalancersInput) (*classicelb.DescribeLoadBalancersOutput, error) {
+	return &classicelb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*classicelb.LoadBalancerDescription{
+			{LoadBalancerName: aws.String("classic-empty")},
+		},
+	}, nil
+}
+
+func (t mockClassicLoadBalancerClient) DescribeTags(*classicelb.DescribeTagsInput) (*classicelb.DescribeTagsOutput, error) {
+	return &classicelb.DescribeTagsOutput{}, nil
+}
+
+func (t mockClassicLoadBalancerClient) DescribeInstanceHealth(*classicelb.DescribeInstanceHealthInput) (*classicelb.DescribeInstanceHealthOutput, error) {
+	t.operationChannel <- "DescribeInstanceHealth"
+	return &classicelb.DescribeInstanceHealthOutput{}, nil
+}
+
+func (t mockClassicLoadBalancerClient) DeleteLoadBalancer(input *classicelb.DeleteLoadBalancerInput) (*classicelb.DeleteLoadBalancerOutput, error) {
+	t.operationChannel <- "DeleteClassicLoadBalancer:" + *input.LoadBalancerName
+	return nil, nil
+}
+
+func TestGetLoadBalancers(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	loadBalancers, err := getLoadBalancers(
+		map[string]loadBalancerClient{"eu-west-1": mockLoadBalancerClient{operationChannel: operationChannel}},
+		map[string]classicLoadBalancerClient{"eu-west-1": mockClassicLoadBalancerClient{operationChannel: operationChannel}})
+	close(operationChannel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(loadBalancers))
+	loadBalancerByName := map[string]*types.LoadBalancer{}
+	for _, loadBalancer := range loadBalancers {
+		loadBalancerByName[loadBalancer.Name] = loadBalancer
+	}
+
+	healthy := loadBalancerByName["healthy"]
+	assert.Equal(t, types.InUse, healthy.State)
+	assert.Equal(t, 2, healthy.Targets)
+	assert.Equal(t, 1, healthy.HealthyTargets)
+	assert.Equal(t, "owner", healthy.Owner)
+	assert.Equal(t, "application", healthy.Type)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), healthy.Created)
+
+	assert.Equal(t, types.Unhealthy, loadBalancerByName["unhealthy"].State)
+	assert.Equal(t, 2, loadBalancerByName["unhealthy"].Targets)
+
+	assert.Equal(t, types.InUse, loadBalancerByName["lambda"].State)
+	assert.Equal(t, 2, loadBalancerByName["lambda"].HealthyTargets)
+
+	assert.Equal(t, types.InUse, loadBalancerByName["unavailable"].State)
+	assert.Equal(t, 1, loadBalancerByName["unavailable"].HealthyTargets)
+
+	classic := loadBalancerByName["classic-empty"]
+	assert.Equal(t, types.Unused, classic.State)
+	assert.Equal(t, classicLoadBalancerType, classic.Type)
+	assert.Equal(t, 0, classic.Targets)
+	assert.Equal(t, 0, len(operationChannel), "instance health is not queried without registered instances")
+}
+
+func TestDeleteLoadBalancers(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteLoadBalancers(
+		map[string]loadBalancerClient{"eu-west-1": mockLoadBalancerClient{operationChannel: operationChannel}},
+		map[string]classicLoadBalancerClient{"eu-west-1": mockClassicLoadBalancerClient{operationChannel: operationChannel}},
+		[]*types.LoadBalancer{
+			{ID: "arn-1", Name: "alb", Type: "application", Region: "eu-west-1"},
+			{ID: "classic", Name: "classic", Type: classicLoadBalancerType, Region: "eu-west-1"},
+		})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"DeleteLoadBalancer:arn-1", "DeleteClassicLoadBalancer:classic"}, operations)
+}
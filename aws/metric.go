@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxDatapoints is the maximum number of datapoints CloudWatch returns in a single request
+const maxDatapoints = 1440
+
+func (p awsProvider) GetMetricValues(item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	return getMetricValues(p.getCloudWatchClientsByRegion(), item, query)
+}
+
+// getMetricValues returns the datapoints of the metric of the item in chronological order. The period defaults
+// to the shortest one that CloudWatch can return in a single request.
+func getMetricValues(cloudWatchClients map[string]cloudWatchClient, item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	namespace, dimensions, region, err := getMetricDimensions(item)
+	if err != nil {
+		return nil, err
+	}
+	cwClient, ok := cloudWatchClients[region]
+	if !ok {
+		return nil, fmt.Errorf("there is no CloudWatch client in region: %s", region)
+	}
+	period := query.Period
+	if period == 0 {
+		period = getDefaultMetricPeriod(query.End.Sub(query.Start))
+	}
+
+	var datapoints []*cloudwatch.Datapoint
+	for start := query.Start; start.Before(query.End); start = start.Add(period * maxDatapoints) {
+		end := start.Add(period * maxDatapoints)
+		if end.After(query.End) {
+			end = query.End
+		}
+		log.Debugf("[AWS] Fetching %s %s of %s from %s to %s", query.Statistic, query.Name, item.GetName(), start, end)
+		result, err := cwClient.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+			Namespace:  aws.String(namespace),
+			MetricName: aws.String(query.Name),
+			Dimensions: dimensions,
+			StartTime:  aws.Time(start),
+			EndTime:    aws.Time(end),
+			Period:     aws.Int64(int64(period.Seconds())),
+			Statistics: []*string{aws.String(query.Statistic)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		datapoints = append(datapoints, result.Datapoints...)
+	}
+	sort.Slice(datapoints, func(i, j int) bool {
+		return datapoints[i].Timestamp.Before(*datapoints[j].Timestamp)
+	})
+
+	values := make([]float64, 0, len(datapoints))
+	for _, datapoint := range datapoints {
+		switch query.Statistic {
+		case types.AverageStatistic:
+			values = append(values, aws.Float64Value(datapoint.Average))
+		case types.SumStatistic:
+			values = append(values, aws.Float64Value(datapoint.Sum))
+		default:
+			return nil, fmt.Errorf("unsupported statistic: %s", query.Statistic)
+		}
+	}
+	return values, nil
+}
+
+// getMetricDimensions returns the namespace and the dimensions of the item, CloudWatch only returns the metrics of
+// the exact dimension set that the service publishes.
+func getMetricDimensions(item types.CloudItem) (namespace string, dimensions []*cloudwatch.Dimension, region string, err error) {
+	switch t := item.GetItem().(type) {
+	case types.Instance:
+		return "AWS/EC2", []*cloudwatch.Dimension{newDimension("InstanceId", t.ID)}, t.Region, nil
+	case types.Database:
+		switch t.Type {
+		case types.ElastiCacheDatabase:
+			// the host level metrics are published per node, the ones of the first node are measured
+			return "AWS/ElastiCache", []*cloudwatch.Dimension{newDimension("CacheClusterId", t.Name), newDimension("CacheNodeId", "0001")}, t.Region, nil
+		case types.RedshiftDatabase:
+			return "AWS/Redshift", []*cloudwatch.Dimension{newDimension("ClusterIdentifier", t.Name)}, t.Region, nil
+		}
+		return "AWS/RDS", []*cloudwatch.Dimension{newDimension("DBInstanceIdentifier", t.Name)}, t.Region, nil
+	case types.Cluster:
+		switch t.Type {
+		case types.EmrCluster:
+			return "AWS/ElasticMapReduce", []*cloudwatch.Dimension{newDimension("JobFlowId", t.Uuid)}, t.Region, nil
+		case types.EksCluster:
+			return "ContainerInsights", []*cloudwatch.Dimension{newDimension("ClusterName", t.Name)}, t.Region, nil
+		}
+	case types.Function:
+		return "AWS/Lambda", []*cloudwatch.Dimension{newDimension("FunctionName", t.Name)}, t.Region, nil
+	case types.Gateway:
+		switch t.Type {
+		case types.NatGateway:
+			return "AWS/NATGateway", []*cloudwatch.Dimension{newDimension("NatGatewayId", t.ID)}, t.Region, nil
+		case types.VpcEndpoint:
+			return "AWS/PrivateLinkEndpoints", []*cloudwatch.Dimension{
+				newDimension("Endpoint Type", t.Metadata["endpointType"]),
+				newDimension("Service Name", t.Metadata["serviceName"]),
+				newDimension("VPC Endpoint Id", t.ID),
+				newDimension("VPC Id", t.VpcID),
+			}, t.Region, nil
+		}
+	}
+	return "", nil, "", fmt.Errorf("metrics are not supported for %s: %s", item.GetType(), item.GetName())
+}
+
+func newDimension(name, value string) *cloudwatch.Dimension {
+	return &cloudwatch.Dimension{Name: aws.String(name), Value: aws.String(value)}
+}
+
+// getDefaultMetricPeriod returns the shortest period, in whole minutes, that returns the interval in a single request
+func getDefaultMetricPeriod(interval time.Duration) time.Duration {
+	period := (interval/maxDatapoints + time.Minute - 1).Truncate(time.Minute)
+	if period < 5*time.Minute {
+		return 5 * time.Minute
+	}
+	return period
+}
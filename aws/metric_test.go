@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetricValues(t *testing.T) {
+	operationChannel := make(chan string, 10)
+	end := time.Now()
+	query := types.MetricQuery{Name: "CPUUtilization", Statistic: types.AverageStatistic, Period: time.Hour, Start: end.Add(-30 * 24 * time.Hour), End: end}
+
+	values, err := getMetricValues(map[string]cloudWatchClient{"region": mockCwClient{operationChannel: operationChannel}},
+		&types.Instance{ID: "i-1", Region: "region"}, query)
+	close(operationChannel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1, 2}, values)
+	assert.Equal(t, "GetMetricStatistics:CPUUtilization:3600", <-operationChannel)
+}
+
+func TestGetMetricValuesInMultipleRequests(t *testing.T) {
+	operationChannel := make(chan string, 10)
+	end := time.Now()
+	query := types.MetricQuery{Name: "NetworkIn", Statistic: types.SumStatistic, Period: 5 * time.Minute, Start: end.Add(-10 * 24 * time.Hour), End: end}
+
+	values, err := getMetricValues(map[string]cloudWatchClient{"region": mockCwClient{operationChannel: operationChannel}},
+		&types.Instance{ID: "i-1", Region: "region"}, query)
+	close(operationChannel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(values))
+	assert.Equal(t, 2, len(operationChannel))
+}
+
+func TestGetMetricValuesNotSupported(t *testing.T) {
+	_, err := getMetricValues(map[string]cloudWatchClient{}, &types.Access{Name: "access"}, types.MetricQuery{})
+
+	assert.NotNil(t, err)
+}
+
+func TestGetDefaultMetricPeriod(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, getDefaultMetricPeriod(24*time.Hour))
+	assert.Equal(t, 30*time.Minute, getDefaultMetricPeriod(30*24*time.Hour))
+}
+
+func TestGetMetricDimensionsDatabase(t *testing.T) {
+	namespace, dimensions, region, err := getMetricDimensions(&types.Database{ID: "db-ABC", Name: "db", Region: "region"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/RDS", namespace)
+	assert.Equal(t, "DBInstanceIdentifier", *dimensions[0].Name)
+	assert.Equal(t, "db", *dimensions[0].Value)
+	assert.Equal(t, "region", region)
+}
+
+func TestGetMetricDimensionsCacheCluster(t *testing.T) {
+	namespace, dimensions, _, err := getMetricDimensions(&types.Database{Name: "cache", Type: types.ElastiCacheDatabase, Region: "region"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/ElastiCache", namespace)
+	assert.Equal(t, "CacheClusterId", *dimensions[0].Name)
+	assert.Equal(t, "cache", *dimensions[0].Value)
+	assert.Equal(t, "CacheNodeId", *dimensions[1].Name)
+}
+
+func TestGetMetricDimensionsRedshiftCluster(t *testing.T) {
+	namespace, dimensions, _, err := getMetricDimensions(&types.Database{Name: "warehouse", Type: types.RedshiftDatabase, Region: "region"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/Redshift", namespace)
+	assert.Equal(t, "ClusterIdentifier", *dimensions[0].Name)
+	assert.Equal(t, "warehouse", *dimensions[0].Value)
+}
+
+func TestGetMetricDimensionsCluster(t *testing.T) {
+	namespace, dimensions, region, err := getMetricDimensions(&types.Cluster{Uuid: "j-1", Name: "cluster", Type: types.EmrCluster, Region: "region"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/ElasticMapReduce", namespace)
+	assert.Equal(t, "JobFlowId", *dimensions[0].Name)
+	assert.Equal(t, "j-1", *dimensions[0].Value)
+	assert.Equal(t, "region", region)
+}
+
+func TestGetMetricDimensionsNatGateway(t *testing.T) {
+	namespace, dimensions, region, err := getMetricDimensions(&types.Gateway{ID: "nat-1", Type: types.NatGateway, Region: "region"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/NATGateway", namespace)
+	assert.Equal(t, 1, len(dimensions))
+	assert.Equal(t, "NatGatewayId", *dimensions[0].Name)
+	assert.Equal(t, "nat-1", *dimensions[0].Value)
+	assert.Equal(t, "region", region)
+}
+
+func TestGetMetricDimensionsVpcEndpoint(t *testing.T) {
+	namespace, dimensions, _, err := getMetricDimensions(&types.Gateway{ID: "vpce-1", Type: types.VpcEndpoint, VpcID: "vpc-1",
+		Metadata: map[string]string{"endpointType": "Interface", "serviceName": "com.amazonaws.eu-west-1.s3"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "AWS/PrivateLinkEndpoints", namespace)
+	values := map[string]string{}
+	for _, dimension := range dimensions {
+		values[*dimension.Name] = *dimension.Value
+	}
+	assert.Equal(t, map[string]string{"Endpoint Type": "Interface", "Service Name": "com.amazonaws.eu-west-1.s3", "VPC Endpoint Id": "vpce-1", "VPC Id": "vpc-1"}, values)
+}
@@ -0,0 +1,282 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type vpcClient interface {
+	DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeInternetGateways(input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error)
+	DetachInternetGateway(input *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error)
+	DeleteInternetGateway(input *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error)
+	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DeleteSubnet(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error)
+	DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	DeleteRouteTable(input *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error)
+	DescribeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error)
+	DeleteNetworkAcl(input *ec2.DeleteNetworkAclInput) (*ec2.DeleteNetworkAclOutput, error)
+	DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
+	DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error)
+	DeleteVpc(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error)
+}
+
+func (p awsProvider) getVpcClientsByRegion() map[string]vpcClient {
+	vpcClients := map[string]vpcClient{}
+	for k := range p.ec2Clients {
+		vpcClients[k] = p.ec2Clients[k]
+	}
+	return vpcClients
+}
+
+func (p awsProvider) GetNetworks() ([]*types.Network, error) {
+	log.Debug("[AWS] Fetch VPCs")
+	return getNetworks(p.getVpcClientsByRegion())
+}
+
+func (p awsProvider) DeleteNetworks(networks *types.NetworkContainer) []error {
+	log.Debug("[AWS] Delete VPCs")
+	return deleteNetworks(p.getVpcClientsByRegion(), networks.Get(types.AWS))
+}
+
+// getNetworks returns the non-default VPCs with the number of network interfaces in them. Every billable resource
+// placed in a VPC (instances, load balancers, NAT gateways, interface endpoints, databases) has a network interface.
+func getNetworks(vpcClients map[string]vpcClient) ([]*types.Network, error) {
+	networkChan := make(chan *types.Network)
+	wg := sync.WaitGroup{}
+	wg.Add(len(vpcClients))
+
+	for r, c := range vpcClients {
+		log.Debugf("[AWS] Fetching VPCs from region: %s", r)
+		go func(region string, vpcClient vpcClient) {
+			defer wg.Done()
+
+			interfaceCounts, err := getNetworkInterfaceCountsByVpc(vpcClient)
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the network interfaces in region: %s, err: %s", region, err)
+				return
+			}
+
+			request := &ec2.DescribeVpcsInput{}
+			for {
+				result, err := vpcClient.DescribeVpcs(request)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the VPCs in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing VPCs (%d) in region: %s", len(result.Vpcs), region)
+				for _, vpc := range result.Vpcs {
+					if aws.BoolValue(vpc.IsDefault) {
+						log.Debugf("[AWS] Skipping default VPC: %s in region: %s", aws.StringValue(vpc.VpcId), region)
+						continue
+					}
+					networkChan <- newNetwork(vpc, interfaceCounts[aws.StringValue(vpc.VpcId)], region)
+				}
+				if result.NextToken == nil {
+					break
+				}
+				request.SetNextToken(*result.NextToken)
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(networkChan)
+	}()
+
+	var networks []*types.Network
+	for network := range networkChan {
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func getNetworkInterfaceCountsByVpc(vpcClient vpcClient) (map[string]int, error) {
+	interfaceCounts := map[string]int{}
+	request := &ec2.DescribeNetworkInterfacesInput{}
+	for {
+		result, err := vpcClient.DescribeNetworkInterfaces(request)
+		if err != nil {
+			return nil, err
+		}
+		for _, networkInterface := range result.NetworkInterfaces {
+			interfaceCounts[aws.StringValue(networkInterface.VpcId)]++
+		}
+		if result.NextToken == nil {
+			return interfaceCounts, nil
+		}
+		request.SetNextToken(*result.NextToken)
+	}
+}
+
+func deleteNetworks(vpcClients map[string]vpcClient, networks []*types.Network) []error {
+	regionNetworks := map[string][]*types.Network{}
+	for _, network := range networks {
+		regionNetworks[network.Region] = append(regionNetworks[network.Region], network)
+	}
+	log.Debugf("[AWS] Delete VPCs: %v", regionNetworks)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionNetworks))
+	errChan := make(chan error)
+
+	for r, n := range regionNetworks {
+		go func(vpcClient vpcClient, region string, networks []*types.Network) {
+			defer wg.Done()
+
+			for _, network := range networks {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, VPC is not deleted: %s, region: %s", network.Name, region)
+					continue
+				}
+				log.Infof("[AWS] Delete VPC: %s, region: %s", network.Name, region)
+				if err := deleteVpc(vpcClient, network.ID); err != nil {
+					log.Errorf("[AWS] Failed to delete VPC: %s, err: %s", network.ID, err)
+					errChan <- err
+				}
+			}
+		}(vpcClients[r], r, n)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// deleteVpc deletes the free resources that are left in the VPC, then the VPC itself. The main route table, the
+// default network ACL and the default security group are deleted together with the VPC. The network interfaces are
+// counted again before anything is deleted, because a resource may have been placed in the VPC since it was listed.
+func deleteVpc(vpcClient vpcClient, vpcID string) error {
+	vpcFilter := []*ec2.Filter{{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}}}
+
+	interfaces, err := vpcClient.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+	if len(interfaces.NetworkInterfaces) > 0 {
+		return fmt.Errorf("VPC %s is not empty, it has network interfaces", vpcID)
+	}
+
+	gateways, err := vpcClient.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
+		Filters: []*ec2.Filter{{Name: aws.String("attachment.vpc-id"), Values: []*string{aws.String(vpcID)}}},
+	})
+	if err != nil {
+		return err
+	}
+	for _, gateway := range gateways.InternetGateways {
+		log.Debugf("[AWS] Delete internet gateway: %s of VPC: %s", aws.StringValue(gateway.InternetGatewayId), vpcID)
+		if _, err := vpcClient.DetachInternetGateway(&ec2.DetachInternetGatewayInput{InternetGatewayId: gateway.InternetGatewayId, VpcId: aws.String(vpcID)}); err != nil {
+			return err
+		}
+		if _, err := vpcClient.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{InternetGatewayId: gateway.InternetGatewayId}); err != nil {
+			return err
+		}
+	}
+
+	subnets, err := vpcClient.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets.Subnets {
+		log.Debugf("[AWS] Delete subnet: %s of VPC: %s", aws.StringValue(subnet.SubnetId), vpcID)
+		if _, err := vpcClient.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId}); err != nil {
+			return err
+		}
+	}
+
+	routeTables, err := vpcClient.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+	for _, routeTable := range routeTables.RouteTables {
+		if isMainRouteTable(routeTable) {
+			continue
+		}
+		log.Debugf("[AWS] Delete route table: %s of VPC: %s", aws.StringValue(routeTable.RouteTableId), vpcID)
+		if _, err := vpcClient.DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: routeTable.RouteTableId}); err != nil {
+			return err
+		}
+	}
+
+	acls, err := vpcClient.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+	for _, acl := range acls.NetworkAcls {
+		if aws.BoolValue(acl.IsDefault) {
+			continue
+		}
+		log.Debugf("[AWS] Delete network ACL: %s of VPC: %s", aws.StringValue(acl.NetworkAclId), vpcID)
+		if _, err := vpcClient.DeleteNetworkAcl(&ec2.DeleteNetworkAclInput{NetworkAclId: acl.NetworkAclId}); err != nil {
+			return err
+		}
+	}
+
+	securityGroups, err := vpcClient.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+	for _, securityGroup := range securityGroups.SecurityGroups {
+		if aws.StringValue(securityGroup.GroupName) == "default" {
+			continue
+		}
+		log.Debugf("[AWS] Delete security group: %s of VPC: %s", aws.StringValue(securityGroup.GroupId), vpcID)
+		if _, err := vpcClient.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: securityGroup.GroupId}); err != nil {
+			return err
+		}
+	}
+
+	_, err = vpcClient.DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String(vpcID)})
+	return err
+}
+
+func isMainRouteTable(routeTable *ec2.RouteTable) bool {
+	for _, association := range routeTable.Associations {
+		if aws.BoolValue(association.Main) {
+			return true
+		}
+	}
+	return false
+}
+
+// newNetwork converts the VPC, the creation time of a VPC is not available from the API
+func newNetwork(vpc *ec2.Vpc, resourceCount int, region string) *types.Network {
+	tags := getEc2Tags(vpc.Tags)
+	name, ok := tags["Name"]
+	if !ok {
+		name = aws.StringValue(vpc.VpcId)
+	}
+	state := types.Running
+	if aws.StringValue(vpc.State) == ec2.VpcStatePending {
+		state = types.Creating
+	}
+	return &types.Network{
+		ID:        aws.StringValue(vpc.VpcId),
+		Name:      name,
+		State:     state,
+		Owner:     tags[ctx.OwnerLabel],
+		CloudType: types.AWS,
+		Region:    region,
+		Metadata: map[string]string{
+			"cidrBlock":                    aws.StringValue(vpc.CidrBlock),
+			types.ResourceCountMetadataKey: strconv.Itoa(resourceCount),
+		},
+		Tags: tags,
+	}
+}
@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockVpcClient struct {
+	operationChannel chan string
+}
+
+func (t mockVpcClient) DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	return &ec2.DescribeVpcsOutput{
+		Vpcs: []*ec2.Vpc{
+			{
+				VpcId:     aws.String("vpc-1"),
+				CidrBlock: aws.String("10.0.0.0/16"),
+				State:     aws.String(ec2.VpcStateAvailable),
+				Tags:      []*ec2.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+			},
+			{VpcId: aws.String("vpc-2"), State: aws.String(ec2.VpcStateAvailable), Tags: []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("empty")}}},
+			{VpcId: aws.String("vpc-default"), State: aws.String(ec2.VpcStateAvailable), IsDefault: aws.Bool(true)},
+		},
+	}, nil
+}
+
+func (t mockVpcClient) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	networkInterfaces := []*ec2.NetworkInterface{
+		{NetworkInterfaceId: aws.String("eni-1"), VpcId: aws.String("vpc-1")},
+		{NetworkInterfaceId: aws.String("eni-2"), VpcId: aws.String("vpc-1")},
+		{NetworkInterfaceId: aws.String("eni-3"), VpcId: aws.String("vpc-default")},
+	}
+	if len(input.Filters) == 0 {
+		return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: networkInterfaces}, nil
+	}
+	output := &ec2.DescribeNetworkInterfacesOutput{}
+	for _, networkInterface := range networkInterfaces {
+		if *networkInterface.VpcId == *input.Filters[0].Values[0] {
+			output.NetworkInterfaces = append(output.NetworkInterfaces, networkInterface)
+		}
+	}
+	return output, nil
+}
+
+func (t mockVpcClient) DescribeInternetGateways(*ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return &ec2.DescribeInternetGatewaysOutput{InternetGateways: []*ec2.InternetGateway{{InternetGatewayId: aws.String("igw-1")}}}, nil
+}
+
+func (t mockVpcClient) DetachInternetGateway(input *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
+	t.operationChannel <- "DetachInternetGateway:" + *input.InternetGatewayId
+	return nil, nil
+}
+
+func (t mockVpcClient) DeleteInternetGateway(input *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
+	t.operationChannel <- "DeleteInternetGateway:" + *input.InternetGatewayId
+	return nil, nil
+}
+
+func (t mockVpcClient) DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1")}}}, nil
+}
+
+func (t mockVpcClient) DeleteSubnet(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
+	t.operationChannel <- "DeleteSubnet:" + *input.SubnetId
+	return nil, nil
+}
+
+func (t mockVpcClient) DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return &ec2.DescribeRouteTablesOutput{
+		RouteTables: []*ec2.RouteTable{
+			{RouteTableId: aws.String("rtb-main"), Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}}},
+			{RouteTableId: aws.String("rtb-1")},
+		},
+	}, nil
+}
+
+func (t mockVpcClient) DeleteRouteTable(input *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+	t.operationChannel <- "DeleteRouteTable:" + *input.RouteTableId
+	return nil, nil
+}
+
+func (t mockVpcClient) DescribeNetworkAcls(*ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	return &ec2.DescribeNetworkAclsOutput{
+		NetworkAcls: []*ec2.NetworkAcl{
+			{NetworkAclId: aws.String("acl-default"), IsDefault: aws.Bool(true)},
+			{NetworkAclId: aws.String("acl-1"), IsDefault: aws.Bool(false)},
+		},
+	}, nil
+}
+
+func (t mockVpcClient) DeleteNetworkAcl(input *ec2.DeleteNetworkAclInput) (*ec2.DeleteNetworkAclOutput, error) {
+	t.operationChannel <- "DeleteNetworkAcl:" + *input.NetworkAclId
+	return nil, nil
+}
+
+func (t mockVpcClient) DescribeSecurityGroups(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return &ec2.DescribeSecurityGroupsOutput{
+		SecurityGroups: []*ec2.SecurityGroup{
+			{GroupId: aws.String("sg-default"), GroupName: aws.String("default")},
+			{GroupId: aws.String("sg-1"), GroupName: aws.String("web")},
+		},
+	}, nil
+}
+
+func (t mockVpcClient) DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	t.operationChannel <- "DeleteSecurityGroup:" + *input.GroupId
+	return nil, nil
+}
+
+func (t mockVpcClient) DeleteVpc(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
+	t.operationChannel <- "DeleteVpc:" + *input.VpcId
+	return nil, nil
+}
+
+func TestGetNetworks(t *testing.T) {
+	networks, err := getNetworks(map[string]vpcClient{"eu-west-1": mockVpcClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(networks))
+
+	network := networks[0]
+	assert.Equal(t, "vpc-1", network.ID)
+	assert.Equal(t, "vpc-1", network.Name)
+	assert.Equal(t, types.Running, network.State)
+	assert.Equal(t, "owner", network.Owner)
+	assert.Equal(t, "eu-west-1", network.Region)
+	assert.Equal(t, "10.0.0.0/16", network.Metadata["cidrBlock"])
+	assert.Equal(t, "2", network.Metadata[types.ResourceCountMetadataKey])
+
+	emptyNetwork := networks[1]
+	assert.Equal(t, "empty", emptyNetwork.Name)
+	assert.Equal(t, "0", emptyNetwork.Metadata[types.ResourceCountMetadataKey])
+}
+
+func TestDeleteNetworks(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteNetworks(map[string]vpcClient{"eu-west-1": mockVpcClient{operationChannel: operationChannel}}, []*types.Network{
+		{ID: "vpc-2", Region: "eu-west-1"},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{
+		"DetachInternetGateway:igw-1",
+		"DeleteInternetGateway:igw-1",
+		"DeleteSubnet:subnet-1",
+		"DeleteRouteTable:rtb-1",
+		"DeleteNetworkAcl:acl-1",
+		"DeleteSecurityGroup:sg-1",
+		"DeleteVpc:vpc-2",
+	}, operations)
+}
+
+func TestDeleteNetworksNotEmpty(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := deleteNetworks(map[string]vpcClient{"eu-west-1": mockVpcClient{operationChannel: operationChannel}}, []*types.Network{
+		{ID: "vpc-1", Region: "eu-west-1"},
+	})
+	close(operationChannel)
+
+	assert.Equal(t, 1, len(errs))
+	assert.Empty(t, operationChannel)
+}
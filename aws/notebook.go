@@ -0,0 +1,169 @@
+package aws
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type sageMakerClient interface {
+	ListNotebookInstances(input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error)
+	ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error)
+	StopNotebookInstance(input *sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error)
+}
+
+func (p awsProvider) getSageMakerClientsByRegion() map[string]sageMakerClient {
+	sageMakerClients := map[string]sageMakerClient{}
+	for k := range p.sageMakerClients {
+		sageMakerClients[k] = p.sageMakerClients[k]
+	}
+	return sageMakerClients
+}
+
+func (p awsProvider) GetNotebooks() ([]*types.Notebook, error) {
+	log.Debug("[AWS] Fetch SageMaker notebook instances")
+	return getNotebooks(p.getSageMakerClientsByRegion())
+}
+
+func (p awsProvider) StopNotebooks(notebooks *types.NotebookContainer) []error {
+	log.Debug("[AWS] Stop SageMaker notebook instances")
+	return stopNotebooks(p.getSageMakerClientsByRegion(), notebooks.Get(types.AWS))
+}
+
+func getNotebooks(sageMakerClients map[string]sageMakerClient) ([]*types.Notebook, error) {
+	notebookChan := make(chan *types.Notebook)
+	wg := sync.WaitGroup{}
+	wg.Add(len(sageMakerClients))
+
+	for r, c := range sageMakerClients {
+		log.Debugf("[AWS] Fetching SageMaker notebook instances from: %s", r)
+		go func(region string, sageMakerClient sageMakerClient) {
+			defer wg.Done()
+
+			input := &sagemaker.ListNotebookInstancesInput{}
+			for {
+				result, err := sageMakerClient.ListNotebookInstances(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the SageMaker notebook instances in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing SageMaker notebook instances (%d) in region: %s", len(result.NotebookInstances), region)
+				for _, notebook := range result.NotebookInstances {
+					tags := types.Tags{}
+					tagList, err := sageMakerClient.ListTags(&sagemaker.ListTagsInput{ResourceArn: notebook.NotebookInstanceArn})
+					if err != nil {
+						log.Debugf("[AWS] Cannot list tags for SageMaker notebook instance: %s", aws.StringValue(notebook.NotebookInstanceName))
+					} else {
+						for _, tag := range tagList.Tags {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+					}
+					notebookChan <- newNotebook(notebook, tags, region)
+				}
+				if result.NextToken == nil {
+					break
+				}
+				input.NextToken = result.NextToken
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(notebookChan)
+	}()
+
+	var notebooks []*types.Notebook
+	for notebook := range notebookChan {
+		notebooks = append(notebooks, notebook)
+	}
+	return notebooks, nil
+}
+
+func stopNotebooks(sageMakerClients map[string]sageMakerClient, notebooks []*types.Notebook) []error {
+	regionNotebooks := map[string][]*types.Notebook{}
+	for _, notebook := range notebooks {
+		regionNotebooks[notebook.Region] = append(regionNotebooks[notebook.Region], notebook)
+	}
+	log.Debugf("[AWS] Stop SageMaker notebook instances: %v", regionNotebooks)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionNotebooks))
+	errChan := make(chan error)
+
+	for r, n := range regionNotebooks {
+		go func(region string, notebooks []*types.Notebook) {
+			defer wg.Done()
+
+			sageMakerClient, ok := sageMakerClients[region]
+			if !ok {
+				errChan <- fmt.Errorf("there is no SageMaker client in region: %s", region)
+				return
+			}
+			for _, notebook := range notebooks {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, SageMaker notebook instance is not stopped: %s, region: %s", notebook.Name, region)
+					continue
+				}
+				log.Infof("[AWS] Stop SageMaker notebook instance: %s, region: %s", notebook.Name, region)
+				if _, err := sageMakerClient.StopNotebookInstance(&sagemaker.StopNotebookInstanceInput{NotebookInstanceName: aws.String(notebook.Name)}); err != nil {
+					log.Errorf("[AWS] Failed to stop SageMaker notebook instance: %s, err: %s", notebook.Name, err)
+					errChan <- err
+				}
+			}
+		}(r, n)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newNotebook(notebook *sagemaker.NotebookInstanceSummary, tags types.Tags, region string) *types.Notebook {
+	return &types.Notebook{
+		ID:           aws.StringValue(notebook.NotebookInstanceArn),
+		Name:         aws.StringValue(notebook.NotebookInstanceName),
+		Type:         types.SageMakerNotebook,
+		InstanceType: aws.StringValue(notebook.InstanceType),
+		Created:      getCreated(notebook.CreationTime),
+		LastModified: getCreated(notebook.LastModifiedTime),
+		State:        getNotebookState(aws.StringValue(notebook.NotebookInstanceStatus)),
+		Owner:        tags[ctx.OwnerLabel],
+		CloudType:    types.AWS,
+		Region:       region,
+		Metadata:     map[string]string{"url": aws.StringValue(notebook.Url)},
+		Tags:         tags,
+	}
+}
+
+func getNotebookState(status string) types.State {
+	switch status {
+	case sagemaker.NotebookInstanceStatusPending:
+		return types.Starting
+	case sagemaker.NotebookInstanceStatusInService:
+		return types.Running
+	case sagemaker.NotebookInstanceStatusStopping:
+		return types.Stopping
+	case sagemaker.NotebookInstanceStatusStopped:
+		return types.Stopped
+	case sagemaker.NotebookInstanceStatusUpdating:
+		return types.Updating
+	case sagemaker.NotebookInstanceStatusDeleting:
+		return types.Deleting
+	case sagemaker.NotebookInstanceStatusFailed:
+		return types.Failed
+	}
+	return types.Unknown
+}
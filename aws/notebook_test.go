@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSageMakerClient struct {
+	operationChannel chan string
+}
+
+func (t mockSageMakerClient) ListNotebookInstances(*sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+	return &sagemaker.ListNotebookInstancesOutput{
+		NotebookInstances: []*sagemaker.NotebookInstanceSummary{
+			{
+				NotebookInstanceArn:    aws.String("arn-notebook"),
+				NotebookInstanceName:   aws.String("notebook"),
+				NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+				InstanceType:           aws.String(sagemaker.InstanceTypeMlT3Medium),
+				CreationTime:           aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+				LastModifiedTime:       aws.Time(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}, nil
+}
+
+func (t mockSageMakerClient) ListTags(*sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	return &sagemaker.ListTagsOutput{
+		Tags: []*sagemaker.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+	}, nil
+}
+
+func (t mockSageMakerClient) StopNotebookInstance(input *sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+	t.operationChannel <- "StopNotebookInstance:" + *input.NotebookInstanceName
+	return nil, nil
+}
+
+func TestGetNotebooks(t *testing.T) {
+	notebooks, err := getNotebooks(map[string]sageMakerClient{"eu-west-1": mockSageMakerClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(notebooks))
+	notebook := notebooks[0]
+	assert.Equal(t, "notebook", notebook.Name)
+	assert.Equal(t, types.SageMakerNotebook, notebook.Type)
+	assert.Equal(t, "ml.t3.medium", notebook.InstanceType)
+	assert.Equal(t, types.Running, notebook.State)
+	assert.Equal(t, "owner", notebook.Owner)
+	assert.Equal(t, "eu-west-1", notebook.Region)
+	assert.Equal(t, time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), notebook.LastModified)
+}
+
+func TestStopNotebooks(t *testing.T) {
+	operationChannel := make(chan string, 10)
+
+	errs := stopNotebooks(map[string]sageMakerClient{"eu-west-1": mockSageMakerClient{operationChannel: operationChannel}}, []*types.Notebook{
+		{Name: "notebook", Region: "eu-west-1"},
+		{Name: "unknown", Region: "us-east-1"},
+	})
+	close(operationChannel)
+
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, []string{"StopNotebookInstance:notebook"}, operations)
+}
@@ -0,0 +1,379 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// cloudTrailLookbackPeriod is the period CloudTrail keeps the management events for
+var cloudTrailLookbackPeriod = 90 * 24 * time.Hour
+
+// cloudTrailLookupInterval is the time between the LookupEvents requests in a region, CloudTrail allows 2 requests
+// per second per account and region
+var cloudTrailLookupInterval = 500 * time.Millisecond
+
+// cloudTrailMaxRetries is the number of times a throttled LookupEvents request is retried with exponential backoff
+var cloudTrailMaxRetries = 5
+
+// ownerResource is a resource without owner whose creator is looked up in the CloudTrail events
+type ownerResource struct {
+	region    string
+	names     []string
+	created   time.Time
+	eventName string
+	setOwner  func(iamUser string)
+}
+
+type cloudTrailEvent struct {
+	UserIdentity struct {
+		T string `json:"type"`
+	} `json:"userIdentity"`
+}
+
+func newInstanceOwnerResources(instances []*types.Instance) []*ownerResource {
+	var resources []*ownerResource
+	for _, i := range instances {
+		if len(i.Owner) == 0 && (ctx.OwnerInference || i.State == types.Running) {
+			inst := i
+			resources = append(resources, &ownerResource{inst.Region, []string{inst.ID}, inst.Created, "RunInstances", func(iamUser string) {
+				inst.Metadata = setIAMUser(inst.Metadata, iamUser)
+				inst.Owner = getInferredOwner(inst.Owner, iamUser)
+			}})
+		}
+	}
+	return resources
+}
+
+func newDiskOwnerResources(disks []*types.Disk) []*ownerResource {
+	var resources []*ownerResource
+	for _, d := range disks {
+		if len(d.Owner) == 0 && ctx.OwnerInference {
+			disk := d
+			resources = append(resources, &ownerResource{disk.Region, []string{disk.ID}, disk.Created, "CreateVolume", func(iamUser string) {
+				disk.Metadata = setIAMUser(disk.Metadata, iamUser)
+				disk.Owner = getInferredOwner(disk.Owner, iamUser)
+			}})
+		}
+	}
+	return resources
+}
+
+func newDatabaseOwnerResources(databases []*types.Database) []*ownerResource {
+	var resources []*ownerResource
+	for _, d := range databases {
+		if len(d.Owner) == 0 && (ctx.OwnerInference || d.State == types.Running) {
+			db := d
+			resources = append(resources, &ownerResource{db.Region, []string{db.Name}, db.Created, "CreateDBInstance", func(iamUser string) {
+				db.Metadata = setIAMUser(db.Metadata, iamUser)
+				db.Owner = getInferredOwner(db.Owner, iamUser)
+			}})
+		}
+	}
+	return resources
+}
+
+func newStackOwnerResources(stacks []*types.Stack) []*ownerResource {
+	var resources []*ownerResource
+	for _, s := range stacks {
+		if len(s.Owner) == 0 && ctx.OwnerInference {
+			stack := s
+			resources = append(resources, &ownerResource{stack.Region, []string{stack.ID, stack.Name}, stack.Created, "CreateStack", func(iamUser string) {
+				stack.Metadata = setIAMUser(stack.Metadata, iamUser)
+				stack.Owner = getInferredOwner(stack.Owner, iamUser)
+			}})
+		}
+	}
+	return resources
+}
+
+func setIAMUser(metadata map[string]string, iamUser string) map[string]string {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["IAMUser"] = iamUser
+	return metadata
+}
+
+func getInferredOwner(owner, iamUser string) string {
+	if len(owner) == 0 && utils.IsInferredOwnerPromoted() {
+		return iamUser
+	}
+	return owner
+}
+
+// inferOwners looks up the creators of the resources. Without the owner inference only the running instances and
+// databases are looked up, the disks, stacks and the stopped resources are looked up if it is enabled.
+func (p awsProvider) inferOwners(cloudTrailClients map[string]cloudTrailClient, resources []*ownerResource) {
+	if len(resources) == 0 {
+		return
+	}
+	inferOwners(cloudTrailClients, utils.GetOwnerCache(), resources)
+}
+
+// inferOwners sets the creators of the resources from the cache or the CloudTrail events. The events are looked up
+// in batches per region and event name instead of one lookup per resource.
+func inferOwners(cloudTrailClients map[string]cloudTrailClient, cache *utils.OwnerCache, resources []*ownerResource) {
+	resourcesPerRegion := map[string][]*ownerResource{}
+	for _, resource := range resources {
+		resourcesPerRegion[resource.region] = append(resourcesPerRegion[resource.region], resource)
+	}
+
+	wg := sync.WaitGroup{}
+	for r, res := range resourcesPerRegion {
+		ctClient, ok := cloudTrailClients[r]
+		if !ok {
+			log.Debugf("[AWS] There is no CloudTrail client in region: %s", r)
+			continue
+		}
+		wg.Add(1)
+		go func(region string, cloudTrailClient cloudTrailClient, regionResources []*ownerResource) {
+			defer wg.Done()
+
+			var missing []*ownerResource
+			for _, resource := range regionResources {
+				if owner, ok := cache.Get(getOwnerCacheKey(region, resource)); !ok {
+					missing = append(missing, resource)
+				} else if len(owner) > 0 {
+					resource.setOwner(owner)
+				}
+			}
+			if len(missing) == 0 {
+				return
+			}
+
+			creators, err := lookupCreators(cloudTrailClient, region, missing)
+			if err != nil {
+				log.Errorf("[AWS] Failed to retrieve CloudTrail events in region %s, err: %s", region, err.Error())
+				return
+			}
+			for _, resource := range missing {
+				var owner string
+				for _, name := range resource.names {
+					if creator, ok := creators[name]; ok {
+						owner = creator
+						break
+					}
+				}
+				cache.Set(getOwnerCacheKey(region, resource), owner)
+				if len(owner) > 0 {
+					resource.setOwner(owner)
+				}
+			}
+		}(r, ctClient, res)
+	}
+	wg.Wait()
+	cache.Save()
+}
+
+func getOwnerCacheKey(region string, resource *ownerResource) string {
+	return "AWS:" + region + ":" + resource.names[0]
+}
+
+// lookupCreators returns the IAM users by the names of the resources created in the events of the resources
+func lookupCreators(cloudTrailClient cloudTrailClient, region string, resources []*ownerResource) (map[string]string, error) {
+	startTimes := map[string]time.Time{}
+	now := time.Now()
+	for _, resource := range resources {
+		startTime := resource.created.Add(-time.Hour)
+		if startTime.Before(now.Add(-cloudTrailLookbackPeriod)) {
+			startTime = now.Add(-cloudTrailLookbackPeriod)
+		}
+		if current, ok := startTimes[resource.eventName]; !ok || startTime.Before(current) {
+			startTimes[resource.eventName] = startTime
+		}
+	}
+
+	creators := map[string]string{}
+	lookups := 0
+	for eventName, startTime := range startTimes {
+		log.Debugf("[AWS] Looking up %s CloudTrail events since %s in region: %s", eventName, startTime, region)
+		input := &cloudtrail.LookupEventsInput{
+			LookupAttributes: []*cloudtrail.LookupAttribute{{
+				AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyEventName),
+				AttributeValue: aws.String(eventName),
+			}},
+			StartTime: &startTime,
+		}
+		for {
+			if lookups > 0 {
+				time.Sleep(cloudTrailLookupInterval)
+			}
+			lookups++
+			events, err := lookupEvents(cloudTrailClient, input)
+			if err != nil {
+				return nil, err
+			}
+			if events == nil {
+				break
+			}
+			for _, event := range events.Events {
+				iamUser := getIAMUser(event)
+				if iamUser == nil {
+					continue
+				}
+				for _, resource := range event.Resources {
+					if resource.ResourceName != nil {
+						creators[*resource.ResourceName] = *iamUser
+					}
+				}
+			}
+			if events.NextToken == nil {
+				break
+			}
+			input.NextToken = events.NextToken
+		}
+	}
+	return creators, nil
+}
+
+// lookupEvents retries the throttled requests with exponential backoff
+func lookupEvents(cloudTrailClient cloudTrailClient, input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	delay := cloudTrailLookupInterval
+	for retry := 0; ; retry++ {
+		events, err := cloudTrailClient.LookupEvents(input)
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ThrottlingException" || retry == cloudTrailMaxRetries {
+			return events, err
+		}
+		log.Debugf("[AWS] CloudTrail lookup is throttled, retrying in %s", delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// getIAMUser returns the name of the user who initiated the event if it is an IAM user or an assumed role
+func getIAMUser(event *cloudtrail.Event) *string {
+	if event.CloudTrailEvent == nil {
+		return nil
+	}
+	var eventSource cloudTrailEvent
+	if err := json.Unmarshal([]byte(*event.CloudTrailEvent), &eventSource); err != nil {
+		log.Errorf("[AWS] Failed to unmarshal the CloudTrail event source, err: %s", err.Error())
+		return nil
+	}
+	idType := eventSource.UserIdentity.T
+	if idType == "IAMUser" || idType == "AssumedRole" {
+		return event.Username
+	}
+	return nil
+}
+
+// tagOwners writes the owners of the items into their owner tag. Instances and volumes are tagged in a single request
+// per region, for CloudFormation stacks the instances and volumes of the stack are tagged.
+func tagOwners(ec2Clients map[string]ec2Client, rdsClients map[string]rdsClient, cfClients map[string]cfClient, items []types.CloudItem) []error {
+	ec2ResourcesPerRegionAndOwner := map[string]map[string][]*string{}
+	var errs []error
+	for _, item := range items {
+		switch t := item.(type) {
+		case *types.Instance:
+			addEc2OwnerResource(ec2ResourcesPerRegionAndOwner, t.Region, t.Owner, t.ID)
+		case *types.Disk:
+			addEc2OwnerResource(ec2ResourcesPerRegionAndOwner, t.Region, t.Owner, t.ID)
+		case *types.Database:
+			if err := tagDatabaseOwner(rdsClients[t.Region], t); err != nil {
+				errs = append(errs, err)
+			}
+		case *types.Stack:
+			if t.Metadata[METADATA_TYPE] != TYPE_CF {
+				log.Warnf("[AWS] Native stack %s has no resource to tag, tag its instances and volumes instead", t.Name)
+				continue
+			}
+			if err := addStackEc2OwnerResources(cfClients[t.Region], ec2ResourcesPerRegionAndOwner, t); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			log.Debugf("[AWS] Tagging owner is not supported for %s: %s", item.GetType(), item.GetName())
+		}
+	}
+	for region, resourcesPerOwner := range ec2ResourcesPerRegionAndOwner {
+		for owner, resources := range resourcesPerOwner {
+			if ctx.DryRun {
+				log.Infof("[AWS] Dry-run set, resources are not tagged with owner %s in region %s: %s", owner, region, aws.StringValueSlice(resources))
+				continue
+			}
+			log.Infof("[AWS] Tagging resources with owner %s in region %s: %s", owner, region, aws.StringValueSlice(resources))
+			ec2Client, ok := ec2Clients[region]
+			if !ok {
+				errs = append(errs, fmt.Errorf("there is no EC2 client in region: %s", region))
+				continue
+			}
+			if _, err := ec2Client.CreateTags(&ec2.CreateTagsInput{
+				Resources: resources,
+				Tags:      []*ec2.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String(owner)}},
+			}); err != nil {
+				log.Errorf("[AWS] Failed to tag resources with owner %s in region %s, err: %s", owner, region, err)
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+func addEc2OwnerResource(resourcesPerRegionAndOwner map[string]map[string][]*string, region, owner, ID string) {
+	if resourcesPerRegionAndOwner[region] == nil {
+		resourcesPerRegionAndOwner[region] = map[string][]*string{}
+	}
+	resourcesPerRegionAndOwner[region][owner] = append(resourcesPerRegionAndOwner[region][owner], aws.String(ID))
+}
+
+func tagDatabaseOwner(rdsClient rdsClient, db *types.Database) error {
+	if ctx.DryRun {
+		log.Infof("[AWS] Dry-run set, RDS instance %s is not tagged with owner %s", db.Name, db.Owner)
+		return nil
+	}
+	log.Infof("[AWS] Tagging RDS instance %s with owner %s", db.Name, db.Owner)
+	if rdsClient == nil {
+		return fmt.Errorf("there is no RDS client in region: %s", db.Region)
+	}
+	_, err := rdsClient.AddTagsToResource(&rds.AddTagsToResourceInput{
+		ResourceName: aws.String(db.Metadata["arn"]),
+		Tags:         []*rds.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String(db.Owner)}},
+	})
+	if err != nil {
+		log.Errorf("[AWS] Failed to tag RDS instance %s with owner %s, err: %s", db.Name, db.Owner, err)
+	}
+	return err
+}
+
+// addStackEc2OwnerResources adds the instances and volumes of the CloudFormation stack to the resources to tag. The
+// stack itself is not updated, an update only to change its tags can fail or replace its drifted resources.
+func addStackEc2OwnerResources(cfClient cfClient, resourcesPerRegionAndOwner map[string]map[string][]*string, stack *types.Stack) error {
+	if cfClient == nil {
+		return fmt.Errorf("there is no CloudFormation client in region: %s", stack.Region)
+	}
+	input := &cloudformation.ListStackResourcesInput{StackName: &stack.ID}
+	for {
+		result, err := cfClient.ListStackResources(input)
+		if err != nil {
+			log.Errorf("[AWS] Failed to list the resources of CloudFormation stack %s, err: %s", stack.Name, err)
+			return err
+		}
+		for _, resource := range result.StackResourceSummaries {
+			if resource.PhysicalResourceId == nil || aws.StringValue(resource.ResourceStatus) == cloudformation.ResourceStatusDeleteComplete {
+				continue
+			}
+			switch aws.StringValue(resource.ResourceType) {
+			case "AWS::EC2::Instance", "AWS::EC2::Volume":
+				addEc2OwnerResource(resourcesPerRegionAndOwner, stack.Region, stack.Owner, *resource.PhysicalResourceId)
+			default:
+				log.Debugf("[AWS] Tagging owner is not supported for %s of CloudFormation stack %s", aws.StringValue(resource.ResourceType), stack.Name)
+			}
+		}
+		if result.NextToken == nil {
+			return nil
+		}
+		input.NextToken = result.NextToken
+	}
+}
@@ -0,0 +1,221 @@
+package aws
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOwnerCtClient struct {
+	lookups *int
+	err     error
+}
+
+func (t mockOwnerCtClient) LookupEvents(input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	*t.lookups++
+	if t.err != nil {
+		return nil, t.err
+	}
+	userEvent := `{"userIdentity":{"type":"IAMUser"}}`
+	serviceEvent := `{"userIdentity":{"type":"AWSService"}}`
+	if input.NextToken == nil {
+		return &cloudtrail.LookupEventsOutput{
+			Events: []*cloudtrail.Event{
+				{Username: aws.String("creator"), CloudTrailEvent: &userEvent, Resources: []*cloudtrail.Resource{{ResourceName: aws.String("i-1")}}},
+				{Username: aws.String("autoscaling"), CloudTrailEvent: &serviceEvent, Resources: []*cloudtrail.Resource{{ResourceName: aws.String("i-2")}}},
+			},
+			NextToken: aws.String("next"),
+		}, nil
+	}
+	return &cloudtrail.LookupEventsOutput{
+		Events: []*cloudtrail.Event{
+			{Username: aws.String("other"), CloudTrailEvent: &userEvent, Resources: []*cloudtrail.Resource{{ResourceName: aws.String("i-3")}}},
+		},
+	}, nil
+}
+
+func newOwnerTestInstances() []*types.Instance {
+	return []*types.Instance{
+		{ID: "i-1", Region: "region", Created: time.Now(), State: types.Running},
+		{ID: "i-2", Region: "region", Created: time.Now(), State: types.Running},
+		{ID: "i-3", Region: "region", Created: time.Now(), State: types.Running},
+		{ID: "i-4", Region: "region", Created: time.Now(), State: types.Running, Owner: "owner"},
+	}
+}
+
+func TestInferOwners(t *testing.T) {
+	lookups := 0
+	cache := utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json"))
+	instances := newOwnerTestInstances()
+
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups}}, cache, newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, "creator", instances[0].Metadata["IAMUser"])
+	assert.Equal(t, "", instances[0].Owner)
+	assert.Nil(t, instances[1].Metadata)
+	assert.Equal(t, "other", instances[2].Metadata["IAMUser"])
+	assert.Nil(t, instances[3].Metadata)
+}
+
+func TestInferOwnersFromCache(t *testing.T) {
+	lookups := 0
+	cacheFile := filepath.Join(t.TempDir(), "owners.json")
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups}}, utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(newOwnerTestInstances()))
+	instances := newOwnerTestInstances()
+
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups}}, utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, "creator", instances[0].Metadata["IAMUser"])
+}
+
+func TestInferOwnersPromoted(t *testing.T) {
+	os.Setenv("INFERRED_OWNER_AS_OWNER", "true")
+	defer os.Unsetenv("INFERRED_OWNER_AS_OWNER")
+	ctx.OwnerInference = true
+	defer func() { ctx.OwnerInference = false }()
+	lookups := 0
+	disks := []*types.Disk{{ID: "i-1", Region: "region", Created: time.Now()}}
+
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups}}, utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json")), newDiskOwnerResources(disks))
+
+	assert.Equal(t, "creator", disks[0].Owner)
+}
+
+func TestInferOwnersDoesNotCacheErrors(t *testing.T) {
+	lookups := 0
+	cacheFile := filepath.Join(t.TempDir(), "owners.json")
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups, err: errors.New("throttled")}}, utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(newOwnerTestInstances()))
+	instances := newOwnerTestInstances()
+
+	inferOwners(map[string]cloudTrailClient{"region": mockOwnerCtClient{lookups: &lookups}}, utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 3, lookups)
+	assert.Equal(t, "creator", instances[0].Metadata["IAMUser"])
+}
+
+func TestTagOwners(t *testing.T) {
+	operationChannel := make(chan string, 10)
+	items := []types.CloudItem{
+		&types.Instance{ID: "i-1", Region: "region", Owner: "creator"},
+		&types.Disk{ID: "vol-1", Region: "region", Owner: "creator"},
+		&types.Database{Name: "db", Region: "region", Owner: "dba", Metadata: map[string]string{"arn": "db-arn"}},
+		&types.Stack{Name: "native", Region: "region", Owner: "creator", Metadata: map[string]string{METADATA_TYPE: TYPE_NATIVE}},
+	}
+
+	errs := tagOwners(map[string]ec2Client{"region": mockEc2Client{operationChannel: operationChannel}},
+		map[string]rdsClient{"region": mockRdsClient{operationChannel: operationChannel}},
+		map[string]cfClient{"region": mockCfClient{operationChannel: operationChannel}}, items)
+	close(operationChannel)
+
+	assert.Empty(t, errs)
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.ElementsMatch(t, []string{"AddTagsToResource:db-arn:dba", "CreateTags:i-1,vol-1:creator"}, operations)
+}
+
+type mockOwnerCfClient struct {
+	mockCfClient
+}
+
+func (t mockOwnerCfClient) ListStackResources(*cloudformation.ListStackResourcesInput) (*cloudformation.ListStackResourcesOutput, error) {
+	return &cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []*cloudformation.StackResourceSummary{
+			{ResourceType: aws.String("AWS::EC2::Instance"), PhysicalResourceId: aws.String("i-2"), ResourceStatus: aws.String(cloudformation.ResourceStatusCreateComplete)},
+			{ResourceType: aws.String("AWS::EC2::Volume"), PhysicalResourceId: aws.String("vol-2"), ResourceStatus: aws.String(cloudformation.ResourceStatusDeleteComplete)},
+			{ResourceType: aws.String("AWS::EC2::VPC"), PhysicalResourceId: aws.String("vpc-1"), ResourceStatus: aws.String(cloudformation.ResourceStatusCreateComplete)},
+		},
+	}, nil
+}
+
+func TestTagOwnersOfCloudFormationStack(t *testing.T) {
+	operationChannel := make(chan string, 10)
+	items := []types.CloudItem{
+		&types.Stack{ID: "stack-id", Name: "stack", Region: "region", Owner: "creator", Metadata: map[string]string{METADATA_TYPE: TYPE_CF}},
+	}
+
+	errs := tagOwners(map[string]ec2Client{"region": mockEc2Client{operationChannel: operationChannel}},
+		map[string]rdsClient{},
+		map[string]cfClient{"region": mockOwnerCfClient{mockCfClient{operationChannel: operationChannel}}}, items)
+	close(operationChannel)
+
+	assert.Empty(t, errs)
+	var operations []string
+	for op := range operationChannel {
+		operations = append(operations, op)
+	}
+	assert.Equal(t, []string{"CreateTags:i-2:creator"}, operations)
+}
+
+func TestTagOwnersDryRun(t *testing.T) {
+	ctx.DryRun = true
+	defer func() { ctx.DryRun = false }()
+	operationChannel := make(chan string, 10)
+	items := []types.CloudItem{
+		&types.Instance{ID: "i-1", Region: "region", Owner: "creator"},
+		&types.Database{Name: "db", Region: "region", Owner: "dba", Metadata: map[string]string{"arn": "db-arn"}},
+	}
+
+	errs := tagOwners(map[string]ec2Client{"region": mockEc2Client{operationChannel: operationChannel}},
+		map[string]rdsClient{"region": mockRdsClient{operationChannel: operationChannel}},
+		map[string]cfClient{}, items)
+	close(operationChannel)
+
+	assert.Empty(t, errs)
+	assert.Empty(t, operationChannel)
+}
+
+type mockThrottledCtClient struct {
+	lookups *int
+}
+
+func (t mockThrottledCtClient) LookupEvents(*cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	*t.lookups++
+	if *t.lookups < 3 {
+		return nil, awserr.New("ThrottlingException", "Rate exceeded", nil)
+	}
+	return &cloudtrail.LookupEventsOutput{}, nil
+}
+
+func TestLookupEventsRetriesThrottled(t *testing.T) {
+	defer func(interval time.Duration) { cloudTrailLookupInterval = interval }(cloudTrailLookupInterval)
+	cloudTrailLookupInterval = time.Millisecond
+	lookups := 0
+
+	_, err := lookupEvents(mockThrottledCtClient{lookups: &lookups}, &cloudtrail.LookupEventsInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, lookups)
+}
+
+func TestOwnerResourcesWithoutOwnerInference(t *testing.T) {
+	instances := append(newOwnerTestInstances(), &types.Instance{ID: "i-5", Region: "region", State: types.Stopped})
+
+	assert.Equal(t, 3, len(newInstanceOwnerResources(instances)))
+	assert.Equal(t, 0, len(newDiskOwnerResources([]*types.Disk{{ID: "vol-1", Region: "region"}})))
+	assert.Equal(t, 0, len(newStackOwnerResources([]*types.Stack{{ID: "stack", Region: "region"}})))
+}
+
+func TestOwnerResourcesWithOwnerInference(t *testing.T) {
+	ctx.OwnerInference = true
+	defer func() { ctx.OwnerInference = false }()
+	instances := append(newOwnerTestInstances(), &types.Instance{ID: "i-5", Region: "region", State: types.Stopped})
+
+	assert.Equal(t, 4, len(newInstanceOwnerResources(instances)))
+	assert.Equal(t, 1, len(newDiskOwnerResources([]*types.Disk{{ID: "vol-1", Region: "region"}})))
+	assert.Equal(t, 1, len(newStackOwnerResources([]*types.Stack{{ID: "stack", Region: "region"}})))
+}
@@ -0,0 +1,112 @@
+package aws
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type redshiftClient interface {
+	DescribeClusters(input *redshift.DescribeClustersInput) (*redshift.DescribeClustersOutput, error)
+}
+
+func (p awsProvider) getRedshiftClientsByRegion() map[string]redshiftClient {
+	redshiftClients := map[string]redshiftClient{}
+	for k := range p.redshiftClients {
+		redshiftClients[k] = p.redshiftClients[k]
+	}
+	return redshiftClients
+}
+
+func getRedshiftClusters(redshiftClients map[string]redshiftClient) ([]*types.Database, error) {
+	dbChan := make(chan *types.Database)
+	wg := sync.WaitGroup{}
+	wg.Add(len(redshiftClients))
+
+	for r, c := range redshiftClients {
+		log.Debugf("[AWS] Fetching Redshift clusters from: %s", r)
+		go func(region string, redshiftClient redshiftClient) {
+			defer wg.Done()
+
+			input := &redshift.DescribeClustersInput{}
+			for {
+				result, err := redshiftClient.DescribeClusters(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the Redshift clusters in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing Redshift clusters (%d) in region: %s", len(result.Clusters), region)
+				for _, cluster := range result.Clusters {
+					dbChan <- newRedshiftCluster(cluster, region)
+				}
+				if result.Marker == nil {
+					break
+				}
+				input.Marker = result.Marker
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(dbChan)
+	}()
+
+	var databases []*types.Database
+	for db := range dbChan {
+		databases = append(databases, db)
+	}
+	return databases, nil
+}
+
+func newRedshiftCluster(cluster *redshift.Cluster, region string) *types.Database {
+	tags := types.Tags{}
+	for _, tag := range cluster.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return &types.Database{
+		ID:           aws.StringValue(cluster.ClusterNamespaceArn),
+		Name:         aws.StringValue(cluster.ClusterIdentifier),
+		Type:         types.RedshiftDatabase,
+		Created:      getCreated(cluster.ClusterCreateTime),
+		Region:       region,
+		InstanceType: aws.StringValue(cluster.NodeType),
+		NodeCount:    int(aws.Int64Value(cluster.NumberOfNodes)),
+		State:        getRedshiftClusterState(aws.StringValue(cluster.ClusterStatus)),
+		Owner:        tags[ctx.OwnerLabel],
+		Tags:         tags,
+		CloudType:    types.AWS,
+		Metadata:     map[string]string{"dbName": aws.StringValue(cluster.DBName)},
+	}
+}
+
+// getRedshiftClusterState returns Stopped for the paused clusters, only their storage is billed
+func getRedshiftClusterState(status string) types.State {
+	switch status {
+	case "available":
+		return types.Running
+	case "paused":
+		return types.Stopped
+	case "pausing":
+		return types.Stopping
+	case "resuming":
+		return types.Starting
+	case "creating":
+		return types.Creating
+	case "deleting", "final-snapshot":
+		return types.Deleting
+	case "modifying", "rebooting", "renaming", "resizing", "prep-for-resize", "resize-cleanup", "cancelling-resize":
+		return types.Updating
+	case "hardware-failure", "storage-full":
+		return types.Failed
+	}
+	if strings.HasPrefix(status, "incompatible-") {
+		return types.Failed
+	}
+	return types.Unknown
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRedshiftClient struct {
+}
+
+func (t mockRedshiftClient) DescribeClusters(*redshift.DescribeClustersInput) (*redshift.DescribeClustersOutput, error) {
+	return &redshift.DescribeClustersOutput{
+		Clusters: []*redshift.Cluster{
+			{
+				ClusterIdentifier: aws.String("warehouse"),
+				ClusterStatus:     aws.String("paused"),
+				NodeType:          aws.String("ra3.xlplus"),
+				NumberOfNodes:     aws.Int64(2),
+				Tags:              []*redshift.Tag{{Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}},
+			},
+		},
+	}, nil
+}
+
+func TestGetRedshiftClusters(t *testing.T) {
+	databases, err := getRedshiftClusters(map[string]redshiftClient{"eu-west-1": mockRedshiftClient{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(databases))
+	warehouse := databases[0]
+	assert.Equal(t, "warehouse", warehouse.Name)
+	assert.Equal(t, types.RedshiftDatabase, warehouse.Type)
+	assert.Equal(t, "ra3.xlplus", warehouse.InstanceType)
+	assert.Equal(t, 2, warehouse.NodeCount)
+	assert.Equal(t, types.Stopped, warehouse.State)
+	assert.Equal(t, "owner", warehouse.Owner)
+}
+
+func TestGetRedshiftClusterState(t *testing.T) {
+	assert.Equal(t, types.Running, getRedshiftClusterState("available"))
+	assert.Equal(t, types.Stopping, getRedshiftClusterState("pausing"))
+	assert.Equal(t, types.Updating, getRedshiftClusterState("resizing"))
+	assert.Equal(t, types.Failed, getRedshiftClusterState("incompatible-network"))
+}
+
+func TestStopDatabasesDryRun(t *testing.T) {
+	ctx.DryRun = true
+	defer func() { ctx.DryRun = false }()
+
+	errs := awsProvider{}.StopDatabases(types.NewDatabaseContainer([]*types.Database{
+		{CloudType: types.AWS, Type: types.RdsDatabase, Name: "rds", Region: "eu-west-1"},
+		{CloudType: types.AWS, Type: types.RedshiftDatabase, Name: "warehouse", Region: "eu-west-1"},
+	}))
+
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+type snapshotClient interface {
+	DescribeSnapshots(input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeVolumes(input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error)
+	DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
+	DeleteSnapshot(input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error)
+}
+
+func (p awsProvider) getSnapshotClientsByRegion() map[string]snapshotClient {
+	snapshotClients := map[string]snapshotClient{}
+	for k := range p.ec2Clients {
+		snapshotClients[k] = p.ec2Clients[k]
+	}
+	return snapshotClients
+}
+
+func (p awsProvider) GetSnapshots() ([]*types.Snapshot, error) {
+	log.Debug("[AWS] Fetch snapshots")
+	return getSnapshots(p.getSnapshotClientsByRegion())
+}
+
+func (p awsProvider) DeleteSnapshots(snapshots *types.SnapshotContainer) []error {
+	log.Debug("[AWS] Delete snapshots")
+	return deleteSnapshots(p.getSnapshotClientsByRegion(), snapshots.Get(types.AWS))
+}
+
+// getSnapshots returns the snapshots owned by the account. A snapshot is orphaned if its source volume no longer
+// exists and it does not back any of the images of the account.
+func getSnapshots(snapshotClients map[string]snapshotClient) ([]*types.Snapshot, error) {
+	snapshotChan := make(chan *types.Snapshot)
+	wg := sync.WaitGroup{}
+	wg.Add(len(snapshotClients))
+
+	for r, c := range snapshotClients {
+		log.Debugf("[AWS] Fetching snapshots from region: %s", r)
+		go func(region string, snapshotClient snapshotClient) {
+			defer wg.Done()
+
+			volumes, err := snapshotClient.DescribeVolumes(&ec2.DescribeVolumesInput{})
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the volumes in region: %s, err: %s", region, err)
+				return
+			}
+			existingSources := map[string]bool{}
+			for _, volume := range volumes.Volumes {
+				existingSources[aws.StringValue(volume.VolumeId)] = true
+			}
+			images, err := snapshotClient.DescribeImages(&ec2.DescribeImagesInput{Owners: aws.StringSlice([]string{"self"})})
+			if err != nil {
+				log.Errorf("[AWS] Failed to fetch the images in region: %s, err: %s", region, err)
+				return
+			}
+			for _, image := range images.Images {
+				for _, mapping := range image.BlockDeviceMappings {
+					if mapping.Ebs != nil {
+						existingSources[aws.StringValue(mapping.Ebs.SnapshotId)] = true
+					}
+				}
+			}
+
+			input := &ec2.DescribeSnapshotsInput{OwnerIds: aws.StringSlice([]string{"self"}), MaxResults: aws.Int64(1000)}
+			for {
+				result, err := snapshotClient.DescribeSnapshots(input)
+				if err != nil {
+					log.Errorf("[AWS] Failed to fetch the snapshots in region: %s, err: %s", region, err)
+					return
+				}
+				log.Debugf("[AWS] Processing snapshots (%d) in region: %s", len(result.Snapshots), region)
+				for _, snapshot := range result.Snapshots {
+					orphaned := !existingSources[aws.StringValue(snapshot.VolumeId)] && !existingSources[aws.StringValue(snapshot.SnapshotId)]
+					snapshotChan <- newSnapshot(snapshot, region, orphaned)
+				}
+				if result.NextToken == nil {
+					break
+				}
+				input.NextToken = result.NextToken
+			}
+		}(r, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(snapshotChan)
+	}()
+
+	var snapshots []*types.Snapshot
+	for snapshot := range snapshotChan {
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+func deleteSnapshots(snapshotClients map[string]snapshotClient, snapshots []*types.Snapshot) []error {
+	regionSnapshots := map[string][]*types.Snapshot{}
+	for _, snapshot := range snapshots {
+		regionSnapshots[snapshot.Region] = append(regionSnapshots[snapshot.Region], snapshot)
+	}
+	log.Debugf("[AWS] Delete snapshots: %v", regionSnapshots)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(regionSnapshots))
+	errChan := make(chan error)
+
+	for r, s := range regionSnapshots {
+		go func(snapshotClient snapshotClient, region string, snapshots []*types.Snapshot) {
+			defer wg.Done()
+
+			for _, snapshot := range snapshots {
+				if ctx.DryRun {
+					log.Infof("[AWS] Dry-run set, snapshot is not deleted: %s:%s, region: %s", snapshot.Name, snapshot.ID, region)
+					continue
+				}
+				log.Infof("[AWS] Delete snapshot: %s:%s, region: %s", snapshot.Name, snapshot.ID, region)
+				if _, err := snapshotClient.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: aws.String(snapshot.ID)}); err != nil {
+					errChan <- err
+				}
+			}
+		}(snapshotClients[r], r, s)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newSnapshot(snapshot *ec2.Snapshot, region string, orphaned bool) *types.Snapshot {
+	tags := getEc2Tags(snapshot.Tags)
+	name, ok := tags["Name"]
+	if !ok {
+		name = aws.StringValue(snapshot.SnapshotId)
+	}
+	state := types.Available
+	if orphaned {
+		state = types.Orphaned
+	}
+	return &types.Snapshot{
+		ID:         aws.StringValue(snapshot.SnapshotId),
+		Name:       name,
+		Created:    getCreated(snapshot.StartTime),
+		State:      state,
+		Owner:      tags[ctx.OwnerLabel],
+		CloudType:  types.AWS,
+		Region:     region,
+		Size:       aws.Int64Value(snapshot.VolumeSize),
+		SourceDisk: aws.StringValue(snapshot.VolumeId),
+		Metadata:   map[string]string{"description": aws.StringValue(snapshot.Description)},
+		Tags:       tags,
+	}
+}
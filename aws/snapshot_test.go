@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSnapshotClient struct {
+	deleted []string
+}
+
+func (c *mockSnapshotClient) DescribeSnapshots(input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	if input.NextToken == nil {
+		return &ec2.DescribeSnapshotsOutput{
+			Snapshots: []*ec2.Snapshot{
+				{SnapshotId: aws.String("snap-1"), VolumeId: aws.String("vol-1"), VolumeSize: aws.Int64(8), StartTime: aws.Time(time.Unix(0, 0)),
+					Tags: []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("backup")}, {Key: aws.String(ctx.OwnerLabel), Value: aws.String("owner")}}},
+				{SnapshotId: aws.String("snap-2"), VolumeId: aws.String("vol-deleted")},
+			},
+			NextToken: aws.String("next"),
+		}, nil
+	}
+	return &ec2.DescribeSnapshotsOutput{
+		Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("snap-3"), VolumeId: aws.String("vol-deleted")}},
+	}, nil
+}
+
+func (c *mockSnapshotClient) DescribeVolumes(input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	return &ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{{VolumeId: aws.String("vol-1")}}}, nil
+}
+
+func (c *mockSnapshotClient) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	return &ec2.DescribeImagesOutput{Images: []*ec2.Image{{BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+		{Ebs: &ec2.EbsBlockDevice{SnapshotId: aws.String("snap-3")}},
+		{DeviceName: aws.String("ephemeral")},
+	}}}}, nil
+}
+
+func (c *mockSnapshotClient) DeleteSnapshot(input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	c.deleted = append(c.deleted, *input.SnapshotId)
+	return nil, nil
+}
+
+func TestGetSnapshots(t *testing.T) {
+	snapshots, err := getSnapshots(map[string]snapshotClient{"eu-west-1": &mockSnapshotClient{}})
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ID < snapshots[j].ID
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(snapshots))
+	assert.Equal(t, "backup", snapshots[0].Name)
+	assert.Equal(t, "owner", snapshots[0].Owner)
+	assert.Equal(t, "vol-1", snapshots[0].SourceDisk)
+	assert.Equal(t, int64(8), snapshots[0].Size)
+	assert.Equal(t, "eu-west-1", snapshots[0].Region)
+	assert.Equal(t, types.Available, snapshots[0].State)
+	assert.Equal(t, "snap-2", snapshots[1].Name)
+	assert.Equal(t, types.Orphaned, snapshots[1].State)
+	assert.Equal(t, types.Available, snapshots[2].State)
+}
+
+func TestDeleteSnapshots(t *testing.T) {
+	client := &mockSnapshotClient{}
+
+	errs := deleteSnapshots(map[string]snapshotClient{"eu-west-1": client}, []*types.Snapshot{{ID: "snap-1", Region: "eu-west-1"}})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"snap-1"}, client.deleted)
+}
+
+func TestDeleteSnapshotsDryRun(t *testing.T) {
+	ctx.DryRun = true
+	defer func() { ctx.DryRun = false }()
+	client := &mockSnapshotClient{}
+
+	errs := deleteSnapshots(map[string]snapshotClient{"eu-west-1": client}, []*types.Snapshot{{ID: "snap-1", Region: "eu-west-1"}})
+
+	assert.Empty(t, errs)
+	assert.Empty(t, client.deleted)
+}
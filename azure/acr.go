@@ -0,0 +1,248 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
+	acr "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/runtime/2019-08-15-preview/containerregistry"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/to"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// acrPageSize is the number of repositories and manifests fetched in a request from the registries
+const acrPageSize = 100
+
+func (p azureProvider) GetContainerImages() ([]*types.ContainerImage, error) {
+	log.Debug("[AZURE] Fetching container registries")
+	var registries []containerregistry.Registry
+	result, err := p.registryClient.ListComplete(context.Background())
+	if err != nil {
+		log.Errorf("[AZURE] Failed to fetch the container registries, err: %s", err)
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			log.Errorf("[AZURE] Failed to fetch the container registries, err: %s", err)
+			return nil, err
+		}
+		registries = append(registries, result.Value())
+	}
+
+	imageChan := make(chan *types.ContainerImage)
+	wg := sync.WaitGroup{}
+	wg.Add(len(registries))
+
+	for _, r := range registries {
+		go func(registry containerregistry.Registry) {
+			defer wg.Done()
+
+			images, err := p.getRegistryImages(registry)
+			if err != nil {
+				log.Errorf("[AZURE] Failed to fetch the images of container registry: %s, err: %s", to.String(registry.Name), err)
+				return
+			}
+			for _, image := range images {
+				imageChan <- image
+			}
+		}(r)
+	}
+
+	go func() {
+		wg.Wait()
+		close(imageChan)
+	}()
+
+	var images []*types.ContainerImage
+	for image := range imageChan {
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func (p azureProvider) getRegistryImages(registry containerregistry.Registry) ([]*types.ContainerImage, error) {
+	if registry.RegistryProperties == nil || registry.LoginServer == nil {
+		return nil, errors.New("the login server of the registry is unknown")
+	}
+	loginServer := *registry.LoginServer
+	log.Debugf("[AZURE] Fetching the repositories of container registry: %s", loginServer)
+	repositoryClient := acr.NewRepositoryClient("https://" + loginServer)
+	authorizer, err := p.getRegistryAuthorizer(loginServer, "registry:catalog:*")
+	if err != nil {
+		return nil, err
+	}
+	repositoryClient.Authorizer = authorizer
+	var repositories []string
+	for last := ""; ; {
+		result, err := repositoryClient.GetList(context.Background(), last, to.Int32Ptr(acrPageSize))
+		if err != nil {
+			return nil, err
+		}
+		if result.Names == nil || len(*result.Names) == 0 {
+			break
+		}
+		repositories = append(repositories, *result.Names...)
+		if len(*result.Names) < acrPageSize {
+			break
+		}
+		last = (*result.Names)[len(*result.Names)-1]
+	}
+
+	var images []*types.ContainerImage
+	for _, repository := range repositories {
+		manifestClient := acr.NewManifestsClient("https://" + loginServer)
+		authorizer, err := p.getRegistryAuthorizer(loginServer, "repository:"+repository+":metadata_read")
+		if err != nil {
+			return nil, err
+		}
+		manifestClient.Authorizer = authorizer
+		var manifests []acr.ManifestAttributesBase
+		for last := ""; ; {
+			result, err := manifestClient.GetList(context.Background(), repository, last, to.Int32Ptr(acrPageSize), "")
+			if err != nil {
+				return nil, err
+			}
+			if result.ManifestsAttributes == nil || len(*result.ManifestsAttributes) == 0 {
+				break
+			}
+			manifests = append(manifests, *result.ManifestsAttributes...)
+			if len(*result.ManifestsAttributes) < acrPageSize {
+				break
+			}
+			last = to.String((*result.ManifestsAttributes)[len(*result.ManifestsAttributes)-1].Digest)
+		}
+		repositoryImages, err := getContainerImages(registry, repository, manifests)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, repositoryImages...)
+	}
+	return images, nil
+}
+
+// getRegistryAuthorizer exchanges the Azure AD token of the provider for an access token of the registry with the
+// scope, because the data plane of the registries does not accept the Azure AD tokens
+func (p azureProvider) getRegistryAuthorizer(loginServer string, scope string) (autorest.Authorizer, error) {
+	bearerAuthorizer, ok := p.authorizer.(*autorest.BearerAuthorizer)
+	if !ok {
+		return nil, errors.New("the container registries can be accessed with an Azure AD token only")
+	}
+	tokenProvider := bearerAuthorizer.TokenProvider()
+	if refresher, ok := tokenProvider.(adal.Refresher); ok {
+		if err := refresher.EnsureFresh(); err != nil {
+			return nil, err
+		}
+	}
+	loginURI := "https://" + loginServer
+	refreshToken, err := acr.NewRefreshTokensClient(loginURI).GetFromExchange(context.Background(), "access_token", loginServer, os.Getenv("AZURE_TENANT_ID"), "", tokenProvider.OAuthToken())
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := acr.NewAccessTokensClient(loginURI).Get(context.Background(), loginServer, scope, to.String(refreshToken.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	return autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{"Authorization": "Bearer " + to.String(accessToken.AccessToken)}), nil
+}
+
+// getContainerImages converts the manifests of the repository, the repository of the images is prefixed with the
+// login server, because the repository names are unique only within a registry
+func getContainerImages(registry containerregistry.Registry, repository string, manifests []acr.ManifestAttributesBase) ([]*types.ContainerImage, error) {
+	loginServer := to.String(registry.LoginServer)
+	tags := utils.ConvertTags(registry.Tags)
+	var images []*types.ContainerImage
+	for _, manifest := range manifests {
+		created, err := utils.ConvertTimeRFC3339(to.String(manifest.CreatedTime))
+		if err != nil {
+			log.Errorf("[AZURE] Failed to get the creation time of image: %s@%s, err: %s", repository, to.String(manifest.Digest), err)
+			return nil, err
+		}
+		var imageTags []string
+		if manifest.Tags != nil {
+			imageTags = *manifest.Tags
+		}
+		name := loginServer + "/" + repository + "@" + to.String(manifest.Digest)
+		if len(imageTags) > 0 {
+			name = loginServer + "/" + repository + ":" + strings.Join(imageTags, ",")
+		}
+		var size int64
+		if manifest.ImageSize != nil {
+			size = *manifest.ImageSize
+		}
+		images = append(images, &types.ContainerImage{
+			ID:         to.String(manifest.Digest),
+			Name:       name,
+			Repository: loginServer + "/" + repository,
+			ImageTags:  imageTags,
+			Created:    created,
+			Size:       size,
+			Owner:      tags[ctx.OwnerLabel],
+			CloudType:  types.AZURE,
+			Region:     to.String(registry.Location),
+			Metadata:   map[string]string{"loginServer": loginServer, "repository": repository},
+			Tags:       tags,
+		})
+	}
+	return images, nil
+}
+
+// CleanupContainerImages deletes the manifests of the outdated images, so all the tags of an image are removed
+func (p azureProvider) CleanupContainerImages(containerImages *types.ContainerImageContainer, retainedImages int) []error {
+	log.Debugf("[AZURE] Cleaning up container registry images, keeping the %d most recent tagged images per repository", retainedImages)
+	repositoryImages := map[string][]*types.ContainerImage{}
+	for _, image := range containerImages.GetOutdated(types.AZURE, retainedImages) {
+		repositoryImages[image.Repository] = append(repositoryImages[image.Repository], image)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(repositoryImages))
+	errChan := make(chan error)
+
+	for _, i := range repositoryImages {
+		go func(images []*types.ContainerImage) {
+			defer wg.Done()
+
+			loginServer, repository := images[0].Metadata["loginServer"], images[0].Metadata["repository"]
+			manifestClient := acr.NewManifestsClient("https://" + loginServer)
+			if !ctx.DryRun {
+				authorizer, err := p.getRegistryAuthorizer(loginServer, "repository:"+repository+":delete")
+				if err != nil {
+					log.Errorf("[AZURE] Failed to authenticate to container registry: %s, err: %s", loginServer, err)
+					errChan <- err
+					return
+				}
+				manifestClient.Authorizer = authorizer
+			}
+			for _, image := range images {
+				if ctx.DryRun {
+					log.Infof("[AZURE] Dry-run set, container registry image is not deleted: %s", image.Name)
+					continue
+				}
+				log.Infof("[AZURE] Delete container registry image: %s", image.Name)
+				if _, err := manifestClient.Delete(context.Background(), repository, image.ID); err != nil {
+					log.Errorf("[AZURE] Failed to delete container registry image: %s, err: %s", image.Name, err)
+					errChan <- err
+				}
+			}
+		}(i)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
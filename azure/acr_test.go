@@ -0,0 +1,42 @@
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
+	acr "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/runtime/2019-08-15-preview/containerregistry"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContainerImages(t *testing.T) {
+	registry := containerregistry.Registry{
+		Name:               to.StringPtr("registry"),
+		Location:           to.StringPtr("westeurope"),
+		Tags:               map[string]*string{"owner": to.StringPtr("owner")},
+		RegistryProperties: &containerregistry.RegistryProperties{LoginServer: to.StringPtr("registry.azurecr.io")},
+	}
+	manifests := []acr.ManifestAttributesBase{
+		{Digest: to.StringPtr("sha256:1"), CreatedTime: to.StringPtr("2020-01-02T03:04:05.1234567Z"), ImageSize: to.Int64Ptr(10), Tags: &[]string{"1.0", "latest"}},
+		{Digest: to.StringPtr("sha256:2"), CreatedTime: to.StringPtr("2020-01-01T00:00:00Z")},
+	}
+
+	images, err := getContainerImages(registry, "app", manifests)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(images))
+	image := images[0]
+	assert.Equal(t, "sha256:1", image.ID)
+	assert.Equal(t, "registry.azurecr.io/app:1.0,latest", image.Name)
+	assert.Equal(t, "registry.azurecr.io/app", image.Repository)
+	assert.Equal(t, []string{"1.0", "latest"}, image.ImageTags)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 123456700, time.UTC), image.Created)
+	assert.Equal(t, int64(10), image.Size)
+	assert.Equal(t, "owner", image.Owner)
+	assert.Equal(t, types.AZURE, image.CloudType)
+	assert.Equal(t, "westeurope", image.Region)
+	assert.Equal(t, "app", image.Metadata["repository"])
+	assert.Equal(t, "registry.azurecr.io/app@sha256:2", images[1].Name)
+}
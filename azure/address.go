@@ -0,0 +1,99 @@
+package azure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-01-01/network"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p azureProvider) GetAddresses() ([]*types.Address, error) {
+	log.Debug("[AZURE] Fetching public IP addresses")
+	var azureAddresses []network.PublicIPAddress
+	result, err := p.publicIPClient.ListAllComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		azureAddresses = append(azureAddresses, result.Value())
+	}
+	return getAddresses(azureAddresses), nil
+}
+
+// getAddresses converts the public IP addresses, an address is unused if it is not attached to any IP configuration
+func getAddresses(azureAddresses []network.PublicIPAddress) []*types.Address {
+	log.Debugf("[AZURE] Processing public IP addresses (%d)", len(azureAddresses))
+	var addresses []*types.Address
+	for _, address := range azureAddresses {
+		tags := utils.ConvertTags(address.Tags)
+		resourceGroupName, _ := getResourceGroupName(*address.ID)
+		aAddress := &types.Address{
+			ID:        *address.ID,
+			Name:      *address.Name,
+			Created:   getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			State:     types.Unused,
+			Owner:     tags[ctx.OwnerLabel],
+			CloudType: types.AZURE,
+			Region:    *address.Location,
+			Metadata:  map[string]string{"resourceGroupName": resourceGroupName},
+			Tags:      tags,
+		}
+		if properties := address.PublicIPAddressPropertiesFormat; properties != nil {
+			if properties.IPAddress != nil {
+				aAddress.IP = *properties.IPAddress
+			}
+			if properties.IPConfiguration != nil {
+				aAddress.State = types.InUse
+			}
+		}
+		addresses = append(addresses, aAddress)
+	}
+	return addresses
+}
+
+func (p azureProvider) ReleaseAddresses(addresses *types.AddressContainer) []error {
+	azureAddresses := addresses.Get(types.AZURE)
+	log.Debugf("[AZURE] Deleting public IP addresses: %v", azureAddresses)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureAddresses))
+	errChan := make(chan error)
+
+	for _, a := range azureAddresses {
+		go func(address *types.Address) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[AZURE] Dry-run set, public IP address is not deleted: %s", address.Name)
+				return
+			}
+			log.Infof("[AZURE] Delete public IP address: %s", address.ID)
+			future, err := p.publicIPClient.Delete(context.Background(), address.Metadata["resourceGroupName"], address.Name)
+			if err == nil {
+				err = future.WaitForCompletionRef(context.Background(), p.publicIPClient.Client)
+			}
+			if err != nil {
+				log.Errorf("[AZURE] Unable to delete public IP address: %s because: %s", address.ID, err.Error())
+				errChan <- err
+			}
+		}(a)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,40 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-01-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAddresses(t *testing.T) {
+	azureAddresses := []network.PublicIPAddress{
+		{
+			ID:                              to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/unused"),
+			Name:                            to.StringPtr("unused"),
+			Location:                        to.StringPtr("westeurope"),
+			Tags:                            map[string]*string{"owner": to.StringPtr("owner"), "cb-creation-timestamp": to.StringPtr("1527240203")},
+			PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{IPAddress: to.StringPtr("1.2.3.4")},
+		},
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/attached"),
+			Name:     to.StringPtr("attached"),
+			Location: to.StringPtr("westeurope"),
+			PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+				IPConfiguration: &network.IPConfiguration{ID: to.StringPtr("ipconfig")},
+			},
+		},
+	}
+
+	addresses := getAddresses(azureAddresses)
+
+	assert.Equal(t, 2, len(addresses))
+	assert.Equal(t, "1.2.3.4", addresses[0].IP)
+	assert.Equal(t, "rg", addresses[0].Metadata["resourceGroupName"])
+	assert.Equal(t, "owner", addresses[0].Owner)
+	assert.Equal(t, int64(1527240203), addresses[0].Created.Unix())
+	assert.Equal(t, types.Unused, addresses[0].State)
+	assert.Equal(t, types.InUse, addresses[1].State)
+}
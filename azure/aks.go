@@ -0,0 +1,193 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p azureProvider) GetClusters() ([]*types.Cluster, error) {
+	log.Debug("[AZURE] Fetching AKS clusters")
+	var managedClusters []containerservice.ManagedCluster
+	result, err := p.managedClusterClient.ListComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		managedClusters = append(managedClusters, result.Value())
+	}
+	return getAksClusters(managedClusters), nil
+}
+
+func getAksClusters(managedClusters []containerservice.ManagedCluster) []*types.Cluster {
+	log.Debugf("[AZURE] Processing AKS clusters (%d)", len(managedClusters))
+	var clusters []*types.Cluster
+	for _, managedCluster := range managedClusters {
+		tags := utils.ConvertTags(managedCluster.Tags)
+		resourceGroupName, _ := getResourceGroupName(*managedCluster.ID)
+		config := &types.ClusterConfig{}
+		metadata := map[string]string{"resourceGroupName": resourceGroupName}
+		powerState := containerservice.CodeRunning
+		if properties := managedCluster.ManagedClusterProperties; properties != nil {
+			if properties.AgentPoolProfiles != nil {
+				for _, profile := range *properties.AgentPoolProfiles {
+					pool := &types.NodePool{}
+					if profile.Name != nil {
+						pool.Name = *profile.Name
+					}
+					if profile.Count != nil {
+						pool.NodeCount = int(*profile.Count)
+					}
+					if profile.VMSize != nil {
+						pool.InstanceType = *profile.VMSize
+					}
+					if profile.EnableAutoScaling != nil {
+						pool.Autoscaling = *profile.EnableAutoScaling
+					}
+					pool.System = profile.Mode == containerservice.AgentPoolModeSystem
+					config.NodePools = append(config.NodePools, pool)
+				}
+			}
+			if properties.PowerState != nil {
+				powerState = properties.PowerState.Code
+			}
+			if properties.KubernetesVersion != nil {
+				metadata["version"] = *properties.KubernetesVersion
+			}
+		}
+		clusters = append(clusters, &types.Cluster{
+			Uuid:      *managedCluster.ID,
+			Name:      *managedCluster.Name,
+			Type:      types.AksCluster,
+			Created:   getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			Tags:      tags,
+			Owner:     tags[ctx.OwnerLabel],
+			CloudType: types.AZURE,
+			State:     getAksClusterState(powerState, config),
+			Region:    *managedCluster.Location,
+			Config:    config,
+			Metadata:  metadata,
+		})
+	}
+	return clusters
+}
+
+// getAksClusterState returns Stopped for the stopped clusters and for the clusters whose node pools are scaled to zero.
+// System node pools cannot be scaled to zero, so a cluster with user node pools is stopped if its user node pools are.
+func getAksClusterState(powerState containerservice.Code, config *types.ClusterConfig) types.State {
+	if powerState == containerservice.CodeStopped || config.IsScaledToZero() {
+		return types.Stopped
+	}
+	userPools, userNodes := 0, 0
+	for _, pool := range config.NodePools {
+		if !pool.System {
+			userPools++
+			userNodes += pool.NodeCount
+		}
+	}
+	if userPools > 0 && userNodes == 0 {
+		return types.Stopped
+	}
+	return types.Running
+}
+
+// StopClusters scales the user node pools of the AKS clusters to zero. System node pools must keep at least one node,
+// so they are left running.
+func (p azureProvider) StopClusters(clusters *types.ClusterContainer) []error {
+	azureClusters := clusters.Get(types.AZURE)
+	log.Debugf("[AZURE] Stopping AKS clusters: %v", azureClusters)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureClusters))
+	errChan := make(chan error)
+
+	for _, c := range azureClusters {
+		go func(cluster *types.Cluster) {
+			defer wg.Done()
+
+			if cluster.Config == nil {
+				return
+			}
+			resourceGroupName := cluster.Metadata["resourceGroupName"]
+			for _, pool := range cluster.Config.NodePools {
+				if pool.NodeCount == 0 && !pool.Autoscaling {
+					continue
+				}
+				agentPool, err := p.agentPoolClient.Get(context.Background(), resourceGroupName, cluster.Name, pool.Name)
+				if err != nil {
+					log.Errorf("[AZURE] Unable to fetch node pool %s of AKS cluster %s because: %s", pool.Name, cluster.Name, err.Error())
+					errChan <- err
+					continue
+				}
+				if agentPool.ManagedClusterAgentPoolProfileProperties == nil {
+					errChan <- fmt.Errorf("[AZURE] Node pool %s of AKS cluster %s has no properties", pool.Name, cluster.Name)
+					continue
+				}
+				if agentPool.Mode == containerservice.AgentPoolModeSystem {
+					log.Infof("[AZURE] System node pool %s of AKS cluster %s cannot be scaled to zero", pool.Name, cluster.Name)
+					continue
+				}
+				if ctx.DryRun {
+					log.Infof("[AZURE] Dry-run set, node pool %s of AKS cluster %s is not scaled to zero", pool.Name, cluster.Name)
+					continue
+				}
+				log.Infof("[AZURE] Scale node pool %s of AKS cluster %s to zero", pool.Name, cluster.Name)
+				saveAgentPoolScaling(agentPool.ManagedClusterAgentPoolProfileProperties)
+				agentPool.Count = to.Int32Ptr(0)
+				agentPool.EnableAutoScaling = to.BoolPtr(false)
+				agentPool.MinCount = nil
+				agentPool.MaxCount = nil
+				future, err := p.agentPoolClient.CreateOrUpdate(context.Background(), resourceGroupName, cluster.Name, pool.Name, agentPool)
+				if err == nil {
+					err = future.WaitForCompletionRef(context.Background(), p.agentPoolClient.Client)
+				}
+				if err != nil {
+					log.Errorf("[AZURE] Unable to scale node pool %s of AKS cluster %s to zero because: %s", pool.Name, cluster.Name, err.Error())
+					errChan <- err
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// saveAgentPoolScaling tags the node pool with its current scaling, the tag is written together with the new size
+func saveAgentPoolScaling(properties *containerservice.ManagedClusterAgentPoolProfileProperties) {
+	scaling := types.NodePoolScaling{}
+	if properties.Count != nil {
+		scaling.Desired = int(*properties.Count)
+	}
+	if properties.MinCount != nil {
+		scaling.Min = int(*properties.MinCount)
+	}
+	if properties.MaxCount != nil {
+		scaling.Max = int(*properties.MaxCount)
+	}
+	if properties.EnableAutoScaling != nil {
+		scaling.Autoscaling = *properties.EnableAutoScaling
+	}
+	if properties.Tags == nil {
+		properties.Tags = map[string]*string{}
+	}
+	properties.Tags[types.ScalingTag] = to.StringPtr(scaling.String())
+}
@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAksClusters(t *testing.T) {
+	managedClusters := []containerservice.ManagedCluster{
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/running"),
+			Name:     to.StringPtr("running"),
+			Location: to.StringPtr("westeurope"),
+			Tags:     map[string]*string{"owner": to.StringPtr("owner"), "cb-creation-timestamp": to.StringPtr("1527240203")},
+			ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+				KubernetesVersion: to.StringPtr("1.23.5"),
+				AgentPoolProfiles: &[]containerservice.ManagedClusterAgentPoolProfile{
+					{Name: to.StringPtr("system"), Count: to.Int32Ptr(1), VMSize: to.StringPtr("Standard_D2s_v3"), Mode: containerservice.AgentPoolModeSystem},
+					{Name: to.StringPtr("user"), Count: to.Int32Ptr(3), VMSize: to.StringPtr("Standard_D4s_v3"), EnableAutoScaling: to.BoolPtr(true)},
+				},
+			},
+		},
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/stopped"),
+			Name:     to.StringPtr("stopped"),
+			Location: to.StringPtr("westeurope"),
+			ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+				PowerState: &containerservice.PowerState{Code: containerservice.CodeStopped},
+				AgentPoolProfiles: &[]containerservice.ManagedClusterAgentPoolProfile{
+					{Name: to.StringPtr("system"), Count: to.Int32Ptr(1)},
+				},
+			},
+		},
+	}
+
+	clusters := getAksClusters(managedClusters)
+
+	assert.Equal(t, 2, len(clusters))
+	running := clusters[0]
+	assert.Equal(t, types.AksCluster, running.Type)
+	assert.Equal(t, types.Running, running.State)
+	assert.Equal(t, "owner", running.Owner)
+	assert.Equal(t, "rg", running.Metadata["resourceGroupName"])
+	assert.Equal(t, 4, running.Config.GetNodeCount())
+	assert.Equal(t, []string{"Standard_D2s_v3", "Standard_D4s_v3"}, running.Config.GetInstanceTypes())
+	assert.True(t, running.Config.NodePools[1].Autoscaling)
+	assert.Equal(t, types.Stopped, clusters[1].State)
+}
+
+func TestGetAksClusterStateWithSystemNodePool(t *testing.T) {
+	config := &types.ClusterConfig{NodePools: []*types.NodePool{{Name: "system", NodeCount: 1, System: true}, {Name: "user"}}}
+	systemOnly := &types.ClusterConfig{NodePools: []*types.NodePool{{Name: "system", NodeCount: 1, System: true}}}
+
+	assert.Equal(t, types.Stopped, getAksClusterState(containerservice.CodeRunning, config))
+	assert.Equal(t, types.Running, getAksClusterState(containerservice.CodeRunning, systemOnly))
+	assert.Equal(t, types.Running, getAksClusterState(containerservice.CodeRunning, &types.ClusterConfig{}))
+}
+
+func TestSaveAgentPoolScaling(t *testing.T) {
+	properties := &containerservice.ManagedClusterAgentPoolProfileProperties{
+		Count:             to.Int32Ptr(3),
+		MinCount:          to.Int32Ptr(1),
+		MaxCount:          to.Int32Ptr(5),
+		EnableAutoScaling: to.BoolPtr(true),
+	}
+
+	saveAgentPoolScaling(properties)
+
+	assert.Equal(t, "desired-3_min-1_max-5_autoscaling-on", *properties.Tags[types.ScalingTag])
+}
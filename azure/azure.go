@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,9 +15,14 @@ import (
 	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-01-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2015-11-01/resources"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2015-11-01/subscriptions"
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-03-01/web"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -29,6 +35,7 @@ var provider = azureProvider{}
 
 type azureProvider struct {
 	subscriptionID         string
+	authorizer             autorest.Authorizer
 	vmClient               compute.VirtualMachinesClient
 	subscriptionClient     subscriptions.Client
 	vmScaleSetClient       compute.VirtualMachineScaleSetsClient
@@ -37,7 +44,16 @@ type azureProvider struct {
 	rgClient               resources.GroupsClient
 	storageAccountClient   storage.AccountsClient
 	storageContainerClient storage.BlobContainersClient
-	// resClient      resources.Client
+	metricsClient          insights.MetricsClient
+	diskClient             compute.DisksClient
+	snapshotClient         compute.SnapshotsClient
+	publicIPClient         network.PublicIPAddressesClient
+	loadBalancerClient     network.LoadBalancersClient
+	managedClusterClient   containerservice.ManagedClustersClient
+	agentPoolClient        containerservice.AgentPoolsClient
+	appsClient             web.AppsClient
+	resourcesClient        resources.Client
+	registryClient         containerregistry.RegistriesClient
 }
 
 func init() {
@@ -64,6 +80,7 @@ func (p *azureProvider) init(subscriptionID string, authorizer func() (autorest.
 		return errors.New("Failed to authenticate, err: " + err.Error())
 	}
 	p.subscriptionID = subscriptionID
+	p.authorizer = authorization
 	p.vmClient = compute.NewVirtualMachinesClient(subscriptionID)
 	p.vmClient.Authorizer = authorization
 	p.subscriptionClient = subscriptions.NewClient()
@@ -80,6 +97,26 @@ func (p *azureProvider) init(subscriptionID string, authorizer func() (autorest.
 	p.storageAccountClient.Authorizer = authorization
 	p.storageContainerClient = storage.NewBlobContainersClient(subscriptionID)
 	p.storageContainerClient.Authorizer = authorization
+	p.metricsClient = insights.NewMetricsClient(subscriptionID)
+	p.metricsClient.Authorizer = authorization
+	p.diskClient = compute.NewDisksClient(subscriptionID)
+	p.diskClient.Authorizer = authorization
+	p.snapshotClient = compute.NewSnapshotsClient(subscriptionID)
+	p.snapshotClient.Authorizer = authorization
+	p.publicIPClient = network.NewPublicIPAddressesClient(subscriptionID)
+	p.publicIPClient.Authorizer = authorization
+	p.loadBalancerClient = network.NewLoadBalancersClient(subscriptionID)
+	p.loadBalancerClient.Authorizer = authorization
+	p.managedClusterClient = containerservice.NewManagedClustersClient(subscriptionID)
+	p.managedClusterClient.Authorizer = authorization
+	p.agentPoolClient = containerservice.NewAgentPoolsClient(subscriptionID)
+	p.agentPoolClient.Authorizer = authorization
+	p.appsClient = web.NewAppsClient(subscriptionID)
+	p.appsClient.Authorizer = authorization
+	p.resourcesClient = resources.NewClient(subscriptionID)
+	p.resourcesClient.Authorizer = authorization
+	p.registryClient = containerregistry.NewRegistriesClient(subscriptionID)
+	p.registryClient.Authorizer = authorization
 	return nil
 }
 
@@ -104,20 +141,60 @@ func (p azureProvider) GetStacks() ([]*types.Stack, error) {
 		return nil, err
 	}
 
+	resourceCounts, err := p.getResourceCountsByGroup()
+	if err != nil {
+		return nil, err
+	}
+
 	var stacks []*types.Stack
 	for i := 0; resourceGroups.NotDone(); i++ {
 		log.Infof("[AZURE] Fetching resource groups, round: %d", i+1)
 		for _, rg := range resourceGroups.Values() {
-			stacks = append(stacks, newStack(rg))
+			stacks = append(stacks, newStack(rg, resourceCounts[strings.ToLower(*rg.Name)]))
 		}
 		if err := resourceGroups.Next(); err != nil {
 			log.Errorf("[AZURE] All resource groups are fetched, err: %s", err.Error())
 		}
 	}
 
+	p.inferOwners(newStackOwnerResources(stacks))
 	return stacks, nil
 }
 
+// getResourceCountsByGroup returns the number of resources in each resource group of the subscription, keyed by
+// the lower case name of the group as the casing of the resource IDs is not consistent
+func (p azureProvider) getResourceCountsByGroup() (map[string]int, error) {
+	log.Debug("[AZURE] Fetching resources")
+	result, err := p.resourcesClient.ListComplete(context.Background(), "", "", nil)
+	if err != nil {
+		log.Errorf("[AZURE] Failed to fetch the resources, err: %s", err.Error())
+		return nil, err
+	}
+	var resourceIDs []string
+	for result.NotDone() {
+		if resource := result.Value(); resource.ID != nil {
+			resourceIDs = append(resourceIDs, *resource.ID)
+		}
+		if err := result.NextWithContext(context.Background()); err != nil {
+			log.Errorf("[AZURE] Failed to fetch the next page of resources, err: %s", err.Error())
+			return nil, err
+		}
+	}
+	return getResourceCountsByGroup(resourceIDs), nil
+}
+
+func getResourceCountsByGroup(resourceIDs []string) map[string]int {
+	resourceCounts := map[string]int{}
+	for _, resourceID := range resourceIDs {
+		// /subscriptions/<sub_id>/resourceGroups/<rg_name>/providers/<namespace>/<type>/<name>
+		parts := strings.Split(resourceID, "/")
+		if len(parts) > 4 && strings.EqualFold(parts[3], "resourceGroups") {
+			resourceCounts[strings.ToLower(parts[4])]++
+		}
+	}
+	return resourceCounts
+}
+
 func (p azureProvider) GetInstances() ([]*types.Instance, error) {
 	log.Debug("[AZURE] Fetching instances")
 	vms, err := p.vmClient.ListAll(context.Background())
@@ -216,6 +293,7 @@ func (p azureProvider) GetInstances() ([]*types.Instance, error) {
 		}
 	}
 
+	p.inferOwners(newInstanceOwnerResources(instances))
 	return instances, nil
 }
 
@@ -233,12 +311,29 @@ type azureScaleSetInstance struct {
 	tagMap            map[string]*string
 }
 
-func (p azureProvider) GetDisks() ([]*types.Disk, error) {
-	return nil, errors.New("[AZURE] Disk operations are not supported")
+
+func (p azureProvider) GetGateways() ([]*types.Gateway, error) {
+	return nil, errors.New("[AZURE] Gateway operations are not supported")
+}
+
+func (p azureProvider) DeleteGateways(*types.GatewayContainer) []error {
+	return []error{errors.New("[AZURE] Gateway deletion is not supported")}
+}
+
+func (p azureProvider) GetNotebooks() ([]*types.Notebook, error) {
+	return nil, errors.New("[AZURE] Notebook operations are not supported")
+}
+
+func (p azureProvider) StopNotebooks(*types.NotebookContainer) []error {
+	return []error{errors.New("[AZURE] Stopping notebooks is not supported")}
 }
 
-func (p azureProvider) DeleteDisks(*types.DiskContainer) []error {
-	return []error{errors.New("[AZURE] Disk deletion is not supported")}
+func (p azureProvider) GetNetworks() ([]*types.Network, error) {
+	return nil, errors.New("[AZURE] Network operations are not supported, use the empty resource groups instead")
+}
+
+func (p azureProvider) DeleteNetworks(*types.NetworkContainer) []error {
+	return []error{errors.New("[AZURE] Network deletion is not supported")}
 }
 
 func (p azureProvider) GetImages() ([]*types.Image, error) {
@@ -406,6 +501,14 @@ func (p azureProvider) DeleteAlerts(*types.AlertContainer) []error {
 	return []error{errors.New("[AZURE] Deleting alerts is not supported yet")}
 }
 
+func (p azureProvider) DisableAccesses(*types.AccessContainer) []error {
+	return []error{errors.New("[AZURE] Disabling accesses is not supported yet")}
+}
+
+func (p azureProvider) DeleteAccesses(*types.AccessContainer) []error {
+	return []error{errors.New("[AZURE] Deleting accesses is not supported yet")}
+}
+
 func (p azureProvider) GetStorages() ([]*types.Storage, error) {
 	accountListResultIterator, err := p.storageAccountClient.ListComplete(context.Background())
 	if err != nil {
@@ -724,7 +827,7 @@ func getCreationTimeFromTags(tags types.Tags, convertTimeUnix func(unixTimestamp
 	return convertTimeUnix("0")
 }
 
-func newStack(rg resources.Group) *types.Stack {
+func newStack(rg resources.Group, resourceCount int) *types.Stack {
 	tags := utils.ConvertTags(rg.Tags)
 	return &types.Stack{
 		ID:        *rg.ID,
@@ -735,9 +838,6 @@ func newStack(rg resources.Group) *types.Stack {
 		CloudType: types.AZURE,
 		State:     types.Running,
 		Region:    *rg.Location,
+		Metadata:  map[string]string{types.ResourceCountMetadataKey: strconv.Itoa(resourceCount)},
 	}
 }
-
-func (p azureProvider) GetClusters() ([]*types.Cluster, error) {
-	return nil, errors.New("NotImplemented")
-}
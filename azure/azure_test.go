@@ -9,6 +9,7 @@ import (
 	"github.com/blentz/cloud-haunter/types"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2015-11-01/resources"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,6 +88,22 @@ func TestGetResourceGroupName(t *testing.T) {
 	assert.Equal(t, "<inst_name>", name)
 }
 
+func TestGetResourceCountsByGroup(t *testing.T) {
+	resourceCounts := getResourceCountsByGroup([]string{
+		"/subscriptions/<sub_id>/resourceGroups/RG-1/providers/Microsoft.Compute/virtualMachines/vm",
+		"/subscriptions/<sub_id>/resourcegroups/rg-1/providers/Microsoft.Compute/disks/disk",
+		"/subscriptions/<sub_id>/resourceGroups/rg-2/providers/Microsoft.Network/publicIPAddresses/ip",
+	})
+
+	assert.Equal(t, map[string]int{"rg-1": 2, "rg-2": 1}, resourceCounts)
+}
+
+func TestNewStackResourceCount(t *testing.T) {
+	stack := newStack(resources.Group{ID: &(&types.S{S: "id"}).S, Name: &(&types.S{S: "rg-1"}).S, Location: &(&types.S{S: "westus"}).S}, 0)
+
+	assert.Equal(t, "0", stack.Metadata[types.ResourceCountMetadataKey])
+}
+
 func TestGetResourceGroupNameNotFound(t *testing.T) {
 	resourceGroupName, name := getResourceGroupName("")
 
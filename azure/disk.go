@@ -0,0 +1,111 @@
+package azure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p azureProvider) GetDisks() ([]*types.Disk, error) {
+	log.Debug("[AZURE] Fetching disks")
+	var azureDisks []compute.Disk
+	result, err := p.diskClient.ListComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		azureDisks = append(azureDisks, result.Value())
+	}
+	disks := getDisks(azureDisks)
+	p.inferOwners(newDiskOwnerResources(disks))
+	return disks, nil
+}
+
+// getDisks converts the managed disks, a disk that is not attached to a VM is unused
+func getDisks(azureDisks []compute.Disk) []*types.Disk {
+	log.Debugf("[AZURE] Processing disks (%d)", len(azureDisks))
+	var disks []*types.Disk
+	for _, disk := range azureDisks {
+		tags := utils.ConvertTags(disk.Tags)
+		resourceGroupName, _ := getResourceGroupName(*disk.ID)
+		aDisk := &types.Disk{
+			ID:        *disk.ID,
+			Name:      *disk.Name,
+			Created:   getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			State:     types.InUse,
+			Owner:     tags[ctx.OwnerLabel],
+			CloudType: types.AZURE,
+			Region:    *disk.Location,
+			Metadata:  map[string]string{"resourceGroupName": resourceGroupName},
+			Tags:      tags,
+		}
+		if disk.ManagedBy == nil {
+			aDisk.State = types.Unused
+		}
+		if disk.Sku != nil {
+			aDisk.Type = string(disk.Sku.Name)
+		}
+		if properties := disk.DiskProperties; properties != nil {
+			if properties.TimeCreated != nil {
+				aDisk.Created = properties.TimeCreated.Time
+			}
+			if properties.DiskSizeGB != nil {
+				aDisk.Size = int64(*properties.DiskSizeGB)
+			}
+		}
+		disks = append(disks, aDisk)
+	}
+	return disks
+}
+
+func (p azureProvider) DeleteDisks(disks *types.DiskContainer) []error {
+	azureDisks := disks.Get(types.AZURE)
+	log.Debugf("[AZURE] Deleting disks: %v", azureDisks)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureDisks))
+	errChan := make(chan error)
+
+	for _, d := range azureDisks {
+		go func(disk *types.Disk) {
+			defer wg.Done()
+
+			if disk.State != types.Unused {
+				log.Infof("[AZURE] Disk %s is attached to a VM, it is not deleted", disk.Name)
+				return
+			}
+			if ctx.DryRun {
+				log.Infof("[AZURE] Dry-run set, disk is not deleted: %s", disk.Name)
+				return
+			}
+			log.Infof("[AZURE] Delete disk: %s", disk.ID)
+			future, err := p.diskClient.Delete(context.Background(), disk.Metadata["resourceGroupName"], disk.Name)
+			if err == nil {
+				err = future.WaitForCompletionRef(context.Background(), p.diskClient.Client)
+			}
+			if err != nil {
+				log.Errorf("[AZURE] Unable to delete disk: %s because: %s", disk.ID, err.Error())
+				errChan <- err
+			}
+		}(d)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,45 @@
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDisks(t *testing.T) {
+	azureDisks := []compute.Disk{
+		{
+			ID:        to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/disks/attached"),
+			Name:      to.StringPtr("attached"),
+			Location:  to.StringPtr("westeurope"),
+			ManagedBy: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm"),
+			Sku:       &compute.DiskSku{Name: compute.PremiumLRS},
+			Tags:      map[string]*string{"owner": to.StringPtr("owner")},
+			DiskProperties: &compute.DiskProperties{
+				TimeCreated: &date.Time{Time: time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC)},
+				DiskSizeGB:  to.Int32Ptr(128),
+			},
+		},
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/disks/detached"),
+			Name:     to.StringPtr("detached"),
+			Location: to.StringPtr("westeurope"),
+		},
+	}
+
+	disks := getDisks(azureDisks)
+
+	assert.Equal(t, 2, len(disks))
+	assert.Equal(t, types.InUse, disks[0].State)
+	assert.Equal(t, "owner", disks[0].Owner)
+	assert.Equal(t, "rg", disks[0].Metadata["resourceGroupName"])
+	assert.Equal(t, int64(128), disks[0].Size)
+	assert.Equal(t, "Premium_LRS", disks[0].Type)
+	assert.Equal(t, time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC), disks[0].Created)
+	assert.Equal(t, types.Unused, disks[1].State)
+}
@@ -0,0 +1,140 @@
+package azure
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-03-01/web"
+	"github.com/Azure/go-autorest/autorest/to"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetFunctions returns the function apps with their executions in the lookback window
+func (p azureProvider) GetFunctions() ([]*types.Function, error) {
+	log.Debug("[AZURE] Fetching function apps")
+	var sites []web.Site
+	result, err := p.appsClient.ListComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		if site := result.Value(); site.Kind != nil && strings.Contains(*site.Kind, "functionapp") {
+			sites = append(sites, site)
+		}
+	}
+
+	runtimes := map[string]string{}
+	for _, site := range sites {
+		resourceGroupName, _ := getResourceGroupName(*site.ID)
+		settings, err := p.appsClient.ListApplicationSettings(context.Background(), resourceGroupName, *site.Name)
+		if err != nil {
+			log.Debugf("[AZURE] Cannot list the application settings of function app: %s, err: %s", *site.Name, err)
+			continue
+		}
+		runtimes[*site.ID] = to.String(settings.Properties["FUNCTIONS_WORKER_RUNTIME"])
+	}
+
+	end := time.Now()
+	start := end.Add(-utils.GetInvocationLookback())
+	return getFunctions(sites, runtimes, start, func(function *types.Function) (int64, error) {
+		return getExecutions(p.metricsClient, function, start, end)
+	}), nil
+}
+
+// getExecutions returns the number of executions of the functions of the app between the start and end time
+func getExecutions(client metricsClient, function *types.Function, start, end time.Time) (int64, error) {
+	values, err := getMetricValues(client, function, types.MetricQuery{Name: "FunctionExecutionCount", Statistic: types.SumStatistic, Period: 24 * time.Hour, Start: start, End: end})
+	if err != nil {
+		return 0, err
+	}
+	var executions float64
+	for _, value := range values {
+		executions += value
+	}
+	return int64(math.Round(executions)), nil
+}
+
+// getFunctions converts the function apps, the ones whose executions cannot be counted are in Unknown state. Azure
+// does not return the creation time, it is read from the tags like for the other resources.
+func getFunctions(sites []web.Site, runtimes map[string]string, start time.Time, countInvocations func(*types.Function) (int64, error)) []*types.Function {
+	log.Debugf("[AZURE] Processing function apps (%d)", len(sites))
+	var functions []*types.Function
+	for _, site := range sites {
+		tags := utils.ConvertTags(site.Tags)
+		resourceGroupName, _ := getResourceGroupName(*site.ID)
+		function := &types.Function{
+			ID:        *site.ID,
+			Name:      *site.Name,
+			Type:      types.AzureFunction,
+			Runtime:   runtimes[*site.ID],
+			Created:   getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			State:     types.Unknown,
+			Owner:     tags[ctx.OwnerLabel],
+			CloudType: types.AZURE,
+			Region:    to.String(site.Location),
+			Metadata:  map[string]string{"resourceGroupName": resourceGroupName},
+			Tags:      tags,
+		}
+		if properties := site.SiteProperties; properties != nil {
+			if properties.LastModifiedTimeUtc != nil {
+				function.LastModified = properties.LastModifiedTimeUtc.Time
+			}
+			function.Metadata["state"] = to.String(properties.State)
+			function.Metadata["serverFarmId"] = to.String(properties.ServerFarmID)
+		}
+		if invocations, err := countInvocations(function); err != nil {
+			log.Errorf("[AZURE] Failed to count the executions of function app: %s, err: %s", function.Name, err)
+		} else {
+			function.Invocations = invocations
+			function.State = types.GetInvocationState(invocations, function.LastModified, start)
+		}
+		functions = append(functions, function)
+	}
+	return functions
+}
+
+// DeleteFunctions deletes the function apps, their App Service plans are kept as other apps may use them
+func (p azureProvider) DeleteFunctions(functions *types.FunctionContainer) []error {
+	azureFunctions := functions.Get(types.AZURE)
+	log.Debugf("[AZURE] Deleting function apps: %v", azureFunctions)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureFunctions))
+	errChan := make(chan error)
+
+	for _, f := range azureFunctions {
+		go func(function *types.Function) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[AZURE] Dry-run set, function app is not deleted: %s", function.Name)
+				return
+			}
+			log.Infof("[AZURE] Delete function app: %s in resource group: %s", function.Name, function.Metadata["resourceGroupName"])
+			if _, err := p.appsClient.Delete(context.Background(), function.Metadata["resourceGroupName"], function.Name, nil, to.BoolPtr(false)); err != nil {
+				log.Errorf("[AZURE] Failed to delete function app: %s, err: %s", function.Name, err)
+				errChan <- err
+			}
+		}(f)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-03-01/web"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFunctions(t *testing.T) {
+	start := time.Now().Add(-720 * time.Hour)
+	appID := "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Web/sites/app"
+	sites := []web.Site{
+		{
+			ID:       to.StringPtr(appID),
+			Name:     to.StringPtr("app"),
+			Kind:     to.StringPtr("functionapp,linux"),
+			Location: to.StringPtr("westeurope"),
+			Tags:     map[string]*string{"owner": to.StringPtr("owner"), "cb-creation-timestamp": to.StringPtr("1527240203")},
+			SiteProperties: &web.SiteProperties{
+				State:               to.StringPtr("Running"),
+				LastModifiedTimeUtc: &date.Time{Time: start.Add(-time.Hour)},
+			},
+		},
+		{
+			ID:   to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Web/sites/uncounted"),
+			Name: to.StringPtr("uncounted"),
+		},
+	}
+
+	functions := getFunctions(sites, map[string]string{appID: "python"}, start, func(function *types.Function) (int64, error) {
+		if function.Name == "uncounted" {
+			return 0, errors.New("error")
+		}
+		return 0, nil
+	})
+
+	assert.Equal(t, 2, len(functions))
+	app := functions[0]
+	assert.Equal(t, types.AzureFunction, app.Type)
+	assert.Equal(t, "python", app.Runtime)
+	assert.Equal(t, "westeurope", app.Region)
+	assert.Equal(t, "owner", app.Owner)
+	assert.Equal(t, "rg", app.Metadata["resourceGroupName"])
+	assert.Equal(t, int64(1527240203), app.Created.Unix())
+	assert.Equal(t, types.Unused, app.State)
+	assert.Equal(t, types.Unknown, functions[1].State)
+}
+
+func TestGetExecutions(t *testing.T) {
+	client := &mockMetricsClient{data: []insights.MetricValue{{Total: to.Float64Ptr(2)}, {Total: to.Float64Ptr(3)}}}
+	end := time.Now()
+
+	executions, err := getExecutions(client, &types.Function{ID: "app"}, end.Add(-720*time.Hour), end)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), executions)
+	assert.Equal(t, "FunctionExecutionCount", client.metricnames)
+	assert.Equal(t, "P1D", client.interval)
+}
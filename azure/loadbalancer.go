@@ -0,0 +1,110 @@
+package azure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-01-01/network"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p azureProvider) GetLoadBalancers() ([]*types.LoadBalancer, error) {
+	log.Debug("[AZURE] Fetching load balancers")
+	var azureLoadBalancers []network.LoadBalancer
+	result, err := p.loadBalancerClient.ListAllComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; result.NotDone(); err = result.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		azureLoadBalancers = append(azureLoadBalancers, result.Value())
+	}
+	return getLoadBalancers(azureLoadBalancers), nil
+}
+
+// getLoadBalancers converts the load balancers, a load balancer is unused if none of its backend pools have
+// registered IP configurations. The probe health is only exposed as a metric, so the registered targets are counted
+// as healthy.
+func getLoadBalancers(azureLoadBalancers []network.LoadBalancer) []*types.LoadBalancer {
+	log.Debugf("[AZURE] Processing load balancers (%d)", len(azureLoadBalancers))
+	var loadBalancers []*types.LoadBalancer
+	for _, loadBalancer := range azureLoadBalancers {
+		tags := utils.ConvertTags(loadBalancer.Tags)
+		resourceGroupName, _ := getResourceGroupName(*loadBalancer.ID)
+		targets := 0
+		if properties := loadBalancer.LoadBalancerPropertiesFormat; properties != nil && properties.BackendAddressPools != nil {
+			for _, pool := range *properties.BackendAddressPools {
+				if pool.BackendAddressPoolPropertiesFormat != nil && pool.BackendIPConfigurations != nil {
+					targets += len(*pool.BackendIPConfigurations)
+				}
+			}
+		}
+		state := types.InUse
+		if targets == 0 {
+			state = types.Unused
+		}
+		aLoadBalancer := &types.LoadBalancer{
+			ID:             *loadBalancer.ID,
+			Name:           *loadBalancer.Name,
+			Created:        getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			State:          state,
+			Owner:          tags[ctx.OwnerLabel],
+			CloudType:      types.AZURE,
+			Region:         *loadBalancer.Location,
+			Targets:        targets,
+			HealthyTargets: targets,
+			Metadata:       map[string]string{"resourceGroupName": resourceGroupName},
+			Tags:           tags,
+		}
+		if loadBalancer.Sku != nil {
+			aLoadBalancer.Type = string(loadBalancer.Sku.Name)
+		}
+		loadBalancers = append(loadBalancers, aLoadBalancer)
+	}
+	return loadBalancers
+}
+
+func (p azureProvider) DeleteLoadBalancers(loadBalancers *types.LoadBalancerContainer) []error {
+	azureLoadBalancers := loadBalancers.Get(types.AZURE)
+	log.Debugf("[AZURE] Deleting load balancers: %v", azureLoadBalancers)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureLoadBalancers))
+	errChan := make(chan error)
+
+	for _, l := range azureLoadBalancers {
+		go func(loadBalancer *types.LoadBalancer) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[AZURE] Dry-run set, load balancer is not deleted: %s", loadBalancer.Name)
+				return
+			}
+			log.Infof("[AZURE] Delete load balancer: %s", loadBalancer.ID)
+			future, err := p.loadBalancerClient.Delete(context.Background(), loadBalancer.Metadata["resourceGroupName"], loadBalancer.Name)
+			if err == nil {
+				err = future.WaitForCompletionRef(context.Background(), p.loadBalancerClient.Client)
+			}
+			if err != nil {
+				log.Errorf("[AZURE] Unable to delete load balancer: %s because: %s", loadBalancer.ID, err.Error())
+				errChan <- err
+			}
+		}(l)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-01-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLoadBalancers(t *testing.T) {
+	azureLoadBalancers := []network.LoadBalancer{
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/loadBalancers/empty"),
+			Name:     to.StringPtr("empty"),
+			Location: to.StringPtr("westeurope"),
+			Sku:      &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+			Tags:     map[string]*string{"owner": to.StringPtr("owner"), "cb-creation-timestamp": to.StringPtr("1527240203")},
+			LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+				BackendAddressPools: &[]network.BackendAddressPool{
+					{BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{}},
+				},
+			},
+		},
+		{
+			ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/loadBalancers/used"),
+			Name:     to.StringPtr("used"),
+			Location: to.StringPtr("westeurope"),
+			LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+				BackendAddressPools: &[]network.BackendAddressPool{
+					{
+						BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{
+							BackendIPConfigurations: &[]network.InterfaceIPConfiguration{{ID: to.StringPtr("ipconfig")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	loadBalancers := getLoadBalancers(azureLoadBalancers)
+
+	assert.Equal(t, 2, len(loadBalancers))
+	assert.Equal(t, "rg", loadBalancers[0].Metadata["resourceGroupName"])
+	assert.Equal(t, "owner", loadBalancers[0].Owner)
+	assert.Equal(t, "Standard", loadBalancers[0].Type)
+	assert.Equal(t, int64(1527240203), loadBalancers[0].Created.Unix())
+	assert.Equal(t, types.Unused, loadBalancers[0].State)
+	assert.Equal(t, types.InUse, loadBalancers[1].State)
+	assert.Equal(t, 1, loadBalancers[1].Targets)
+}
@@ -0,0 +1,106 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultMetricInterval is the granularity of the datapoints if the query does not specify one
+const defaultMetricInterval = time.Hour
+
+type metricsClient interface {
+	List(ctx context.Context, resourceURI string, timespan string, interval *string, metricnames string, aggregation string, top *int32, orderby string, filter string, resultType insights.ResultType, metricnamespace string) (insights.Response, error)
+}
+
+func (p azureProvider) GetMetricValues(item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	return getMetricValues(p.metricsClient, item, query)
+}
+
+// getMetricValues returns the datapoints of the metric of the item in chronological order as returned by Azure Monitor
+func getMetricValues(client metricsClient, item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	resourceURI, err := getMetricResourceURI(item)
+	if err != nil {
+		return nil, err
+	}
+	aggregation, err := getAggregation(query.Statistic)
+	if err != nil {
+		return nil, err
+	}
+	period := query.Period
+	if period == 0 {
+		period = defaultMetricInterval
+	}
+	interval := getMetricInterval(period)
+	timespan := query.Start.UTC().Format(time.RFC3339) + "/" + query.End.UTC().Format(time.RFC3339)
+
+	log.Debugf("[AZURE] Fetching %s %s of %s in %s", query.Statistic, query.Name, resourceURI, timespan)
+	result, err := client.List(context.Background(), resourceURI, timespan, &interval, query.Name, string(aggregation), nil, "", "", insights.Data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var values []float64
+	if result.Value == nil {
+		return values, nil
+	}
+	for _, metric := range *result.Value {
+		if metric.Timeseries == nil {
+			continue
+		}
+		for _, series := range *metric.Timeseries {
+			if series.Data == nil {
+				continue
+			}
+			for _, data := range *series.Data {
+				value := data.Average
+				if aggregation == insights.TimeAggregationTypeTotal {
+					value = data.Total
+				}
+				// intervals without data, e.g. when the VM was deallocated, have no value
+				if value != nil {
+					values = append(values, *value)
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+func getMetricResourceURI(item types.CloudItem) (string, error) {
+	switch t := item.GetItem().(type) {
+	case types.Instance:
+		if len(t.Metadata["scaleSetName"]) == 0 {
+			return t.ID, nil
+		}
+	case types.Function:
+		return t.ID, nil
+	}
+	return "", fmt.Errorf("metrics are not supported for %s: %s", item.GetType(), item.GetName())
+}
+
+func getAggregation(statistic string) (insights.TimeAggregationType, error) {
+	switch statistic {
+	case types.AverageStatistic:
+		return insights.TimeAggregationTypeAverage, nil
+	case types.SumStatistic:
+		return insights.TimeAggregationTypeTotal, nil
+	}
+	return "", fmt.Errorf("unsupported statistic: %s", statistic)
+}
+
+// getMetricInterval converts the period to an ISO 8601 duration, e.g. 1 hour to PT1H
+func getMetricInterval(period time.Duration) string {
+	switch {
+	case period%(24*time.Hour) == 0:
+		return fmt.Sprintf("P%dD", period/(24*time.Hour))
+	case period%time.Hour == 0:
+		return fmt.Sprintf("PT%dH", period/time.Hour)
+	default:
+		return fmt.Sprintf("PT%dM", period/time.Minute)
+	}
+}
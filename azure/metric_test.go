@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMetricsClient struct {
+	resourceURI string
+	timespan    string
+	interval    string
+	metricnames string
+	aggregation string
+	data        []insights.MetricValue
+}
+
+func (c *mockMetricsClient) List(ctx context.Context, resourceURI string, timespan string, interval *string, metricnames string, aggregation string, top *int32, orderby string, filter string, resultType insights.ResultType, metricnamespace string) (insights.Response, error) {
+	c.resourceURI, c.timespan, c.interval, c.metricnames, c.aggregation = resourceURI, timespan, *interval, metricnames, aggregation
+	return insights.Response{Value: &[]insights.Metric{{Timeseries: &[]insights.TimeSeriesElement{{Data: &c.data}}}}}, nil
+}
+
+func TestGetMetricValuesAverage(t *testing.T) {
+	client := &mockMetricsClient{data: []insights.MetricValue{{Average: to.Float64Ptr(1.5)}, {}, {Average: to.Float64Ptr(2.5), Total: to.Float64Ptr(5)}}}
+	start := time.Date(2018, 5, 25, 11, 0, 0, 0, time.UTC)
+	query := types.MetricQuery{Name: "Percentage CPU", Statistic: types.AverageStatistic, Period: time.Hour, Start: start, End: start.Add(24 * time.Hour)}
+
+	values, err := getMetricValues(client, &types.Instance{ID: "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", Metadata: map[string]string{}}, query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1.5, 2.5}, values)
+	assert.Equal(t, "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", client.resourceURI)
+	assert.Equal(t, "2018-05-25T11:00:00Z/2018-05-26T11:00:00Z", client.timespan)
+	assert.Equal(t, "PT1H", client.interval)
+	assert.Equal(t, "Percentage CPU", client.metricnames)
+	assert.Equal(t, "Average", client.aggregation)
+}
+
+func TestGetMetricValuesTotal(t *testing.T) {
+	client := &mockMetricsClient{data: []insights.MetricValue{{Total: to.Float64Ptr(1024)}}}
+	query := types.MetricQuery{Name: "Network In Total", Statistic: types.SumStatistic, Start: time.Now().Add(-time.Hour), End: time.Now()}
+
+	values, err := getMetricValues(client, &types.Instance{ID: "vm", Metadata: map[string]string{}}, query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1024}, values)
+	assert.Equal(t, "Total", client.aggregation)
+	assert.Equal(t, "PT1H", client.interval)
+}
+
+func TestGetMetricValuesScaleSetInstanceNotSupported(t *testing.T) {
+	_, err := getMetricValues(&mockMetricsClient{}, &types.Instance{ID: "vm", Metadata: map[string]string{"scaleSetName": "ss"}}, types.MetricQuery{Statistic: types.AverageStatistic})
+
+	assert.NotNil(t, err)
+}
+
+func TestGetMetricInterval(t *testing.T) {
+	assert.Equal(t, "PT5M", getMetricInterval(5*time.Minute))
+	assert.Equal(t, "PT6H", getMetricInterval(6*time.Hour))
+	assert.Equal(t, "P1D", getMetricInterval(24*time.Hour))
+}
@@ -0,0 +1,250 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2015-11-01/resources"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// activityLogLookbackPeriod is the period the Activity Log keeps the events for
+var activityLogLookbackPeriod = 90 * 24 * time.Hour
+
+// ownerResource is a resource without owner whose creator is looked up in the Activity Log
+type ownerResource struct {
+	id            string
+	created       time.Time
+	operationName string
+	setOwner      func(caller string)
+}
+
+type activityLogsIterator interface {
+	NotDone() bool
+	Value() insights.EventData
+	NextWithContext(context.Context) error
+}
+
+func newInstanceOwnerResources(instances []*types.Instance) []*ownerResource {
+	var resources []*ownerResource
+	for _, i := range instances {
+		if _, ok := i.Metadata["scaleSetName"]; len(i.Owner) == 0 && !ok {
+			inst := i
+			resources = append(resources, &ownerResource{strings.ToLower(inst.ID), inst.Created, "microsoft.compute/virtualmachines/write", func(caller string) {
+				inst.Metadata = setCaller(inst.Metadata, caller)
+				inst.Owner = getInferredOwner(inst.Owner, caller)
+			}})
+		}
+	}
+	return resources
+}
+
+func newDiskOwnerResources(disks []*types.Disk) []*ownerResource {
+	var resources []*ownerResource
+	for _, d := range disks {
+		if len(d.Owner) == 0 {
+			disk := d
+			resources = append(resources, &ownerResource{strings.ToLower(disk.ID), disk.Created, "microsoft.compute/disks/write", func(caller string) {
+				disk.Metadata = setCaller(disk.Metadata, caller)
+				disk.Owner = getInferredOwner(disk.Owner, caller)
+			}})
+		}
+	}
+	return resources
+}
+
+func newStackOwnerResources(stacks []*types.Stack) []*ownerResource {
+	var resources []*ownerResource
+	for _, s := range stacks {
+		if len(s.Owner) == 0 {
+			stack := s
+			resources = append(resources, &ownerResource{strings.ToLower(stack.ID), stack.Created, "microsoft.resources/subscriptions/resourcegroups/write", func(caller string) {
+				stack.Metadata = setCaller(stack.Metadata, caller)
+				stack.Owner = getInferredOwner(stack.Owner, caller)
+			}})
+		}
+	}
+	return resources
+}
+
+func setCaller(metadata map[string]string, caller string) map[string]string {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["Caller"] = caller
+	return metadata
+}
+
+func getInferredOwner(owner, caller string) string {
+	if len(owner) == 0 && utils.IsInferredOwnerPromoted() {
+		return caller
+	}
+	return owner
+}
+
+func (p azureProvider) inferOwners(resources []*ownerResource) {
+	if !ctx.OwnerInference || len(resources) == 0 {
+		return
+	}
+	activityLogsClient := insights.NewActivityLogsClient(p.subscriptionID)
+	activityLogsClient.Authorizer = p.authorizer
+	inferOwners(func(filter string) (activityLogsIterator, error) {
+		iterator, err := activityLogsClient.ListComplete(context.Background(), filter, "caller,eventTimestamp,operationName,resourceId,status")
+		return &iterator, err
+	}, p.subscriptionID, utils.GetOwnerCache(), resources)
+}
+
+// inferOwners sets the creators of the resources from the cache or the Activity Log. The creators of all the
+// resources are looked up in a single query instead of one query per resource. The Activity Log is optional,
+// if it cannot be read the resources are left without creator.
+func inferOwners(getIterator func(filter string) (activityLogsIterator, error), subscriptionID string, cache *utils.OwnerCache, resources []*ownerResource) {
+	var missing []*ownerResource
+	for _, resource := range resources {
+		if owner, ok := cache.Get(getOwnerCacheKey(subscriptionID, resource)); !ok {
+			missing = append(missing, resource)
+		} else if len(owner) > 0 {
+			resource.setOwner(owner)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	creators, err := lookupCreators(getIterator, missing)
+	if err != nil {
+		log.Warnf("[AZURE] Failed to fetch the Activity Log, err: %s", err.Error())
+		return
+	}
+	for _, resource := range missing {
+		owner := creators[resource.id]
+		cache.Set(getOwnerCacheKey(subscriptionID, resource), owner)
+		if len(owner) > 0 {
+			resource.setOwner(owner)
+		}
+	}
+	cache.Save()
+}
+
+func getOwnerCacheKey(subscriptionID string, resource *ownerResource) string {
+	return "AZURE:" + subscriptionID + ":" + resource.id
+}
+
+// lookupCreators returns the callers by the lower case ID of the resources. A resource is written on every update,
+// the caller of its earliest succeeded write operation is treated as its creator.
+func lookupCreators(getIterator func(filter string) (activityLogsIterator, error), resources []*ownerResource) (map[string]string, error) {
+	now := time.Now()
+	startTime := now
+	operationNames := map[string]string{}
+	for _, resource := range resources {
+		if resource.created.Add(-time.Hour).Before(startTime) {
+			startTime = resource.created.Add(-time.Hour)
+		}
+		operationNames[resource.id] = resource.operationName
+	}
+	if startTime.Before(now.Add(-activityLogLookbackPeriod)) {
+		startTime = now.Add(-activityLogLookbackPeriod)
+	}
+	filter := fmt.Sprintf("eventTimestamp ge '%s' and eventTimestamp le '%s'", startTime.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))
+	log.Debugf("[AZURE] Looking up the creators in the Activity Log: %s", filter)
+
+	iterator, err := getIterator(filter)
+	if err != nil {
+		return nil, err
+	}
+	creators := map[string]string{}
+	created := map[string]time.Time{}
+	for iterator.NotDone() {
+		event := iterator.Value()
+		if id, ok := getCreatedResourceID(event, operationNames); ok {
+			if timestamp, found := created[id]; !found || event.EventTimestamp.Time.Before(timestamp) {
+				created[id] = event.EventTimestamp.Time
+				creators[id] = *event.Caller
+			}
+		}
+		if err := iterator.NextWithContext(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	return creators, nil
+}
+
+// getCreatedResourceID returns the lower case ID of the resource if the event is a succeeded write operation of one
+// of the resources
+func getCreatedResourceID(event insights.EventData, operationNames map[string]string) (string, bool) {
+	if event.ResourceID == nil || event.Caller == nil || event.EventTimestamp == nil || event.OperationName == nil || event.OperationName.Value == nil {
+		return "", false
+	}
+	if event.Status == nil || event.Status.Value == nil || *event.Status.Value != "Succeeded" {
+		return "", false
+	}
+	id := strings.ToLower(*event.ResourceID)
+	if operationName, ok := operationNames[id]; !ok || operationName != strings.ToLower(*event.OperationName.Value) {
+		return "", false
+	}
+	return id, true
+}
+
+func getOwnerTags(tags types.Tags, owner string) map[string]*string {
+	ownerTags := map[string]*string{}
+	for k, v := range tags {
+		value := v
+		ownerTags[k] = &value
+	}
+	ownerTags[ctx.OwnerLabel] = &owner
+	return ownerTags
+}
+
+func (p azureProvider) TagOwners(items []types.CloudItem) []error {
+	log.Debug("[AZURE] Tagging owners")
+	var errs []error
+	for _, item := range items {
+		var call func(tags map[string]*string) error
+		switch t := item.(type) {
+		case *types.Instance:
+			if _, ok := t.Metadata["scaleSetName"]; ok {
+				log.Warnf("[AZURE] Scale set instance %s has the tags of its scale set, tag the scale set instead", t.Name)
+				continue
+			}
+			call = func(tags map[string]*string) error {
+				future, err := p.vmClient.Update(context.Background(), t.Metadata["resourceGroupName"], t.Name, compute.VirtualMachineUpdate{Tags: tags})
+				if err != nil {
+					return err
+				}
+				return future.WaitForCompletionRef(context.Background(), p.vmClient.Client)
+			}
+		case *types.Disk:
+			call = func(tags map[string]*string) error {
+				future, err := p.diskClient.Update(context.Background(), t.Metadata["resourceGroupName"], t.Name, compute.DiskUpdate{Tags: tags})
+				if err != nil {
+					return err
+				}
+				return future.WaitForCompletionRef(context.Background(), p.diskClient.Client)
+			}
+		case *types.Stack:
+			call = func(tags map[string]*string) error {
+				_, err := p.rgClient.Patch(context.Background(), t.Name, resources.Group{Tags: tags})
+				return err
+			}
+		default:
+			log.Debugf("[AZURE] Tagging owner is not supported for %s: %s", item.GetType(), item.GetName())
+			continue
+		}
+		if ctx.DryRun {
+			log.Infof("[AZURE] Dry-run set, %s %s is not tagged with owner %s", item.GetType(), item.GetName(), item.GetOwner())
+			continue
+		}
+		log.Infof("[AZURE] Tagging %s %s with owner %s", item.GetType(), item.GetName(), item.GetOwner())
+		if err := call(getOwnerTags(item.GetTags(), item.GetOwner())); err != nil {
+			log.Errorf("[AZURE] Failed to tag %s %s with owner %s, err: %s", item.GetType(), item.GetName(), item.GetOwner(), err)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,131 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/go-autorest/autorest/date"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockActivityLogsIterator struct {
+	events []insights.EventData
+	index  int
+}
+
+func (i *mockActivityLogsIterator) NotDone() bool {
+	return i.index < len(i.events)
+}
+
+func (i *mockActivityLogsIterator) Value() insights.EventData {
+	return i.events[i.index]
+}
+
+func (i *mockActivityLogsIterator) NextWithContext(context.Context) error {
+	i.index++
+	return nil
+}
+
+func newEvent(resourceID, operationName, status, caller string, timestamp time.Time) insights.EventData {
+	return insights.EventData{
+		ResourceID:     &resourceID,
+		OperationName:  &insights.LocalizableString{Value: &operationName},
+		Status:         &insights.LocalizableString{Value: &status},
+		Caller:         &caller,
+		EventTimestamp: &date.Time{Time: timestamp},
+	}
+}
+
+func newMockActivityLogsIterator(filters *[]string, err error) func(string) (activityLogsIterator, error) {
+	now := time.Now()
+	vm := "/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Compute/virtualMachines/vm"
+	return func(filter string) (activityLogsIterator, error) {
+		*filters = append(*filters, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &mockActivityLogsIterator{events: []insights.EventData{
+			newEvent(vm, "Microsoft.Compute/virtualMachines/write", "Succeeded", "updater@example.com", now),
+			newEvent(vm, "Microsoft.Compute/virtualMachines/write", "Succeeded", "creator@example.com", now.Add(-time.Hour)),
+			newEvent(vm, "Microsoft.Compute/virtualMachines/write", "Failed", "failed@example.com", now.Add(-2*time.Hour)),
+			newEvent(vm, "Microsoft.Compute/virtualMachines/deallocate/action", "Succeeded", "operator@example.com", now.Add(-3*time.Hour)),
+			newEvent("/subscriptions/sub/resourceGroups/rg", "Microsoft.Resources/subscriptions/resourceGroups/write", "Succeeded", "admin@example.com", now),
+		}}, nil
+	}
+}
+
+func newOwnerTestInstances() []*types.Instance {
+	return []*types.Instance{
+		{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", Metadata: map[string]string{}},
+		{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/other", Metadata: map[string]string{}},
+		{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/tagged", Owner: "owner", Metadata: map[string]string{}},
+		{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/ss/virtualMachines/0", Metadata: map[string]string{"scaleSetName": "ss"}},
+	}
+}
+
+func TestInferOwners(t *testing.T) {
+	var filters []string
+	instances := newOwnerTestInstances()
+	stacks := []*types.Stack{{ID: "/subscriptions/sub/resourceGroups/rg"}}
+	resources := append(newInstanceOwnerResources(instances), newStackOwnerResources(stacks)...)
+
+	inferOwners(newMockActivityLogsIterator(&filters, nil), "sub", utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json")), resources)
+
+	assert.Equal(t, 3, len(resources))
+	assert.Equal(t, 1, len(filters))
+	assert.Contains(t, filters[0], "eventTimestamp ge '")
+	assert.Equal(t, "creator@example.com", instances[0].Metadata["Caller"])
+	assert.Equal(t, "", instances[0].Owner)
+	assert.Equal(t, "", instances[1].Metadata["Caller"])
+	assert.Equal(t, "", instances[2].Metadata["Caller"])
+	assert.Equal(t, "admin@example.com", stacks[0].Metadata["Caller"])
+	assert.Equal(t, "admin@example.com", types.GetInferredOwner(stacks[0].Metadata))
+}
+
+func TestNewDiskOwnerResources(t *testing.T) {
+	resources := newDiskOwnerResources([]*types.Disk{
+		{ID: "/subscriptions/sub/resourceGroups/RG/providers/Microsoft.Compute/disks/Disk"},
+		{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/tagged", Owner: "owner"},
+	})
+
+	assert.Equal(t, 1, len(resources))
+	assert.Equal(t, "/subscriptions/sub/resourcegroups/rg/providers/microsoft.compute/disks/disk", resources[0].id)
+	assert.Equal(t, "microsoft.compute/disks/write", resources[0].operationName)
+}
+
+func TestInferOwnersFromCache(t *testing.T) {
+	var filters []string
+	cacheFile := filepath.Join(t.TempDir(), "owners.json")
+	inferOwners(newMockActivityLogsIterator(&filters, nil), "sub", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(newOwnerTestInstances()))
+	instances := newOwnerTestInstances()
+
+	inferOwners(newMockActivityLogsIterator(&filters, nil), "sub", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 1, len(filters))
+	assert.Equal(t, "creator@example.com", instances[0].Metadata["Caller"])
+}
+
+func TestInferOwnersActivityLogFailure(t *testing.T) {
+	var filters []string
+	instances := newOwnerTestInstances()
+
+	inferOwners(newMockActivityLogsIterator(&filters, errors.New("forbidden")), "sub", utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json")), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 1, len(filters))
+	assert.Equal(t, "", instances[0].Metadata["Caller"])
+}
+
+func TestGetOwnerTags(t *testing.T) {
+	tags := getOwnerTags(types.Tags{"key": "value"}, "creator")
+
+	assert.Equal(t, 2, len(tags))
+	assert.Equal(t, "value", *tags["key"])
+	assert.Equal(t, "creator", *tags[ctx.OwnerLabel])
+}
@@ -0,0 +1,119 @@
+package azure
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func (p azureProvider) GetSnapshots() ([]*types.Snapshot, error) {
+	log.Debug("[AZURE] Fetching snapshots")
+	existingSources := map[string]bool{}
+	disks, err := p.diskClient.ListComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; disks.NotDone(); err = disks.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		existingSources[strings.ToLower(*disks.Value().ID)] = true
+	}
+
+	var azureSnapshots []compute.Snapshot
+	snapshots, err := p.snapshotClient.ListComplete(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for ; snapshots.NotDone(); err = snapshots.NextWithContext(context.Background()) {
+		if err != nil {
+			return nil, err
+		}
+		azureSnapshots = append(azureSnapshots, snapshots.Value())
+		existingSources[strings.ToLower(*snapshots.Value().ID)] = true
+	}
+	return getSnapshots(azureSnapshots, existingSources), nil
+}
+
+// getSnapshots converts the snapshots, a snapshot is orphaned if the disk or snapshot it was copied from no longer exists
+func getSnapshots(azureSnapshots []compute.Snapshot, existingSources map[string]bool) []*types.Snapshot {
+	log.Debugf("[AZURE] Processing snapshots (%d)", len(azureSnapshots))
+	var snapshots []*types.Snapshot
+	for _, snapshot := range azureSnapshots {
+		tags := utils.ConvertTags(snapshot.Tags)
+		resourceGroupName, _ := getResourceGroupName(*snapshot.ID)
+		aSnapshot := &types.Snapshot{
+			ID:        *snapshot.ID,
+			Name:      *snapshot.Name,
+			Created:   getCreationTimeFromTags(tags, utils.ConvertTimeUnix),
+			State:     types.Available,
+			Owner:     tags[ctx.OwnerLabel],
+			CloudType: types.AZURE,
+			Region:    *snapshot.Location,
+			Metadata:  map[string]string{"resourceGroupName": resourceGroupName},
+			Tags:      tags,
+		}
+		if properties := snapshot.DiskProperties; properties != nil {
+			if properties.TimeCreated != nil {
+				aSnapshot.Created = properties.TimeCreated.Time
+			}
+			if properties.DiskSizeGB != nil {
+				aSnapshot.Size = int64(*properties.DiskSizeGB)
+			}
+			if properties.CreationData != nil && properties.CreationData.SourceResourceID != nil {
+				aSnapshot.SourceDisk = *properties.CreationData.SourceResourceID
+				if !existingSources[strings.ToLower(aSnapshot.SourceDisk)] {
+					aSnapshot.State = types.Orphaned
+				}
+			}
+		}
+		snapshots = append(snapshots, aSnapshot)
+	}
+	return snapshots
+}
+
+func (p azureProvider) DeleteSnapshots(snapshots *types.SnapshotContainer) []error {
+	azureSnapshots := snapshots.Get(types.AZURE)
+	log.Debugf("[AZURE] Deleting snapshots: %v", azureSnapshots)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(azureSnapshots))
+	errChan := make(chan error)
+
+	for _, s := range azureSnapshots {
+		go func(snapshot *types.Snapshot) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[AZURE] Dry-run set, snapshot is not deleted: %s", snapshot.Name)
+				return
+			}
+			log.Infof("[AZURE] Delete snapshot: %s", snapshot.ID)
+			future, err := p.snapshotClient.Delete(context.Background(), snapshot.Metadata["resourceGroupName"], snapshot.Name)
+			if err == nil {
+				err = future.WaitForCompletionRef(context.Background(), p.snapshotClient.Client)
+			}
+			if err != nil {
+				log.Errorf("[AZURE] Unable to delete snapshot: %s because: %s", snapshot.ID, err.Error())
+				errChan <- err
+			}
+		}(s)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,46 @@
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSnapshot(name string, sourceResourceID *string) compute.Snapshot {
+	return compute.Snapshot{
+		ID:       to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/snapshots/" + name),
+		Name:     to.StringPtr(name),
+		Location: to.StringPtr("westeurope"),
+		Tags:     map[string]*string{"owner": to.StringPtr("owner")},
+		DiskProperties: &compute.DiskProperties{
+			TimeCreated:  &date.Time{Time: time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC)},
+			DiskSizeGB:   to.Int32Ptr(30),
+			CreationData: &compute.CreationData{SourceResourceID: sourceResourceID},
+		},
+	}
+}
+
+func TestGetSnapshots(t *testing.T) {
+	azureSnapshots := []compute.Snapshot{
+		newTestSnapshot("backup", to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/disks/Disk")),
+		newTestSnapshot("orphan", to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/disks/deleted")),
+		newTestSnapshot("imported", nil),
+	}
+
+	snapshots := getSnapshots(azureSnapshots, map[string]bool{"/subscriptions/s/resourcegroups/rg/providers/microsoft.compute/disks/disk": true})
+
+	assert.Equal(t, 3, len(snapshots))
+	assert.Equal(t, "backup", snapshots[0].Name)
+	assert.Equal(t, "rg", snapshots[0].Metadata["resourceGroupName"])
+	assert.Equal(t, int64(30), snapshots[0].Size)
+	assert.Equal(t, "owner", snapshots[0].Owner)
+	assert.Equal(t, time.Date(2018, 5, 25, 0, 0, 0, 0, time.UTC), snapshots[0].Created)
+	assert.Equal(t, types.Available, snapshots[0].State)
+	assert.Equal(t, types.Orphaned, snapshots[1].State)
+	assert.Equal(t, types.Available, snapshots[2].State)
+}
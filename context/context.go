@@ -35,14 +35,22 @@ var Verbose = false
 // IgnoreLabelDisabled is a global flag for enabling/disabling ignore label usage
 var IgnoreLabelDisabled = false
 
+// DisabledAccessesIncluded is a global flag for keeping the disabled credentials in the access filters, only the
+// disable action needs them to delete the credentials after the grace period
+var DisabledAccessesIncluded = false
+
+// OwnerInference is a global flag for looking up the creators of all the resources without owner in the audit logs,
+// without it only the creators of the running AWS instances and databases are looked up
+var OwnerInference = false
+
 // ExactMatchOwner is a global flag for 'exact match' or 'starts with' matching of owner
 var ExactMatchOwner = false
 
 // Operations contains all the available operations
 var Operations = make(map[types.OpType]types.Operation)
 
-// Filters that can be applied on the operations
-var Filters = make(map[types.FilterType]types.Filter)
+// Filters contains the constructors of the filters that can be applied on the operations
+var Filters = make(map[types.FilterType]types.FilterConstructor)
 
 // CloudProviders contains all the available cloud providers
 var CloudProviders = make(map[types.CloudType]func() types.CloudProvider)
@@ -53,5 +61,8 @@ var Dispatchers = make(map[string]types.Dispatcher)
 // Actions contains all the available actions
 var Actions = make(map[types.ActionType]types.Action)
 
+// OperationItems contains all the items returned by the operation before filtering
+var OperationItems []types.CloudItem
+
 // FilterConfig contains the include/exclude configurations from config file
 var FilterConfig types.IFilterConfig
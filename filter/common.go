@@ -1,7 +1,11 @@
 package operation
 
 import (
+	"fmt"
+	"os"
 	"reflect"
+	"strconv"
+	"time"
 
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
@@ -9,6 +13,60 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// noParams returns the constructor of a filter that does not accept parameters
+func noParams(f types.Filter) types.FilterConstructor {
+	return func(params types.FilterParams) (types.Filter, error) {
+		if err := checkParams(params); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+}
+
+// checkParams returns an error if there is a parameter that is not known by the filter
+func checkParams(params types.FilterParams, known ...string) error {
+	for key := range params {
+		if !utils.IsAnyEquals(key, known...) {
+			return fmt.Errorf("unknown filter parameter: %s, known parameters: %v", key, known)
+		}
+	}
+	return nil
+}
+
+// getParam returns the value of the parameter, or the value of the environment variable if the parameter is not set
+func getParam(params types.FilterParams, key string, env string) string {
+	if value, ok := params[key]; ok {
+		return value
+	}
+	return os.Getenv(env)
+}
+
+// getDurationParam returns the parameter as duration, falls back to the environment variable and the default value
+func getDurationParam(params types.FilterParams, key string, env string, defaultValue time.Duration) (time.Duration, error) {
+	value := getParam(params, key, env)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration for %s: %s", key, err)
+	}
+	return duration, nil
+}
+
+// getFloatParam returns the parameter as float, falls back to the environment variable and the default value
+func getFloatParam(params types.FilterParams, key string, env string, defaultValue float64) (float64, error) {
+	value := getParam(params, key, env)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number for %s: %s", key, err)
+	}
+	return number, nil
+}
+
 func filter(filterName string, items []types.CloudItem, filterType types.FilterConfigType, isNeeded func(types.CloudItem) bool) []types.CloudItem {
 	var filtered []types.CloudItem
 	for _, item := range items {
@@ -66,7 +124,7 @@ func isFilterMatch(filterName string, item types.CloudItem, filterType types.Fil
 		} else {
 			filterEntityType = types.ExcludeCluster
 		}
-	case types.Instance, types.Stack, types.Database, types.Disk, types.Alert, types.Storage:
+	case types.Instance, types.Stack, types.Database, types.Disk, types.Alert, types.Storage, types.Snapshot, types.Address, types.LoadBalancer, types.Gateway, types.Notebook, types.ContainerImage, types.Function, types.Network:
 		if filterType.IsInclusive() {
 			filterEntityType = types.IncludeInstance
 		} else {
@@ -0,0 +1,73 @@
+package operation
+
+import (
+	"errors"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+var defaultMonthlyThreshold = 100.0
+
+type costly struct {
+	monthlyThreshold float64
+	accruedThreshold float64
+}
+
+func init() {
+	ctx.Filters[types.CostlyFilter] = newCostly
+}
+
+// newCostly creates the filter from the 'monthly' and 'accrued' USD thresholds, a threshold of 0 is disabled.
+// If none of them is set the monthly threshold defaults to 100.
+func newCostly(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "monthly", "accrued"); err != nil {
+		return nil, err
+	}
+	accruedThreshold, err := getFloatParam(params, "accrued", "COSTLY_ACCRUED_THRESHOLD", 0)
+	if err != nil {
+		log.Errorf("[COSTLY] err: %s", err)
+		return nil, err
+	}
+	monthlyDefault := defaultMonthlyThreshold
+	if accruedThreshold > 0 {
+		monthlyDefault = 0
+	}
+	monthlyThreshold, err := getFloatParam(params, "monthly", "COSTLY_MONTHLY_THRESHOLD", monthlyDefault)
+	if err != nil {
+		log.Errorf("[COSTLY] err: %s", err)
+		return nil, err
+	}
+	if monthlyThreshold < 0 || accruedThreshold < 0 {
+		return nil, errors.New("cost thresholds must not be negative")
+	}
+	log.Infof("[COSTLY] monthly threshold set to: %.2f, accrued threshold set to: %.2f", monthlyThreshold, accruedThreshold)
+	return costly{monthlyThreshold, accruedThreshold}, nil
+}
+
+func (f costly) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[COSTLY] Filtering items (%d): [%s]", len(items), items)
+	now := time.Now()
+	return filter("COSTLY", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
+		cost, ok := pricing.Estimate(item)
+		if !ok {
+			log.Debugf("[COSTLY] Filter %s, because its cost is unknown: %s", item.GetType(), item.GetName())
+			return false
+		}
+		if f.monthlyThreshold > 0 && cost.Monthly > f.monthlyThreshold {
+			log.Debugf("[COSTLY] %s: %s monthly cost: %.2f match: true", item.GetType(), item.GetName(), cost.Monthly)
+			return true
+		}
+		if f.accruedThreshold > 0 {
+			accrued := cost.Hourly * now.Sub(item.GetCreated()).Hours()
+			match := accrued > f.accruedThreshold
+			log.Debugf("[COSTLY] %s: %s accrued cost: %.2f match: %v", item.GetType(), item.GetName(), accrued, match)
+			return match
+		}
+		log.Debugf("[COSTLY] %s: %s monthly cost: %.2f match: false", item.GetType(), item.GetName(), cost.Monthly)
+		return false
+	})
+}
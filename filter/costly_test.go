@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostlyInit(t *testing.T) {
+	assert.NotNil(t, ctx.Filters[types.CostlyFilter])
+}
+
+func newCostlyTestItems() []types.CloudItem {
+	now := time.Now()
+	return []types.CloudItem{
+		&types.Instance{CloudType: types.AWS, Name: "gpu", InstanceType: "p3.2xlarge", Region: "us-east-1", State: types.Running, Created: now},
+		&types.Instance{CloudType: types.AWS, Name: "old micro", InstanceType: "t3.micro", Region: "us-east-1", State: types.Running, Created: now.Add(-24 * 365 * time.Hour)},
+		&types.Instance{CloudType: types.AWS, Name: "stopped gpu", InstanceType: "p3.2xlarge", Region: "us-east-1", State: types.Stopped, Created: now},
+		&types.Instance{CloudType: types.AWS, Name: "unknown", InstanceType: "x9.huge", Region: "us-east-1", State: types.Running, Created: now},
+		&types.Instance{CloudType: types.AWS, Name: "ignored gpu", InstanceType: "p3.2xlarge", Region: "us-east-1", State: types.Running, Created: now, Tags: types.Tags{ctx.IgnoreLabel: "true"}},
+	}
+}
+
+func TestCostlyFilterMonthly(t *testing.T) {
+	filter, _ := newCostly(types.FilterParams{})
+
+	filteredItems := filter.Execute(newCostlyTestItems())
+
+	assert.Equal(t, []string{"gpu"}, getItemNames(filteredItems))
+}
+
+func TestCostlyFilterAccrued(t *testing.T) {
+	filter, _ := newCostly(types.FilterParams{"accrued": "50"})
+
+	filteredItems := filter.Execute(newCostlyTestItems())
+
+	assert.Equal(t, []string{"old micro"}, getItemNames(filteredItems))
+}
+
+func TestCostlyFilterMonthlyOrAccrued(t *testing.T) {
+	filter, _ := newCostly(types.FilterParams{"monthly": "1000", "accrued": "50"})
+
+	filteredItems := filter.Execute(newCostlyTestItems())
+
+	assert.Equal(t, []string{"gpu", "old micro"}, getItemNames(filteredItems))
+}
+
+func TestNewCostlyWithInvalidParams(t *testing.T) {
+	for _, params := range []types.FilterParams{{"monthly": "a lot"}, {"accrued": "-1"}, {"daily": "10"}} {
+		_, err := newCostly(params)
+
+		assert.NotNil(t, err)
+	}
+}
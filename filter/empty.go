@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Filters[types.EmptyFilter] = noParams(empty{})
+}
+
+type empty struct {
+}
+
+// Execute keeps the stacks and networks that contain no billable resources. If the resources could not be counted,
+// the item is not considered empty.
+func (f empty) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[EMPTY] Filtering items (%d): [%s]", len(items), items)
+	return filter("EMPTY", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
+		switch item.GetItem().(type) {
+		case types.Stack, types.Network:
+			count, ok := types.GetResourceCount(types.GetMetadata(item))
+			if !ok {
+				log.Debugf("[EMPTY] Filter %s, because its resources are not counted: %s", item.GetType(), item.GetName())
+				return false
+			}
+			if count != 0 {
+				log.Debugf("[EMPTY] Filter %s, because it contains %d resources: %s", item.GetType(), count, item.GetName())
+				return false
+			}
+		default:
+			log.Fatalf("[EMPTY] Filter does not apply for cloud item: %s", item.GetName())
+		}
+		log.Debugf("[EMPTY] Item was not filtered: %s", item.GetName())
+		return true
+	})
+}
@@ -0,0 +1,22 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmptyFilter(t *testing.T) {
+	items := []types.CloudItem{
+		&types.Stack{CloudType: types.AZURE, Name: "empty resource group", Metadata: map[string]string{types.ResourceCountMetadataKey: "0"}},
+		&types.Stack{CloudType: types.AZURE, Name: "resource group", Metadata: map[string]string{types.ResourceCountMetadataKey: "2"}},
+		&types.Stack{CloudType: types.AWS, Name: "uncounted stack", Metadata: map[string]string{}},
+		&types.Network{CloudType: types.AWS, Name: "empty vpc", Metadata: map[string]string{types.ResourceCountMetadataKey: "0"}},
+		&types.Network{CloudType: types.GCP, Name: "network", Metadata: map[string]string{types.ResourceCountMetadataKey: "1"}},
+	}
+
+	filteredItems := empty{}.Execute(items)
+
+	assert.Equal(t, []string{"empty resource group", "empty vpc"}, getItemNames(filteredItems))
+}
@@ -0,0 +1,278 @@
+package operation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// ParseExpression builds a filter tree from an expression like "stopped or (longrunning(period=6h) and not ownerless)".
+// Operands are the names of the registered filters with optional parameters in parentheses,
+// comma is an alias of 'and' to keep the "filter1,filter2" form working.
+// Precedence from the highest: 'not', 'and', 'or'. Returns a nil filter if the expression is empty.
+func ParseExpression(expression string, filters map[types.FilterType]types.FilterConstructor) (types.Filter, []types.FilterType, error) {
+	p := &expressionParser{tokens: tokenize(expression), filters: filters}
+	if len(p.tokens) == 0 {
+		return nil, nil, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, nil, fmt.Errorf("unexpected '%s' in filter expression: %s", p.tokens[p.pos], expression)
+	}
+	log.Debugf("[EXPRESSION] Filter expression parsed: %s", root)
+	return root, p.names, nil
+}
+
+func tokenize(expression string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range expression {
+		switch {
+		case r == '(' || r == ')' || r == ',':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type expressionParser struct {
+	tokens  []string
+	pos     int
+	filters map[types.FilterType]types.FilterConstructor
+	names   []types.FilterType
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *expressionParser) isKeyword(keyword string) bool {
+	return strings.EqualFold(p.peek(), keyword)
+}
+
+func (p *expressionParser) parseOr() (types.Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (types.Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") || p.peek() == "," {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseNot() (types.Filter, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *expressionParser) parseOperand() (types.Filter, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	case token == "(":
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ')' in filter expression")
+		}
+		p.pos++
+		return inner, nil
+	case token == ")" || token == "," || p.isKeyword("and") || p.isKeyword("or"):
+		return nil, fmt.Errorf("unexpected '%s' in filter expression", token)
+	}
+	p.pos++
+	params, err := p.parseParams()
+	if err != nil {
+		return nil, err
+	}
+	for fType, newFilter := range p.filters {
+		if strings.EqualFold(fType.String(), token) {
+			f, err := newFilter(params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create filter %s: %s", fType, err)
+			}
+			p.addName(fType)
+			return namedFilter{fType, params, f}, nil
+		}
+	}
+	return nil, fmt.Errorf("filter is not found: %s", token)
+}
+
+// parseParams parses the optional "(key1=value1,key2=value2)" part after a filter name
+func (p *expressionParser) parseParams() (types.FilterParams, error) {
+	params := types.FilterParams{}
+	if p.peek() != "(" {
+		return params, nil
+	}
+	p.pos++
+	for p.peek() != ")" {
+		token := p.peek()
+		if token == "" {
+			return nil, fmt.Errorf("missing ')' after filter parameters")
+		}
+		keyValue := strings.SplitN(token, "=", 2)
+		if len(keyValue) != 2 || len(keyValue[0]) == 0 {
+			return nil, fmt.Errorf("invalid filter parameter '%s', expected key=value", token)
+		}
+		params[keyValue[0]] = keyValue[1]
+		p.pos++
+		if p.peek() == "," {
+			p.pos++
+		}
+	}
+	p.pos++
+	return params, nil
+}
+
+func (p *expressionParser) addName(fType types.FilterType) {
+	for _, n := range p.names {
+		if n == fType {
+			return
+		}
+	}
+	p.names = append(p.names, fType)
+}
+
+type namedFilter struct {
+	name   types.FilterType
+	params types.FilterParams
+	filter types.Filter
+}
+
+func (f namedFilter) Execute(items []types.CloudItem) []types.CloudItem {
+	return f.filter.Execute(items)
+}
+
+func (f namedFilter) String() string {
+	if len(f.params) == 0 {
+		return f.name.String()
+	}
+	return fmt.Sprintf("%s%v", f.name, map[string]string(f.params))
+}
+
+type andFilter struct {
+	left  types.Filter
+	right types.Filter
+}
+
+// Execute narrows the result of the left operand with the right one, the same way as the filter chain does
+func (f andFilter) Execute(items []types.CloudItem) []types.CloudItem {
+	return f.right.Execute(f.left.Execute(items))
+}
+
+func (f andFilter) String() string {
+	return fmt.Sprintf("(%s and %s)", f.left, f.right)
+}
+
+type orFilter struct {
+	left  types.Filter
+	right types.Filter
+}
+
+// Execute returns the items matched by any of the operands in their original order
+func (f orFilter) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[OR] Filtering items (%d): [%s]", len(items), items)
+	matched := toItemSet(f.left.Execute(items))
+	for key := range toItemSet(f.right.Execute(items)) {
+		matched[key] = true
+	}
+	var filtered []types.CloudItem
+	for _, item := range items {
+		if matched[itemKey(item)] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func (f orFilter) String() string {
+	return fmt.Sprintf("(%s or %s)", f.left, f.right)
+}
+
+type notFilter struct {
+	operand types.Filter
+}
+
+// Execute returns the items not matched by the operand. The ignore label and the exclude config are still respected,
+// so negating a filter never brings back an item that is protected from the actions.
+func (f notFilter) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[NOT] Filtering items (%d): [%s]", len(items), items)
+	matched := toItemSet(f.operand.Execute(items))
+	return filter("NOT", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
+		return !matched[itemKey(item)]
+	})
+}
+
+func (f notFilter) String() string {
+	return fmt.Sprintf("not %s", f.operand)
+}
+
+func toItemSet(items []types.CloudItem) map[interface{}]bool {
+	set := make(map[interface{}]bool, len(items))
+	for _, item := range items {
+		set[itemKey(item)] = true
+	}
+	return set
+}
+
+// itemKey returns the identity of an item. Operations return pointers, but items passed by value can't be map keys.
+func itemKey(item types.CloudItem) interface{} {
+	if reflect.TypeOf(item).Comparable() {
+		return item
+	}
+	return fmt.Sprintf("%s:%s:%s:%d", item.GetCloudType(), item.GetType(), item.GetName(), item.GetCreated().UnixNano())
+}
@@ -0,0 +1,116 @@
+package operation
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type nameFilter struct {
+	names map[string]bool
+}
+
+func (f nameFilter) Execute(items []types.CloudItem) []types.CloudItem {
+	var filtered []types.CloudItem
+	for _, item := range items {
+		if f.names[item.GetName()] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func newExpressionTestItems() []types.CloudItem {
+	return []types.CloudItem{
+		&types.Instance{Name: "a", CloudType: types.AWS},
+		&types.Instance{Name: "b", CloudType: types.AWS},
+		&types.Instance{Name: "c", CloudType: types.AWS},
+		&types.Instance{Name: "d", CloudType: types.AWS, Tags: types.Tags{ctx.IgnoreLabel: "true"}},
+	}
+}
+
+func newNameFilter(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "names"); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, name := range strings.Split(params["names"], "+") {
+		names[name] = true
+	}
+	return nameFilter{names}, nil
+}
+
+func newExpressionTestFilters() map[types.FilterType]types.FilterConstructor {
+	return map[types.FilterType]types.FilterConstructor{
+		types.FilterType("ab"):     noParams(nameFilter{map[string]bool{"a": true, "b": true}}),
+		types.FilterType("bc"):     noParams(nameFilter{map[string]bool{"b": true, "c": true}}),
+		types.FilterType("c-only"): noParams(nameFilter{map[string]bool{"c": true}}),
+		types.FilterType("names"):  newNameFilter,
+	}
+}
+
+func getItemNames(items []types.CloudItem) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.GetName())
+	}
+	return names
+}
+
+func TestParseExpressionEmpty(t *testing.T) {
+	filter, names, err := ParseExpression(" ", newExpressionTestFilters())
+
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+	assert.Empty(t, names)
+}
+
+func TestParseExpressionCommaIsAnd(t *testing.T) {
+	filter, names, _ := ParseExpression("ab,bc", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"b"}, getItemNames(filter.Execute(newExpressionTestItems())))
+	assert.Equal(t, []types.FilterType{"ab", "bc"}, names)
+}
+
+func TestParseExpressionOr(t *testing.T) {
+	filter, _, _ := ParseExpression("ab or c-only", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"a", "b", "c"}, getItemNames(filter.Execute(newExpressionTestItems())))
+}
+
+func TestParseExpressionNotRespectsIgnoreLabel(t *testing.T) {
+	filter, _, _ := ParseExpression("NOT ab", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"c"}, getItemNames(filter.Execute(newExpressionTestItems())))
+}
+
+func TestParseExpressionPrecedence(t *testing.T) {
+	filter, _, _ := ParseExpression("c-only or ab and not bc", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"a", "c"}, getItemNames(filter.Execute(newExpressionTestItems())))
+}
+
+func TestParseExpressionParentheses(t *testing.T) {
+	filter, names, _ := ParseExpression("(c-only or ab) and not(bc)", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"a"}, getItemNames(filter.Execute(newExpressionTestItems())))
+	assert.Equal(t, []types.FilterType{"c-only", "ab", "bc"}, names)
+}
+
+func TestParseExpressionParams(t *testing.T) {
+	filter, names, _ := ParseExpression("(names(names=a+d) or names( names=c )),bc", newExpressionTestFilters())
+
+	assert.Equal(t, []string{"c"}, getItemNames(filter.Execute(newExpressionTestItems())))
+	assert.Equal(t, []types.FilterType{"names", "bc"}, names)
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	for _, expression := range []string{"unknown", "ab or", "(ab", "ab bc", "and ab", "ab)", "ab(x=1)", "names(names)", "names(names=a"} {
+		_, _, err := ParseExpression(expression, newExpressionTestFilters())
+
+		assert.NotNil(t, err, expression)
+	}
+}
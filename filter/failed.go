@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.FailedFilter] = failed{}
+	ctx.Filters[types.FailedFilter] = noParams(failed{})
 }
 
 type failed struct {
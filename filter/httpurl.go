@@ -1,8 +1,6 @@
 package operation
 
 import (
-	"os"
-
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
 	log "github.com/sirupsen/logrus"
@@ -14,17 +12,23 @@ type httpUrl struct {
 }
 
 func init() {
-	// initialize new FilterType objects
-	httpPathEnv := os.Getenv("HTTPURL_PATH")
-	if len(httpPathEnv) < 1 {
-		log.Warn("[HTTPURL] no path found in HTTPURL_PATH environment variable.")
+	ctx.Filters[types.HttpUrlFilter] = newHttpUrl
+}
+
+func newHttpUrl(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "path", "port"); err != nil {
+		return nil, err
+	}
+	httpPath := getParam(params, "path", "HTTPURL_PATH")
+	if len(httpPath) < 1 {
+		log.Warn("[HTTPURL] no path found in path parameter or HTTPURL_PATH environment variable.")
 	}
-	httpPortEnv := os.Getenv("HTTPURL_PORT")
-	if len(httpPortEnv) < 1 {
-		log.Info("[HTTPURL] no port found in HTTPURL_PORT environment variable.")
+	httpPort := getParam(params, "port", "HTTPURL_PORT")
+	if len(httpPort) < 1 {
+		log.Info("[HTTPURL] no port found in port parameter or HTTPURL_PORT environment variable.")
 	}
-	log.Infof("[HTTPURL] path set to: %s, port set to: %s", httpPathEnv, httpPortEnv)
-	ctx.Filters[types.HttpUrlFilter] = httpUrl{httpPathEnv, httpPortEnv}
+	log.Infof("[HTTPURL] path set to: %s, port set to: %s", httpPath, httpPort)
+	return httpUrl{httpPath, httpPort}, nil
 }
 
 func (f httpUrl) Execute(items []types.CloudItem) []types.CloudItem {
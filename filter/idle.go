@@ -1,34 +1,87 @@
 package operation
 
 import (
-	"context"
 	"fmt"
-	"os"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
 	log "github.com/sirupsen/logrus"
 
-	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spenczar/tdigest"
-	"google.golang.org/api/iterator"
-	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
 )
 
 func init() {
-	ctx.Filters[types.IdleFilter] = idle{}
+	ctx.Filters[types.IdleFilter] = newIdle
 }
 
-type op func(float64) float64
+// Define what is an Idle item here if no policy is given, an item is idle if all of its metrics are below the limit
+// GCP: CPU utilization is less than 0.15 vCPUs for 95% of VM runtime(30 days).
+// AWS: CPU utilization is less than 15% and the network traffic is less than 5 MB per hour in both directions for
+// 95% of the hours of the VM runtime(30 days).
+// AZURE: same as AWS, measured by the Percentage CPU and the Network In/Out Total metrics of Azure Monitor.
+// Databases are idle if they have no connections and their CPU utilization is less than 5% for 95% of the hours,
+// Dataproc and EMR clusters are idle if no YARN memory is pending (Dataproc) or reserved for pending containers (EMR)
+// and less than 5% (Dataproc) or none (EMR) of it is allocated for 95% of the hours. Kubernetes clusters do not
+// publish these metrics, so the cluster metrics are scoped by the type of the clusters.
+// Vertex AI Workbench notebooks are idle like the GCP instances, SageMaker notebook instances do not publish metrics.
+var defaultIdlePolicy = &types.IdlePolicy{
+	Lookback:    30 * 24 * time.Hour,
+	Combination: types.AndCombination,
+	Metrics: map[types.CloudType][]*types.IdleMetric{
+		types.GCP: {
+			{Type: "instance", Threshold: 0.15, Percentile: 0.95, Name: "compute.googleapis.com/instance/cpu/utilization"},
+			{Type: "database", Threshold: 1, Percentile: 0.95, Name: "cloudsql.googleapis.com/database/network/connections", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: "database", Threshold: 0.05, Percentile: 0.95, Name: "cloudsql.googleapis.com/database/cpu/utilization", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: types.DataprocCluster, Threshold: 1, Percentile: 0.95, Name: "dataproc.googleapis.com/cluster/yarn/pending_memory_size", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: types.DataprocCluster, Threshold: 0.05, Percentile: 0.95, Name: "dataproc.googleapis.com/cluster/yarn/allocated_memory_percentage", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: "notebook", Threshold: 0.15, Percentile: 0.95, Name: "compute.googleapis.com/instance/cpu/utilization"},
+		},
+		types.AWS: {
+			{Type: "instance", Threshold: 15, Percentile: 0.95, Name: "CPUUtilization", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: "instance", Threshold: 5 * 1024 * 1024, Percentile: 0.95, Name: "NetworkIn", Statistic: types.SumStatistic, Period: time.Hour},
+			{Type: "instance", Threshold: 5 * 1024 * 1024, Percentile: 0.95, Name: "NetworkOut", Statistic: types.SumStatistic, Period: time.Hour},
+			{Type: "database", Threshold: 1, Percentile: 0.95, Name: "DatabaseConnections", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: "database", Threshold: 5, Percentile: 0.95, Name: "CPUUtilization", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: types.EmrCluster, Threshold: 1, Percentile: 0.95, Name: "MemoryReservedMB", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: types.EmrCluster, Threshold: 1, Percentile: 0.95, Name: "MemoryAllocatedMB", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: types.NatGateway, Threshold: 1024 * 1024, Percentile: 0.95, Name: "BytesOutToDestination", Statistic: types.SumStatistic, Period: time.Hour},
+			{Type: types.NatGateway, Threshold: 1024 * 1024, Percentile: 0.95, Name: "BytesInFromDestination", Statistic: types.SumStatistic, Period: time.Hour},
+			{Type: types.VpcEndpoint, Threshold: 1024 * 1024, Percentile: 0.95, Name: "BytesProcessed", Statistic: types.SumStatistic, Period: time.Hour},
+		},
+		types.AZURE: {
+			{Type: "instance", Threshold: 15, Percentile: 0.95, Name: "Percentage CPU", Statistic: types.AverageStatistic, Period: time.Hour},
+			{Type: "instance", Threshold: 5 * 1024 * 1024, Percentile: 0.95, Name: "Network In Total", Statistic: types.SumStatistic, Period: time.Hour},
+			{Type: "instance", Threshold: 5 * 1024 * 1024, Percentile: 0.95, Name: "Network Out Total", Statistic: types.SumStatistic, Period: time.Hour},
+		},
+	},
+}
 
-type IdleMetric struct {
-	metric     string
-	percentile float64
-	limit      float64
-	measured   float64
-	operation  op
+type idle struct {
+	policy          *types.IdlePolicy
+	getMetricValues func(types.CloudItem, types.MetricQuery) ([]float64, error)
+}
+
+// newIdle creates the filter from the idle policy YAML given by the 'policy' parameter, or the default policy
+func newIdle(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "policy"); err != nil {
+		return nil, err
+	}
+	location := getParam(params, "policy", "IDLE_POLICY")
+	if len(location) == 0 {
+		return idle{defaultIdlePolicy, getProviderMetricValues}, nil
+	}
+	policy, err := utils.LoadIdlePolicy(location)
+	if err != nil {
+		log.Errorf("[idle] Failed to load the idle policy %s, err: %s", location, err)
+		return nil, err
+	}
+	log.Infof("[idle] idle policy loaded from: %s", location)
+	return idle{policy, getProviderMetricValues}, nil
 }
 
 //get percentile
@@ -42,86 +95,77 @@ func getPercentile(datapoints []float64, percentile float64) float64 {
 	return td.Quantile(percentile)
 }
 
-//return slice of monitored stats for a specific metric/instance (one month duration)
-func getTimeSeriesValue(projectID, metric, instance string) ([]float64, error) {
-
-	var valueType string
-	rstats := make([]float64, 0)
-	ctx := context.Background()
-	client, err := monitoring.NewMetricClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer client.Close()
+// formatMetricValue rounds the value to 4 decimals without exponent, e.g. 0.023499 to 0.0235 and 5242880 to 5242880
+func formatMetricValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10000)/10000, 'f', -1, 64)
+}
 
-	req := &monitoringpb.ListTimeSeriesRequest{
-		Name:   "projects/" + projectID,
-		Filter: fmt.Sprintf("metric.type = \"%s\" AND metric.label.instance_name = \"%s\" ", metric, instance),
-		Interval: &monitoringpb.TimeInterval{
-			StartTime: &timestamp.Timestamp{
-				Seconds: time.Now().Add(-30 * 24 * time.Hour).Unix(),
-			},
-			EndTime: &timestamp.Timestamp{
-				Seconds: time.Now().Unix(),
-			},
-		},
-	}
-	iter := client.ListTimeSeries(ctx, req)
-	for {
-		resp, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		valueType = resp.ValueType.String()
-		for _, point := range resp.Points {
-			if valueType == "INT64" {
-				rstats = append(rstats, float64(point.Value.GetInt64Value()))
-			} else {
-				rstats = append(rstats, point.Value.GetDoubleValue())
-			}
-			rstats = append(rstats, point.Value.GetDoubleValue())
-		}
+// getIdleTypes returns the types the metrics of the item are configured for, the clusters by their own type too
+func getIdleTypes(item types.CloudItem) []string {
+	if cluster, ok := item.GetItem().(types.Cluster); ok {
+		return []string{item.GetType(), cluster.Type}
 	}
-	return rstats, nil
+	return []string{item.GetType()}
 }
 
-func id(x float64) float64 {
-	return x
+func getProviderMetricValues(item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	return ctx.CloudProviders[item.GetCloudType()]().GetMetricValues(item, query)
 }
 
-// Define what is an Idle instance here
-// CPU utilization is less than 0.15 vCPUs for 95% of VM runtime(30 days).
-// should return True if instance is idle.
-func isIdleFiltered(projectID, instance string) bool {
-	idleMetrics := map[string]IdleMetric{
-		"usage": {limit: 0.15, percentile: 0.95, metric: "compute.googleapis.com/instance/cpu/utilization", operation: id},
+// should return True if item is idle. The measured values are stored in the metadata of idle items.
+func (f idle) isIdleFiltered(item types.CloudItem) bool {
+	metrics := f.policy.GetMetrics(item.GetCloudType(), getIdleTypes(item)...)
+	if len(metrics) == 0 {
+		log.Debugf("[idle] Skipping %s %s because idle detection is not configured on %s", item.GetType(), item.GetName(), item.GetCloudType())
+		return false
 	}
-	for _key, idleMetric := range idleMetrics {
-		stats, err := getTimeSeriesValue(projectID, idleMetric.metric, instance)
+	orCombination := f.policy.Combination == types.OrCombination
+	now := time.Now()
+	var measurements []string
+	isIdle := false
+	for _, idleMetric := range metrics {
+		stats, err := f.getMetricValues(item, types.MetricQuery{
+			Name:      idleMetric.Name,
+			Statistic: idleMetric.Statistic,
+			Period:    idleMetric.Period,
+			Start:     now.Add(-f.policy.Lookback),
+			End:       now,
+		})
 		if err != nil {
-			log.Errorln(err)
+			log.Errorf("[idle] Failed to fetch metric %s of item %s, err: %s", idleMetric.Name, item.GetName(), err)
+		} else if len(stats) == 0 {
+			log.Infof("[idle] Skipping metric %s of item %s because it has no datapoints", idleMetric.Name, item.GetName())
+		} else {
+			measured := getPercentile(stats, idleMetric.Percentile)
+			filtered := measured < idleMetric.Threshold
+			log.Infof("[idle] metric = %s ,instance = %s ,percentile = %f, limit = %f, measured = %f filtered = %t", idleMetric.Name, item.GetName(), idleMetric.Percentile, idleMetric.Threshold, measured, filtered)
+			if filtered {
+				measurements = append(measurements, fmt.Sprintf("%s p%g=%s < %s", idleMetric.Name, idleMetric.Percentile*100, formatMetricValue(measured), formatMetricValue(idleMetric.Threshold)))
+				isIdle = true
+				if orCombination {
+					break
+				}
+				continue
+			}
+		}
+		// a metric that is not below the threshold or cannot be measured decides if all of them must be below it
+		if !orCombination {
+			return false
 		}
-		copyMetric := idleMetrics[_key]
-		copyMetric.measured = copyMetric.operation(getPercentile(stats, copyMetric.percentile))
-		idleMetrics[_key] = copyMetric
-		log.Printf("metric = %s ,instance = %s ,percentile = %f, limit = %f, measured = %f filtered = %t\n", _key, instance, idleMetrics[_key].percentile, idleMetrics[_key].limit, idleMetrics[_key].measured, idleMetrics[_key].measured < idleMetrics[_key].limit)
 	}
-	log.Printf("%f,%f", idleMetrics["usage"].measured, idleMetrics["usage"].limit)
-	return idleMetrics["usage"].measured < idleMetrics["usage"].limit
-}
-
-type idle struct {
+	if isIdle && !types.SetMetadata(item, types.IdleMetricsKey, strings.Join(measurements, ", ")) {
+		log.Infof("[idle] %s %s is idle: %s", item.GetType(), item.GetName(), strings.Join(measurements, ", "))
+	}
+	return isIdle
 }
 
 func (f idle) Execute(items []types.CloudItem) []types.CloudItem {
 	log.Debugf("[idle] Filtering items (%d): [%s]", len(items), items)
 	return filter("idle", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
-		if item.GetCreated().After(time.Now().Add(-30 * 24 * time.Hour)) {
-			log.Printf("[idle] Skipping item %s because it was created less than 30 days", item.GetName())
+		if item.GetCreated().After(time.Now().Add(-f.policy.Lookback)) {
+			log.Printf("[idle] Skipping item %s because it was created less than %s ago", item.GetName(), f.policy.Lookback)
 			return false
-		} else {
-			filtered := isIdleFiltered(os.Getenv("GOOGLE_PROJECT_ID"), item.GetName())
-			return filtered
 		}
+		return f.isIdleFiltered(item)
 	})
 }
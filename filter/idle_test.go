@@ -0,0 +1,171 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleInit(t *testing.T) {
+	assert.NotNil(t, ctx.Filters[types.IdleFilter])
+}
+
+func newIdleTestMetricValues(queries *[]types.MetricQuery) func(types.CloudItem, types.MetricQuery) ([]float64, error) {
+	return func(item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+		*queries = append(*queries, query)
+		switch item.GetName() {
+		case "idle":
+			return []float64{0.01, 0.02, 0.01}, nil
+		case "busy network":
+			if query.Name == "NetworkOut" {
+				return []float64{100 * 1024 * 1024}, nil
+			}
+			return []float64{1}, nil
+		case "no data":
+			return []float64{}, nil
+		}
+		return nil, errors.New("metrics are not available")
+	}
+}
+
+func TestIdleFilterAws(t *testing.T) {
+	var queries []types.MetricQuery
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	items := []types.CloudItem{
+		&types.Instance{CloudType: types.AWS, Name: "idle", Created: old},
+		&types.Instance{CloudType: types.AWS, Name: "busy network", Created: old},
+		&types.Instance{CloudType: types.AWS, Name: "no data", Created: old},
+		&types.Instance{CloudType: types.AWS, Name: "error", Created: old},
+		&types.Instance{CloudType: types.AWS, Name: "new", Created: time.Now()},
+	}
+
+	filteredItems := idle{defaultIdlePolicy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, []string{"idle"}, getItemNames(filteredItems))
+	assert.Equal(t, types.AWS, filteredItems[0].GetCloudType())
+	assert.Equal(t, "CPUUtilization", queries[0].Name)
+	assert.Equal(t, types.AverageStatistic, queries[0].Statistic)
+	assert.Equal(t, time.Hour, queries[0].Period)
+	assert.Equal(t, defaultIdlePolicy.Lookback, queries[0].End.Sub(queries[0].Start))
+	assert.Equal(t, "CPUUtilization p95=0.0235 < 15, NetworkIn p95=0.0235 < 5242880, NetworkOut p95=0.0235 < 5242880", filteredItems[0].(*types.Instance).Metadata[types.IdleMetricsKey])
+}
+
+func TestIdleFilterGcp(t *testing.T) {
+	var queries []types.MetricQuery
+	items := []types.CloudItem{
+		&types.Instance{CloudType: types.GCP, Name: "idle", Created: time.Now().Add(-60 * 24 * time.Hour)},
+	}
+
+	filteredItems := idle{defaultIdlePolicy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, []string{"idle"}, getItemNames(filteredItems))
+	assert.Equal(t, 1, len(queries))
+	assert.Equal(t, "compute.googleapis.com/instance/cpu/utilization", queries[0].Name)
+}
+
+func TestIdleFilterAzure(t *testing.T) {
+	var queries []types.MetricQuery
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	items := []types.CloudItem{
+		&types.Instance{CloudType: types.AZURE, Name: "idle", Created: old},
+		&types.Instance{CloudType: types.AZURE, Name: "error", Created: old},
+	}
+
+	filteredItems := idle{defaultIdlePolicy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, []string{"idle"}, getItemNames(filteredItems))
+	assert.Equal(t, 4, len(queries))
+	assert.Equal(t, "Percentage CPU", queries[0].Name)
+	assert.Equal(t, types.AverageStatistic, queries[0].Statistic)
+	assert.Equal(t, "Network Out Total", queries[2].Name)
+	assert.Equal(t, types.SumStatistic, queries[2].Statistic)
+}
+
+func TestIdleFilterOrCombination(t *testing.T) {
+	var queries []types.MetricQuery
+	policy := &types.IdlePolicy{
+		Lookback:    24 * time.Hour,
+		Combination: types.OrCombination,
+		Metrics: map[types.CloudType][]*types.IdleMetric{
+			types.AWS: {
+				{Name: "NetworkOut", Type: "instance", Statistic: types.SumStatistic, Percentile: 0.5, Threshold: 1024},
+				{Name: "GPUUtilization", Type: "instance", Statistic: types.AverageStatistic, Percentile: 0.5, Threshold: 5},
+			},
+		},
+	}
+	items := []types.CloudItem{
+		&types.Instance{CloudType: types.AWS, Name: "busy network", Created: time.Now().Add(-48 * time.Hour)},
+		&types.Instance{CloudType: types.AWS, Name: "error", Created: time.Now().Add(-48 * time.Hour)},
+		&types.Instance{CloudType: types.AWS, Name: "idle", Created: time.Now().Add(-12 * time.Hour)},
+	}
+
+	filteredItems := idle{policy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, []string{"busy network"}, getItemNames(filteredItems))
+	assert.Equal(t, "GPUUtilization p50=1 < 5", filteredItems[0].(*types.Instance).Metadata[types.IdleMetricsKey])
+	assert.Equal(t, 4, len(queries))
+	assert.Equal(t, 24*time.Hour, queries[0].End.Sub(queries[0].Start))
+}
+
+func TestNewIdleDefaultPolicy(t *testing.T) {
+	filter, err := newIdle(types.FilterParams{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, defaultIdlePolicy, filter.(idle).policy)
+}
+
+func TestNewIdlePolicy(t *testing.T) {
+	filter, err := newIdle(types.FilterParams{"policy": "../utils/testdata/idlePolicy.yml"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, types.OrCombination, filter.(idle).policy.Combination)
+}
+
+func TestNewIdleInvalidParams(t *testing.T) {
+	_, err := newIdle(types.FilterParams{"unknown": "x"})
+	assert.NotNil(t, err)
+
+	_, err = newIdle(types.FilterParams{"policy": "missing.yml"})
+	assert.NotNil(t, err)
+}
+
+func TestIdleFilterDatabasesAndClusters(t *testing.T) {
+	var queries []types.MetricQuery
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	items := []types.CloudItem{
+		&types.Database{CloudType: types.AWS, Name: "idle", Created: old},
+		&types.Cluster{CloudType: types.GCP, Type: types.DataprocCluster, Name: "idle", Created: old},
+		&types.Cluster{CloudType: types.GCP, Type: types.GkeCluster, Name: "kubernetes", Created: old},
+		&types.Database{CloudType: types.AZURE, Name: "idle", Created: old},
+	}
+
+	filteredItems := idle{defaultIdlePolicy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, 2, len(filteredItems))
+	assert.Equal(t, "database", filteredItems[0].GetType())
+	assert.Equal(t, "cluster", filteredItems[1].GetType())
+	assert.Equal(t, []string{"DatabaseConnections", "CPUUtilization", "dataproc.googleapis.com/cluster/yarn/pending_memory_size",
+		"dataproc.googleapis.com/cluster/yarn/allocated_memory_percentage"}, []string{queries[0].Name, queries[1].Name, queries[2].Name, queries[3].Name})
+	assert.Equal(t, 4, len(queries))
+	assert.NotEmpty(t, filteredItems[1].(*types.Cluster).Metadata[types.IdleMetricsKey])
+}
+
+func TestIdleFilterGateways(t *testing.T) {
+	var queries []types.MetricQuery
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	items := []types.CloudItem{
+		&types.Gateway{CloudType: types.AWS, Type: types.NatGateway, Name: "idle", Created: old},
+		&types.Gateway{CloudType: types.AWS, Type: types.VpcEndpoint, Name: "error", Created: old},
+	}
+
+	filteredItems := idle{defaultIdlePolicy, newIdleTestMetricValues(&queries)}.Execute(items)
+
+	assert.Equal(t, 1, len(filteredItems))
+	assert.Equal(t, types.NatGateway, filteredItems[0].GetType())
+	assert.Equal(t, []string{"BytesOutToDestination", "BytesInFromDestination", "BytesProcessed"}, []string{queries[0].Name, queries[1].Name, queries[2].Name})
+	assert.NotEmpty(t, filteredItems[0].(*types.Gateway).Metadata[types.IdleMetricsKey])
+}
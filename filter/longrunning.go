@@ -3,8 +3,6 @@ package operation
 import (
 	"time"
 
-	"os"
-
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
 	log "github.com/sirupsen/logrus"
@@ -17,20 +15,20 @@ type longRunning struct {
 }
 
 func init() {
-	runningEnv := os.Getenv("RUNNING_PERIOD")
-	var runningPeriod time.Duration
-	if len(runningEnv) > 0 {
-		duration, err := time.ParseDuration(runningEnv)
-		if err != nil {
-			log.Errorf("[LONGRUNNING] err: %s", err)
-			return
-		}
-		runningPeriod = duration
-	} else {
-		runningPeriod = defaultRunningPeriod
+	ctx.Filters[types.LongRunningFilter] = newLongRunning
+}
+
+func newLongRunning(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "period"); err != nil {
+		return nil, err
+	}
+	runningPeriod, err := getDurationParam(params, "period", "RUNNING_PERIOD", defaultRunningPeriod)
+	if err != nil {
+		log.Errorf("[LONGRUNNING] err: %s", err)
+		return nil, err
 	}
 	log.Infof("[LONGRUNNING] running period set to: %s", runningPeriod)
-	ctx.Filters[types.LongRunningFilter] = longRunning{runningPeriod}
+	return longRunning{runningPeriod}, nil
 }
 
 func (f longRunning) Execute(items []types.CloudItem) []types.CloudItem {
@@ -67,6 +65,33 @@ func (f longRunning) Execute(items []types.CloudItem) []types.CloudItem {
 				log.Debugf("[LONGRUNNING] Filter instance, because it's not in RUNNING state: %s", item.GetName())
 				return false
 			}
+		case types.Address:
+			if item.GetItem().(types.Address).State != types.Unused {
+				log.Debugf("[LONGRUNNING] Filter address, because it's in used state: %s", item.GetName())
+				return false
+			}
+		case types.LoadBalancer:
+			if item.GetItem().(types.LoadBalancer).State != types.Unused {
+				log.Debugf("[LONGRUNNING] Filter load balancer, because it's in used state: %s", item.GetName())
+				return false
+			}
+		case types.Gateway:
+			if item.GetItem().(types.Gateway).State != types.Running {
+				log.Debugf("[LONGRUNNING] Filter gateway, because it's not in RUNNING state: %s", item.GetName())
+				return false
+			}
+		case types.Notebook:
+			notebook := item.GetItem().(types.Notebook)
+			if notebook.State != types.Running {
+				log.Debugf("[LONGRUNNING] Filter notebook, because it's not in RUNNING state: %s", item.GetName())
+				return false
+			}
+			// notebooks are modified when they are started, so they are running since their last modification
+			match := notebook.LastModified.Add(f.runningPeriod).Before(now)
+			log.Debugf("[LONGRUNNING] %s: %s match: %v", item.GetType(), item.GetName(), match)
+			return match
+		case types.Snapshot, types.ContainerImage, types.Function, types.Network:
+			// snapshots, container images, functions and networks have no running state, only their age matters
 		default:
 			log.Fatalf("[LONGRUNNING] Filter does not apply for cloud item: %s", item.GetName())
 			return true
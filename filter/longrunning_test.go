@@ -34,3 +34,70 @@ func TestLongRunningFilter(t *testing.T) {
 
 	assert.Equal(t, 1, len(filteredItems))
 }
+
+func TestLongRunningFilterSnapshot(t *testing.T) {
+	now := time.Now()
+	items := []types.CloudItem{
+		&types.Snapshot{CloudType: types.AWS, Name: "new", Created: now, State: types.Orphaned},
+		&types.Snapshot{CloudType: types.AWS, Name: "old", Created: now.Add(-defaultRunningPeriod).Add(-1 * time.Second), State: types.Available},
+	}
+
+	filteredItems := longRunning{defaultRunningPeriod}.Execute(items)
+
+	assert.Equal(t, []string{"old"}, getItemNames(filteredItems))
+}
+
+func TestLongRunningFilterContainerImage(t *testing.T) {
+	now := time.Now()
+	items := []types.CloudItem{
+		&types.ContainerImage{CloudType: types.AWS, Name: "new", Created: now},
+		&types.ContainerImage{CloudType: types.AWS, Name: "old", Created: now.Add(-defaultRunningPeriod).Add(-1 * time.Second)},
+	}
+
+	filteredItems := longRunning{defaultRunningPeriod}.Execute(items)
+
+	assert.Equal(t, []string{"old"}, getItemNames(filteredItems))
+}
+
+func TestLongRunningFilterFunctionAndNetwork(t *testing.T) {
+	now := time.Now()
+	items := []types.CloudItem{
+		&types.Function{CloudType: types.AWS, Name: "new function", Created: now},
+		&types.Function{CloudType: types.AWS, Name: "old function", Created: now.Add(-defaultRunningPeriod).Add(-1 * time.Second), State: types.Unused},
+		&types.Network{CloudType: types.GCP, Name: "new network", Created: now, State: types.Running},
+		&types.Network{CloudType: types.GCP, Name: "old network", Created: now.Add(-defaultRunningPeriod).Add(-1 * time.Second), State: types.Running},
+	}
+
+	filteredItems := longRunning{defaultRunningPeriod}.Execute(items)
+
+	assert.Equal(t, []string{"old function", "old network"}, getItemNames(filteredItems))
+}
+
+func TestLongRunningFilterNotebook(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-defaultRunningPeriod).Add(-1 * time.Second)
+	items := []types.CloudItem{
+		&types.Notebook{CloudType: types.AWS, Name: "restarted", Created: old, LastModified: now, State: types.Running},
+		&types.Notebook{CloudType: types.AWS, Name: "running", Created: old, LastModified: old, State: types.Running},
+		&types.Notebook{CloudType: types.AWS, Name: "stopped", Created: old, LastModified: old, State: types.Stopped},
+	}
+
+	filteredItems := longRunning{defaultRunningPeriod}.Execute(items)
+
+	assert.Equal(t, []string{"running"}, getItemNames(filteredItems))
+}
+
+func TestNewLongRunningWithPeriodParam(t *testing.T) {
+	filter, err := newLongRunning(types.FilterParams{"period": "6h"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 6*time.Hour, filter.(longRunning).runningPeriod)
+}
+
+func TestNewLongRunningWithInvalidParams(t *testing.T) {
+	_, err := newLongRunning(types.FilterParams{"period": "six hours"})
+	assert.NotNil(t, err)
+
+	_, err = newLongRunning(types.FilterParams{"unknown": "6h"})
+	assert.NotNil(t, err)
+}
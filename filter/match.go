@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.MatchFilter] = match{}
+	ctx.Filters[types.MatchFilter] = noParams(match{})
 }
 
 type match struct {
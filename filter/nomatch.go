@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.NoMatchFilter] = noMatch{}
+	ctx.Filters[types.NoMatchFilter] = noParams(noMatch{})
 }
 
 type noMatch struct {
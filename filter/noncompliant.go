@@ -0,0 +1,58 @@
+package operation
+
+import (
+	"errors"
+	"strings"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type noncompliant struct {
+	policy *types.TagPolicy
+}
+
+func init() {
+	ctx.Filters[types.NoncompliantFilter] = newNoncompliant
+}
+
+// newNoncompliant creates the filter from the tag policy YAML given by the 'policy' parameter
+func newNoncompliant(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "policy"); err != nil {
+		return nil, err
+	}
+	location := getParam(params, "policy", "TAG_POLICY")
+	if len(location) == 0 {
+		return nil, errors.New("the location of the tag policy is not set")
+	}
+	policy, err := utils.LoadTagPolicy(location)
+	if err != nil {
+		log.Errorf("[NONCOMPLIANT] Failed to load the tag policy %s, err: %s", location, err)
+		return nil, err
+	}
+	log.Infof("[NONCOMPLIANT] tag policy loaded from: %s", location)
+	return noncompliant{policy}, nil
+}
+
+// Execute returns the items violating the tag policy, the violations are stored in the metadata of the items. The
+// access keys cannot be tagged, so they never violate the policy.
+func (f noncompliant) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[NONCOMPLIANT] Filtering items (%d): [%s]", len(items), items)
+	return filter("NONCOMPLIANT", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
+		if _, ok := item.GetItem().(types.Access); ok {
+			log.Debugf("[NONCOMPLIANT] %s: %s cannot be tagged", item.GetType(), item.GetName())
+			return false
+		}
+		violations := f.policy.Violations(item)
+		log.Debugf("[NONCOMPLIANT] %s: %s violations: %s match: %v", item.GetType(), item.GetName(), violations, len(violations) > 0)
+		if len(violations) == 0 {
+			return false
+		}
+		if !types.SetMetadata(item, types.TagPolicyViolationsKey, strings.Join(violations, ", ")) {
+			log.Infof("[NONCOMPLIANT] %s %s violates the tag policy: %s", item.GetType(), item.GetName(), strings.Join(violations, ", "))
+		}
+		return true
+	})
+}
@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const tagPolicyLocation = "../utils/testdata/tagPolicy.yml"
+
+func TestNoncompliantInit(t *testing.T) {
+	assert.NotNil(t, ctx.Filters[types.NoncompliantFilter])
+}
+
+func TestNoncompliantWithoutPolicy(t *testing.T) {
+	_, err := newNoncompliant(types.FilterParams{})
+
+	assert.NotNil(t, err)
+}
+
+func TestNoncompliantFilter(t *testing.T) {
+	compliant := &types.Instance{CloudType: types.AWS, Name: "compliant", Tags: types.Tags{"owner": "owner", "cost-center": "cc-1234", "env": "dev"}}
+	missing := &types.Instance{CloudType: types.AWS, Name: "missing", Tags: types.Tags{"owner": "owner", "env": "prod"}}
+	invalid := &types.Instance{CloudType: types.AWS, Name: "invalid", Tags: types.Tags{"owner": "owner", "cost-center": "1234", "env": "staging"}}
+	gcpDisk := &types.Disk{CloudType: types.GCP, Name: "gcp disk", Tags: types.Tags{"owner": "owner"}}
+	azure := &types.Instance{CloudType: types.AZURE, Name: "azure", Tags: types.Tags{"owner": "owner", "cost-center": "cc-1234", "env": "dev"}}
+	ignored := &types.Instance{CloudType: types.AWS, Name: "ignored", Tags: types.Tags{ctx.IgnoreLabel: "true"}}
+	access := &types.Access{CloudType: types.AWS, Name: "access"}
+	filter, err := newNoncompliant(types.FilterParams{"policy": tagPolicyLocation})
+	assert.Nil(t, err)
+
+	filteredItems := filter.Execute([]types.CloudItem{compliant, missing, invalid, gcpDisk, azure, ignored, access})
+
+	assert.Equal(t, []string{"missing", "invalid", "azure"}, getItemNames(filteredItems))
+	assert.Equal(t, "missing tag: cost-center", missing.Metadata[types.TagPolicyViolationsKey])
+	assert.Equal(t, "invalid value of tag cost-center: 1234, invalid value of tag env: staging", invalid.Metadata[types.TagPolicyViolationsKey])
+	assert.Equal(t, "invalid value of tag env: dev", azure.Metadata[types.TagPolicyViolationsKey])
+	assert.Empty(t, compliant.Metadata)
+}
@@ -1,7 +1,6 @@
 package operation
 
 import (
-	"os"
 	"time"
 
 	ctx "github.com/blentz/cloud-haunter/context"
@@ -16,27 +15,31 @@ type oldAccess struct {
 }
 
 func init() {
-	availableEnv := os.Getenv("ACCESS_AVAILABLE_PERIOD")
-	var availablePeriod time.Duration
-	if len(availableEnv) > 0 {
-		duration, err := time.ParseDuration(availableEnv)
-		if err != nil {
-			log.Errorf("[OLDACCESS] err: %s", err)
-			return
-		}
-		availablePeriod = duration
-	} else {
-		availablePeriod = defaultAvailablePeriod
+	ctx.Filters[types.OldAccessFilter] = newOldAccess
+}
+
+func newOldAccess(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "period"); err != nil {
+		return nil, err
+	}
+	availablePeriod, err := getDurationParam(params, "period", "ACCESS_AVAILABLE_PERIOD", defaultAvailablePeriod)
+	if err != nil {
+		log.Errorf("[OLDACCESS] err: %s", err)
+		return nil, err
 	}
 	log.Infof("[OLDACCESS] running period set to: %s", availablePeriod)
-	ctx.Filters[types.OldAccessFilter] = oldAccess{availablePeriod}
+	return oldAccess{availablePeriod}, nil
 }
 
 func (f oldAccess) Execute(items []types.CloudItem) []types.CloudItem {
 	log.Debugf("[OLDACCESS] Filtering items (%d): [%s]", len(items), items)
 	return filter("OLDACCESS", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
-		switch item.GetItem().(type) {
+		switch access := item.GetItem().(type) {
 		case types.Access:
+			if access.State == types.Disabled && !ctx.DisabledAccessesIncluded {
+				log.Debugf("[OLDACCESS] Access: %s is already disabled", item.GetName())
+				return false
+			}
 			match := item.GetCreated().Add(f.availablePeriod).Before(time.Now())
 			log.Debugf("[OLDACCESS] Access: %s match: %v", item.GetName(), match)
 			return match
@@ -32,3 +32,21 @@ func TestOldAccessFilter(t *testing.T) {
 
 	assert.Equal(t, 1, len(filteredItems))
 }
+
+func TestOldAccessFilterSkipsDisabled(t *testing.T) {
+	items := []types.CloudItem{
+		&types.Access{
+			CloudType: types.AWS,
+			Name:      "disabled",
+			Created:   time.Now().Add(-2 * defaultAvailablePeriod),
+			State:     types.Disabled,
+		},
+	}
+
+	assert.Equal(t, 0, len(oldAccess{defaultAvailablePeriod}.Execute(items)))
+
+	ctx.DisabledAccessesIncluded = true
+	defer func() { ctx.DisabledAccessesIncluded = false }()
+
+	assert.Equal(t, 1, len(oldAccess{defaultAvailablePeriod}.Execute(items)))
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.OwnerlessFilter] = ownerless{}
+	ctx.Filters[types.OwnerlessFilter] = noParams(ownerless{})
 }
 
 type ownerless struct {
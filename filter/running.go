@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.RunningFilter] = running{}
+	ctx.Filters[types.RunningFilter] = noParams(running{})
 }
 
 type running struct {
@@ -42,6 +42,11 @@ func (f running) Execute(items []types.CloudItem) []types.CloudItem {
 				log.Debugf("[RUNNING] Filter cluster, because it's not in RUNNING state: %s", item.GetName())
 				return false
 			}
+		case types.Notebook:
+			if item.GetItem().(types.Notebook).State != types.Running {
+				log.Debugf("[RUNNING] Filter notebook, because it's not in RUNNING state: %s", item.GetName())
+				return false
+			}
 		default:
 			log.Fatalf("[RUNNING] Filter does not apply for cloud item: %s", item.GetName())
 		}
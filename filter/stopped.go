@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.StoppedFilter] = stopped{}
+	ctx.Filters[types.StoppedFilter] = noParams(stopped{})
 }
 
 type stopped struct {
@@ -27,6 +27,11 @@ func (f stopped) Execute(items []types.CloudItem) []types.CloudItem {
 				log.Debugf("[RUNNING] Filter cluster, because it's not in STOPPED state: %s", item.GetName())
 				return false
 			}
+		case types.Notebook:
+			if item.GetItem().(types.Notebook).State != types.Stopped {
+				log.Debugf("[STOPPED] Filter notebook, because it's not in STOPPED state: %s", item.GetName())
+				return false
+			}
 		default:
 			log.Fatalf("[STOPPED] Filter does not apply for cloud item: %s", item.GetName())
 		}
@@ -2,7 +2,6 @@ package operation
 
 import (
 	"encoding/json"
-	"os"
 	"time"
 
 	ctx "github.com/blentz/cloud-haunter/context"
@@ -18,17 +17,23 @@ type tamrLicenseInputs struct {
 }
 
 func init() {
-	// initialize new FilterType objects
-	httpPathEnv := os.Getenv("HTTPURL_PATH")
-	if len(httpPathEnv) < 1 {
-		log.Warn("[TAMR-LICENSE] no path found in HTTPURL_PATH environment variable.")
+	ctx.Filters[types.TamrLicenseFilter] = newTamrLicenseInputs
+}
+
+func newTamrLicenseInputs(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "path", "port"); err != nil {
+		return nil, err
+	}
+	httpPath := getParam(params, "path", "HTTPURL_PATH")
+	if len(httpPath) < 1 {
+		log.Warn("[TAMR-LICENSE] no path found in path parameter or HTTPURL_PATH environment variable.")
 	}
-	httpPortEnv := os.Getenv("HTTPURL_PORT")
-	if len(httpPortEnv) < 1 {
-		log.Info("[TAMR-LICENSE] no port found in HTTPURL_PORT environment variable.")
+	httpPort := getParam(params, "port", "HTTPURL_PORT")
+	if len(httpPort) < 1 {
+		log.Info("[TAMR-LICENSE] no port found in port parameter or HTTPURL_PORT environment variable.")
 	}
-	log.Infof("[TAMR-LICENSE] path set to: %s, port set to: %s", httpPathEnv, httpPortEnv)
-	ctx.Filters[types.TamrLicenseFilter] = tamrLicenseInputs{httpPathEnv, httpPortEnv}
+	log.Infof("[TAMR-LICENSE] path set to: %s, port set to: %s", httpPath, httpPort)
+	return tamrLicenseInputs{httpPath, httpPort}, nil
 }
 
 func (f tamrLicenseInputs) Execute(items []types.CloudItem) []types.CloudItem {
@@ -3,7 +3,6 @@ package operation
 import (
 	"encoding/json"
 	semver "golang.org/x/mod/semver"
-	"os"
 
 	ctx "github.com/blentz/cloud-haunter/context"
 	"github.com/blentz/cloud-haunter/types"
@@ -32,17 +31,23 @@ type tamrVersionInputs struct {
 }
 
 func init() {
-	// initialize new FilterType objects
-	httpPathEnv := os.Getenv("HTTPURL_PATH")
-	if len(httpPathEnv) < 1 {
-		log.Warn("[TAMR-VERSION] no path found in HTTPURL_PATH environment variable.")
+	ctx.Filters[types.TamrVersionFilter] = newTamrVersionInputs
+}
+
+func newTamrVersionInputs(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "path", "port"); err != nil {
+		return nil, err
+	}
+	httpPath := getParam(params, "path", "HTTPURL_PATH")
+	if len(httpPath) < 1 {
+		log.Warn("[TAMR-VERSION] no path found in path parameter or HTTPURL_PATH environment variable.")
 	}
-	httpPortEnv := os.Getenv("HTTPURL_PORT")
-	if len(httpPortEnv) < 1 {
-		log.Info("[TAMR-VERSION] no port found in HTTPURL_PORT environment variable.")
+	httpPort := getParam(params, "port", "HTTPURL_PORT")
+	if len(httpPort) < 1 {
+		log.Info("[TAMR-VERSION] no port found in port parameter or HTTPURL_PORT environment variable.")
 	}
-	log.Infof("[TAMR-VERSION] path set to: %s, port set to: %s", httpPathEnv, httpPortEnv)
-	ctx.Filters[types.TamrVersionFilter] = tamrVersionInputs{httpPathEnv, httpPortEnv}
+	log.Infof("[TAMR-VERSION] path set to: %s, port set to: %s", httpPath, httpPort)
+	return tamrVersionInputs{httpPath, httpPort}, nil
 }
 
 // contains checks if a string is present in a slice
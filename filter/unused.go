@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	ctx.Filters[types.UnusedFilter] = unused{}
+	ctx.Filters[types.UnusedFilter] = noParams(unused{})
 }
 
 type unused struct {
@@ -27,6 +27,26 @@ func (f unused) Execute(items []types.CloudItem) []types.CloudItem {
 				log.Debugf("[UNUSED] Filter alert, because it's in use: %s", item.GetName())
 				return false
 			}
+		case types.Address:
+			if item.GetItem().(types.Address).State != types.Unused {
+				log.Debugf("[UNUSED] Filter address, because it's associated: %s", item.GetName())
+				return false
+			}
+		case types.LoadBalancer:
+			if item.GetItem().(types.LoadBalancer).State != types.Unused {
+				log.Debugf("[UNUSED] Filter load balancer, because it has healthy targets: %s", item.GetName())
+				return false
+			}
+		case types.Function:
+			if item.GetItem().(types.Function).State != types.Unused {
+				log.Debugf("[UNUSED] Filter function, because it was invoked: %s", item.GetName())
+				return false
+			}
+		case types.Snapshot:
+			if item.GetItem().(types.Snapshot).State != types.Orphaned {
+				log.Debugf("[UNUSED] Filter snapshot, because its source still exists: %s", item.GetName())
+				return false
+			}
 		default:
 			log.Fatalf("[UNUSED] Filter does not apply for cloud item: %s", item.GetName())
 		}
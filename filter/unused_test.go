@@ -0,0 +1,28 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedFilter(t *testing.T) {
+	items := []types.CloudItem{
+		&types.Disk{CloudType: types.AWS, Name: "unused disk", State: types.Unused},
+		&types.Disk{CloudType: types.AWS, Name: "attached disk", State: types.InUse},
+		&types.Snapshot{CloudType: types.AWS, Name: "orphaned snapshot", State: types.Orphaned},
+		&types.Snapshot{CloudType: types.AWS, Name: "snapshot", State: types.Available},
+		&types.Address{CloudType: types.AWS, Name: "unused address", State: types.Unused},
+		&types.Address{CloudType: types.AWS, Name: "associated address", State: types.InUse},
+		&types.LoadBalancer{CloudType: types.AWS, Name: "unused load balancer", State: types.Unused},
+		&types.LoadBalancer{CloudType: types.AWS, Name: "load balancer", State: types.InUse},
+		&types.Function{CloudType: types.AWS, Name: "unused function", State: types.Unused},
+		&types.Function{CloudType: types.AWS, Name: "function", State: types.InUse},
+		&types.Function{CloudType: types.AWS, Name: "uncounted function", State: types.Unknown},
+	}
+
+	filteredItems := unused{}.Execute(items)
+
+	assert.Equal(t, []string{"unused disk", "orphaned snapshot", "unused address", "unused load balancer", "unused function"}, getItemNames(filteredItems))
+}
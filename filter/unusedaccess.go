@@ -0,0 +1,55 @@
+package operation
+
+import (
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+var defaultUnusedPeriod = 90 * 24 * time.Hour
+
+type unusedAccess struct {
+	unusedPeriod time.Duration
+}
+
+func init() {
+	ctx.Filters[types.UnusedAccessFilter] = newUnusedAccess
+}
+
+func newUnusedAccess(params types.FilterParams) (types.Filter, error) {
+	if err := checkParams(params, "period"); err != nil {
+		return nil, err
+	}
+	unusedPeriod, err := getDurationParam(params, "period", "ACCESS_UNUSED_PERIOD", defaultUnusedPeriod)
+	if err != nil {
+		log.Errorf("[UNUSEDACCESS] err: %s", err)
+		return nil, err
+	}
+	log.Infof("[UNUSEDACCESS] unused period set to: %s", unusedPeriod)
+	return unusedAccess{unusedPeriod}, nil
+}
+
+func (f unusedAccess) Execute(items []types.CloudItem) []types.CloudItem {
+	log.Debugf("[UNUSEDACCESS] Filtering items (%d): [%s]", len(items), items)
+	return filter("UNUSEDACCESS", items, types.ExclusiveFilter, func(item types.CloudItem) bool {
+		switch access := item.GetItem().(type) {
+		case types.Access:
+			if access.State == types.Disabled && !ctx.DisabledAccessesIncluded {
+				log.Debugf("[UNUSEDACCESS] Access: %s is already disabled", item.GetName())
+				return false
+			}
+			if access.UsageUnknown {
+				log.Debugf("[UNUSEDACCESS] Access: %s usage is unknown", item.GetName())
+				return false
+			}
+			match := access.GetLastActivity().Add(f.unusedPeriod).Before(time.Now())
+			log.Debugf("[UNUSEDACCESS] Access: %s last used: %v match: %v", item.GetName(), access.LastUsed, match)
+			return match
+		default:
+			log.Fatalf("[UNUSEDACCESS] Filter does not apply for cloud item: %s", item.GetName())
+		}
+		return true
+	})
+}
@@ -0,0 +1,86 @@
+package operation
+
+import (
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedAccessInit(t *testing.T) {
+	assert.NotNil(t, ctx.Filters[types.UnusedAccessFilter])
+}
+
+func TestUnusedAccessFilter(t *testing.T) {
+	now := time.Now()
+	recently := now.Add(-time.Hour)
+	longAgo := now.Add(-defaultUnusedPeriod).Add(-1 * time.Second)
+	items := []types.CloudItem{
+		&types.Access{
+			CloudType: types.AWS,
+			Name:      "old but used",
+			Created:   now.Add(-2 * defaultUnusedPeriod),
+			LastUsed:  &recently,
+		},
+		&types.Access{
+			CloudType: types.AWS,
+			Name:      "old and idle",
+			Created:   now.Add(-2 * defaultUnusedPeriod),
+			LastUsed:  &longAgo,
+		},
+		&types.Access{
+			CloudType: types.AWS,
+			Name:      "new and never used",
+			Created:   now.Add(-defaultUnusedPeriod).Add(1 * time.Second),
+		},
+		&types.Access{
+			CloudType: types.GCP,
+			Name:      "old and never used",
+			Created:   now.Add(-defaultUnusedPeriod).Add(-1 * time.Second),
+		},
+	}
+
+	filteredItems := unusedAccess{defaultUnusedPeriod}.Execute(items)
+
+	assert.Equal(t, []string{"old and idle", "old and never used"}, getItemNames(filteredItems))
+}
+
+func TestNewUnusedAccessWithPeriodParam(t *testing.T) {
+	filter, err := newUnusedAccess(types.FilterParams{"period": "720h"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 720*time.Hour, filter.(unusedAccess).unusedPeriod)
+}
+
+func TestUnusedAccessFilterSkipsUnknownUsage(t *testing.T) {
+	items := []types.CloudItem{
+		&types.Access{
+			CloudType:    types.GCP,
+			Name:         "unknown usage",
+			Created:      time.Now().Add(-2 * defaultUnusedPeriod),
+			UsageUnknown: true,
+		},
+	}
+
+	assert.Equal(t, 0, len(unusedAccess{defaultUnusedPeriod}.Execute(items)))
+}
+
+func TestUnusedAccessFilterSkipsDisabled(t *testing.T) {
+	items := []types.CloudItem{
+		&types.Access{
+			CloudType: types.AWS,
+			Name:      "disabled",
+			Created:   time.Now().Add(-2 * defaultUnusedPeriod),
+			State:     types.Disabled,
+		},
+	}
+
+	assert.Equal(t, 0, len(unusedAccess{defaultUnusedPeriod}.Execute(items)))
+
+	ctx.DisabledAccessesIncluded = true
+	defer func() { ctx.DisabledAccessesIncluded = false }()
+
+	assert.Equal(t, 1, len(unusedAccess{defaultUnusedPeriod}.Execute(items)))
+}
@@ -0,0 +1,126 @@
+package gcp
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// globalRegion is the region of the global addresses
+const globalRegion = "global"
+
+func (p gcpProvider) GetAddresses() ([]*types.Address, error) {
+	log.Debug("[GCP] Fetching static addresses")
+	var gAddresses []*compute.Address
+	err := p.computeClient.Addresses.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.AddressAggregatedList) error {
+		for _, items := range list.Items {
+			gAddresses = append(gAddresses, items.Addresses...)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the regional static addresses, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.GlobalAddresses.List(p.projectID).Pages(context.Background(), func(list *compute.AddressList) error {
+		gAddresses = append(gAddresses, list.Items...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the global static addresses, err: %s", err.Error())
+		return nil, err
+	}
+	return getAddresses(gAddresses)
+}
+
+// getAddresses converts the external addresses, the internal ones are not billed
+func getAddresses(gAddresses []*compute.Address) ([]*types.Address, error) {
+	log.Debugf("[GCP] Processing static addresses (%d)", len(gAddresses))
+	addresses := make([]*types.Address, 0)
+	for _, gAddress := range gAddresses {
+		if gAddress.AddressType == "INTERNAL" {
+			log.Debugf("[GCP] Skipping internal address: %s", gAddress.Name)
+			continue
+		}
+		creationTimeStamp, err := utils.ConvertTimeRFC3339(gAddress.CreationTimestamp)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of address, err: %s", err.Error())
+			return nil, err
+		}
+		region := globalRegion
+		if len(gAddress.Region) > 0 {
+			region = getZone(gAddress.Region)
+		}
+		addresses = append(addresses, &types.Address{
+			CloudType: types.GCP,
+			ID:        strconv.FormatUint(gAddress.Id, 10),
+			Name:      gAddress.Name,
+			IP:        gAddress.Address,
+			Region:    region,
+			Created:   creationTimeStamp,
+			State:     getAddressStatus(gAddress),
+			Metadata:  map[string]string{"description": gAddress.Description},
+			Tags:      types.Tags{},
+		})
+	}
+	return addresses, nil
+}
+
+func getAddressStatus(gAddress *compute.Address) types.State {
+	switch gAddress.Status {
+	case "RESERVED":
+		return types.Unused
+	case "IN_USE":
+		return types.InUse
+	case "RESERVING":
+		return types.Creating
+	}
+	return types.Unknown
+}
+
+func (p gcpProvider) ReleaseAddresses(addresses *types.AddressContainer) []error {
+	gcpAddresses := addresses.Get(types.GCP)
+	log.Debugf("[GCP] Releasing static addresses: %v", gcpAddresses)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpAddresses))
+	errChan := make(chan error)
+
+	for _, a := range gcpAddresses {
+		go func(address *types.Address) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, static address is not released: %s", address.Name)
+				return
+			}
+			log.Infof("[GCP] Sending request to release static address in region %s: %s", address.Region, address.Name)
+			var err error
+			if address.Region == globalRegion {
+				_, err = p.computeClient.GlobalAddresses.Delete(p.projectID, address.Name).Do()
+			} else {
+				_, err = p.computeClient.Addresses.Delete(p.projectID, address.Region, address.Name).Do()
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}(a)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,35 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestGetAddresses(t *testing.T) {
+	gAddresses := []*compute.Address{
+		{
+			Id:                1,
+			Name:              "reserved",
+			Address:           "1.2.3.4",
+			Status:            "RESERVED",
+			Region:            "https://www.googleapis.com/compute/v1/projects/p/regions/us-west1",
+			CreationTimestamp: "2018-05-25T11:23:23.000-07:00",
+		},
+		{Id: 2, Name: "global", Status: "IN_USE", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		{Id: 3, Name: "internal", AddressType: "INTERNAL", Status: "RESERVED", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+	}
+
+	addresses, err := getAddresses(gAddresses)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(addresses))
+	assert.Equal(t, "1", addresses[0].ID)
+	assert.Equal(t, "1.2.3.4", addresses[0].IP)
+	assert.Equal(t, "us-west1", addresses[0].Region)
+	assert.Equal(t, types.Unused, addresses[0].State)
+	assert.Equal(t, "global", addresses[1].Region)
+	assert.Equal(t, types.InUse, addresses[1].State)
+}
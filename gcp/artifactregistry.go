@@ -0,0 +1,160 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	artifactregistry "google.golang.org/api/artifactregistry/v1"
+)
+
+// GetContainerImages returns the images of the Docker repositories of Artifact Registry. Repositories can only be
+// listed per location, so the locations of the service are listed first.
+func (p gcpProvider) GetContainerImages() ([]*types.ContainerImage, error) {
+	log.Debug("[GCP] Fetching Artifact Registry images")
+	var repositories []*artifactregistry.Repository
+	err := p.artifactRegistryClient.Projects.Locations.List("projects/"+p.projectID).Pages(context.Background(), func(locations *artifactregistry.ListLocationsResponse) error {
+		for _, location := range locations.Locations {
+			err := p.artifactRegistryClient.Projects.Locations.Repositories.List(location.Name).Pages(context.Background(), func(list *artifactregistry.ListRepositoriesResponse) error {
+				for _, repository := range list.Repositories {
+					if repository.Format == "DOCKER" {
+						repositories = append(repositories, repository)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				log.Errorf("[GCP] Failed to fetch the Artifact Registry repositories in location: %s, err: %s", location.LocationId, err.Error())
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the Artifact Registry locations, err: %s", err.Error())
+		return nil, err
+	}
+
+	images := map[string][]*artifactregistry.DockerImage{}
+	for _, repository := range repositories {
+		err := p.artifactRegistryClient.Projects.Locations.Repositories.DockerImages.List(repository.Name).Pages(context.Background(), func(list *artifactregistry.ListDockerImagesResponse) error {
+			images[repository.Name] = append(images[repository.Name], list.DockerImages...)
+			return nil
+		})
+		if err != nil {
+			log.Errorf("[GCP] Failed to fetch the images of Artifact Registry repository: %s, err: %s", repository.Name, err.Error())
+			return nil, err
+		}
+	}
+	return getContainerImages(repositories, images)
+}
+
+// getContainerImages converts the Docker images, the owner is taken from the labels of their repository. An Artifact
+// Registry repository holds many packages, so the repository of the images is the package within it, the way an ECR
+// or ACR repository holds a single one.
+func getContainerImages(repositories []*artifactregistry.Repository, images map[string][]*artifactregistry.DockerImage) ([]*types.ContainerImage, error) {
+	log.Debugf("[GCP] Processing Artifact Registry repositories (%d)", len(repositories))
+	result := make([]*types.ContainerImage, 0)
+	for _, repository := range repositories {
+		for _, image := range images[repository.Name] {
+			uploaded, err := utils.ConvertTimeRFC3339(image.UploadTime)
+			if err != nil {
+				log.Errorf("[GCP] Failed to get the upload time of Artifact Registry image, err: %s", err.Error())
+				return nil, err
+			}
+			pkg, digest := getDockerImagePackage(image.Name)
+			name := pkg + "@" + digest
+			if len(image.Tags) > 0 {
+				name = pkg + ":" + strings.Join(image.Tags, ",")
+			}
+			result = append(result, &types.ContainerImage{
+				ID:         image.Name,
+				Name:       name,
+				Repository: getZone(repository.Name) + "/" + pkg,
+				ImageTags:  image.Tags,
+				Created:    uploaded,
+				Size:       image.ImageSizeBytes,
+				Owner:      repository.Labels[ctx.OwnerLabel],
+				CloudType:  types.GCP,
+				Region:     getLocation(repository.Name),
+				Metadata:   map[string]string{"uri": image.Uri},
+				Tags:       repository.Labels,
+			})
+		}
+	}
+	return result, nil
+}
+
+// getDockerImagePackage returns the unescaped package and the digest from the name of the image,
+// e.g. projects/p/locations/l/repositories/r/dockerImages/team%2Fapp@sha256:e995
+func getDockerImagePackage(name string) (string, string) {
+	image := getZone(name)
+	parts := strings.SplitN(image, "@", 2)
+	if len(parts) != 2 {
+		return image, ""
+	}
+	pkg, err := url.PathUnescape(parts[0])
+	if err != nil {
+		pkg = parts[0]
+	}
+	return pkg, parts[1]
+}
+
+// getDockerImageVersion returns the name of the package version of the image, the Docker images cannot be deleted
+// by their own name
+func getDockerImageVersion(name string) string {
+	repository := name[:strings.LastIndex(name, "/dockerImages/")]
+	image := getZone(name)
+	parts := strings.SplitN(image, "@", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return fmt.Sprintf("%s/packages/%s/versions/%s", repository, parts[0], parts[1])
+}
+
+// CleanupContainerImages deletes the package versions of the outdated images together with their tags
+func (p gcpProvider) CleanupContainerImages(containerImages *types.ContainerImageContainer, retainedImages int) []error {
+	gcpImages := containerImages.GetOutdated(types.GCP, retainedImages)
+	log.Debugf("[GCP] Cleaning up Artifact Registry images, keeping the %d most recent tagged images per repository: %v", retainedImages, gcpImages)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpImages))
+	errChan := make(chan error)
+
+	for _, i := range gcpImages {
+		go func(image *types.ContainerImage) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, Artifact Registry image is not deleted: %s", image.Name)
+				return
+			}
+			version := getDockerImageVersion(image.ID)
+			if len(version) == 0 {
+				errChan <- fmt.Errorf("failed to get the package version of image: %s", image.ID)
+				return
+			}
+			log.Infof("[GCP] Sending request to delete Artifact Registry image in location %s: %s", image.Region, image.Name)
+			if _, err := p.artifactRegistryClient.Projects.Locations.Repositories.Packages.Versions.Delete(version).Force(true).Do(); err != nil {
+				errChan <- err
+			}
+		}(i)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
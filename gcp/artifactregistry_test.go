@@ -0,0 +1,73 @@
+package gcp
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	artifactregistry "google.golang.org/api/artifactregistry/v1"
+)
+
+func TestGetContainerImages(t *testing.T) {
+	repositoryName := "projects/p/locations/us-west1/repositories/repo"
+	repositories := []*artifactregistry.Repository{
+		{Name: repositoryName, Format: "DOCKER", Labels: map[string]string{ctx.OwnerLabel: "owner"}},
+	}
+	images := map[string][]*artifactregistry.DockerImage{
+		repositoryName: {
+			{
+				Name:           repositoryName + "/dockerImages/team%2Fapp@sha256:tagged",
+				Uri:            "us-west1-docker.pkg.dev/p/repo/team/app@sha256:tagged",
+				Tags:           []string{"1.0"},
+				UploadTime:     "2018-05-25T11:23:23+00:00",
+				ImageSizeBytes: 1024,
+			},
+			{
+				Name:       repositoryName + "/dockerImages/team%2Fapp@sha256:untagged",
+				UploadTime: "2018-05-24T11:23:23+00:00",
+			},
+		},
+	}
+
+	result, err := getContainerImages(repositories, images)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result))
+	tagged := result[0]
+	assert.Equal(t, "team/app:1.0", tagged.Name)
+	assert.Equal(t, "repo/team/app", tagged.Repository)
+	assert.Equal(t, "us-west1", tagged.Region)
+	assert.Equal(t, "owner", tagged.Owner)
+	assert.Equal(t, int64(1024), tagged.Size)
+	assert.Equal(t, types.GCP, tagged.CloudType)
+	assert.Equal(t, "team/app@sha256:untagged", result[1].Name)
+}
+
+func TestGetContainerImagesOfPackages(t *testing.T) {
+	repositoryName := "projects/p/locations/us-west1/repositories/repo"
+	repositories := []*artifactregistry.Repository{{Name: repositoryName, Format: "DOCKER"}}
+	images := map[string][]*artifactregistry.DockerImage{
+		repositoryName: {
+			{Name: repositoryName + "/dockerImages/frontend@sha256:new", Tags: []string{"2.0"}, UploadTime: "2018-05-25T11:23:23+00:00"},
+			{Name: repositoryName + "/dockerImages/frontend@sha256:old", Tags: []string{"1.0"}, UploadTime: "2018-05-24T11:23:23+00:00"},
+			{Name: repositoryName + "/dockerImages/backend@sha256:only", Tags: []string{"1.0"}, UploadTime: "2018-05-23T11:23:23+00:00"},
+		},
+	}
+
+	result, err := getContainerImages(repositories, images)
+	assert.Nil(t, err)
+	container := types.NewContainerImageContainer(result)
+	outdated := container.GetOutdated(types.GCP, 1)
+
+	assert.Equal(t, "repo/frontend", result[0].Repository)
+	assert.Equal(t, "repo/backend", result[2].Repository)
+	assert.Equal(t, 1, len(outdated))
+	assert.Equal(t, "frontend:1.0", outdated[0].Name)
+}
+
+func TestGetDockerImageVersion(t *testing.T) {
+	version := getDockerImageVersion("projects/p/locations/us-west1/repositories/repo/dockerImages/team%2Fapp@sha256:e995")
+
+	assert.Equal(t, "projects/p/locations/us-west1/repositories/repo/packages/team%2Fapp/versions/sha256:e995", version)
+}
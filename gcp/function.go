@@ -0,0 +1,158 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	cloudfunctions "google.golang.org/api/cloudfunctions/v2"
+)
+
+const (
+	// gen1ExecutionCount is the invocation metric of the 1st gen functions
+	gen1ExecutionCount = "cloudfunctions.googleapis.com/function/execution_count"
+
+	// gen2RequestCount is the invocation metric of the 2nd gen functions, they are served by Cloud Run
+	gen2RequestCount = "run.googleapis.com/request_count"
+)
+
+// GetFunctions returns the 1st and 2nd gen Cloud Functions with their invocations in the lookback window
+func (p gcpProvider) GetFunctions() ([]*types.Function, error) {
+	log.Debug("[GCP] Fetching Cloud Functions")
+	var gFunctions []*cloudfunctions.Function
+	err := p.functionsClient.Projects.Locations.Functions.List(fmt.Sprintf("projects/%s/locations/-", p.projectID)).Pages(context.Background(), func(list *cloudfunctions.ListFunctionsResponse) error {
+		if len(list.Unreachable) > 0 {
+			log.Warnf("[GCP] Cloud Functions could not be fetched from locations: %s", list.Unreachable)
+		}
+		gFunctions = append(gFunctions, list.Functions...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the Cloud Functions, err: %s", err.Error())
+		return nil, err
+	}
+	end := time.Now()
+	start := end.Add(-utils.GetInvocationLookback())
+	return getFunctions(gFunctions, start, func(function *types.Function) (int64, error) {
+		values, err := p.GetMetricValues(function, types.MetricQuery{Name: getInvocationMetric(function), Statistic: types.SumStatistic, Period: 24 * time.Hour, Start: start, End: end})
+		if err != nil {
+			return 0, err
+		}
+		var invocations float64
+		for _, value := range values {
+			invocations += value
+		}
+		return int64(math.Round(invocations)), nil
+	})
+}
+
+// getFunctions converts the functions, the ones whose invocations cannot be counted are in Unknown state. The API
+// does not return the creation time, so the last update is used.
+func getFunctions(gFunctions []*cloudfunctions.Function, start time.Time, countInvocations func(*types.Function) (int64, error)) ([]*types.Function, error) {
+	log.Debugf("[GCP] Processing Cloud Functions (%d)", len(gFunctions))
+	result := make([]*types.Function, 0)
+	for _, gFunction := range gFunctions {
+		updated, err := utils.ConvertTimeRFC3339(gFunction.UpdateTime)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the update time of Cloud Function, err: %s", err.Error())
+			return nil, err
+		}
+		function := &types.Function{
+			ID:           gFunction.Name,
+			Name:         getZone(gFunction.Name),
+			Type:         types.CloudFunction,
+			Created:      updated,
+			LastModified: updated,
+			State:        types.Unknown,
+			Owner:        gFunction.Labels[ctx.OwnerLabel],
+			CloudType:    types.GCP,
+			Region:       getLocation(gFunction.Name),
+			Metadata:     map[string]string{"environment": gFunction.Environment},
+			Tags:         gFunction.Labels,
+		}
+		if gFunction.BuildConfig != nil {
+			function.Runtime = gFunction.BuildConfig.Runtime
+		}
+		if gFunction.ServiceConfig != nil {
+			function.MemorySize = getMemorySize(gFunction.ServiceConfig.AvailableMemory)
+			function.Metadata["service"] = getZone(gFunction.ServiceConfig.Service)
+			function.Metadata["minInstances"] = strconv.FormatInt(gFunction.ServiceConfig.MinInstanceCount, 10)
+		}
+		if invocations, err := countInvocations(function); err != nil {
+			log.Errorf("[GCP] Failed to count the invocations of Cloud Function: %s, err: %s", function.Name, err.Error())
+		} else {
+			function.Invocations = invocations
+			function.State = types.GetInvocationState(invocations, function.LastModified, start)
+		}
+		result = append(result, function)
+	}
+	return result, nil
+}
+
+func getInvocationMetric(function *types.Function) string {
+	if function.Metadata["environment"] == "GEN_2" {
+		return gen2RequestCount
+	}
+	return gen1ExecutionCount
+}
+
+// getMemorySize returns the memory in MB from the Kubernetes style quantity, e.g. 256M, 512Mi or 1Gi
+func getMemorySize(memory string) int64 {
+	unit := strings.TrimLeft(memory, "0123456789")
+	size, err := strconv.ParseInt(strings.TrimSuffix(memory, unit), 10, 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.TrimSuffix(unit, "i") {
+	case "G":
+		return size * 1024
+	case "k":
+		return size / 1024
+	case "":
+		return size / (1024 * 1024)
+	}
+	return size
+}
+
+func (p gcpProvider) DeleteFunctions(functions *types.FunctionContainer) []error {
+	gcpFunctions := functions.Get(types.GCP)
+	log.Debugf("[GCP] Deleting Cloud Functions: %v", gcpFunctions)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpFunctions))
+	errChan := make(chan error)
+
+	for _, f := range gcpFunctions {
+		go func(function *types.Function) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, Cloud Function is not deleted: %s", function.Name)
+				return
+			}
+			log.Infof("[GCP] Sending request to delete Cloud Function in region %s: %s", function.Region, function.Name)
+			if _, err := p.functionsClient.Projects.Locations.Functions.Delete(function.ID).Do(); err != nil {
+				errChan <- err
+			}
+		}(f)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
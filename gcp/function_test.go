@@ -0,0 +1,59 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	cloudfunctions "google.golang.org/api/cloudfunctions/v2"
+)
+
+func TestGetFunctions(t *testing.T) {
+	gFunctions := []*cloudfunctions.Function{
+		{
+			Name:          "projects/p/locations/us-west1/functions/gen2",
+			Environment:   "GEN_2",
+			UpdateTime:    "2018-05-25T11:23:23+00:00",
+			Labels:        map[string]string{ctx.OwnerLabel: "owner"},
+			BuildConfig:   &cloudfunctions.BuildConfig{Runtime: "go119"},
+			ServiceConfig: &cloudfunctions.ServiceConfig{AvailableMemory: "1Gi", Service: "projects/p/locations/us-west1/services/gen2"},
+		},
+		{
+			Name:        "projects/p/locations/us-west1/functions/uncounted",
+			Environment: "GEN_1",
+			UpdateTime:  "2018-05-25T11:23:23+00:00",
+		},
+	}
+
+	result, err := getFunctions(gFunctions, time.Now().Add(-720*time.Hour), func(function *types.Function) (int64, error) {
+		if function.Name == "uncounted" {
+			return 0, errors.New("error")
+		}
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result))
+	gen2 := result[0]
+	assert.Equal(t, "gen2", gen2.Name)
+	assert.Equal(t, "us-west1", gen2.Region)
+	assert.Equal(t, "go119", gen2.Runtime)
+	assert.Equal(t, int64(1024), gen2.MemorySize)
+	assert.Equal(t, "gen2", gen2.Metadata["service"])
+	assert.Equal(t, "owner", gen2.Owner)
+	assert.Equal(t, types.Unused, gen2.State)
+	assert.Equal(t, gen2RequestCount, getInvocationMetric(gen2))
+	uncounted := result[1]
+	assert.Equal(t, types.Unknown, uncounted.State)
+	assert.Equal(t, gen1ExecutionCount, getInvocationMetric(uncounted))
+}
+
+func TestGetMemorySize(t *testing.T) {
+	assert.Equal(t, int64(256), getMemorySize("256M"))
+	assert.Equal(t, int64(512), getMemorySize("512Mi"))
+	assert.Equal(t, int64(2048), getMemorySize("2G"))
+	assert.Equal(t, int64(0), getMemorySize(""))
+}
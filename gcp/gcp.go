@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -19,11 +20,18 @@ import (
 
 	dataproc "cloud.google.com/go/dataproc/apiv1"
 	"golang.org/x/oauth2/google"
+	artifactregistry "google.golang.org/api/artifactregistry/v1"
+	cloudfunctions "google.golang.org/api/cloudfunctions/v2"
 	compute "google.golang.org/api/compute/v1"
+	container "google.golang.org/api/container/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/iterator"
+	logging "google.golang.org/api/logging/v2"
+	notebooks "google.golang.org/api/notebooks/v2"
 	"google.golang.org/api/option"
+	policyanalyzer "google.golang.org/api/policyanalyzer/v1"
+	redis "google.golang.org/api/redis/v1"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 	dataprocpb "google.golang.org/genproto/googleapis/cloud/dataproc/v1"
 )
@@ -31,11 +39,18 @@ import (
 var provider = gcpProvider{}
 
 type gcpProvider struct {
-	projectID      string
-	computeClient  *compute.Service
-	dataprocClient *dataproc.ClusterControllerClient
-	iamClient      *iam.Service
-	sqlClient      *sqladmin.Service
+	projectID              string
+	computeClient          *compute.Service
+	dataprocClient         *dataproc.ClusterControllerClient
+	iamClient              *iam.Service
+	loggingClient          *logging.Service
+	policyAnalyzerClient   *policyanalyzer.Service
+	sqlClient              *sqladmin.Service
+	containerClient        *container.Service
+	redisClient            *redis.Service
+	notebooksClient        *notebooks.Service
+	artifactRegistryClient *artifactregistry.Service
+	functionsClient        *cloudfunctions.Service
 }
 
 func init() {
@@ -99,11 +114,46 @@ func (p *gcpProvider) init(projectID string, computeHTTPClient *http.Client, iam
 		return errors.New("Failed to initialize iam client, err: " + err.Error())
 	}
 	p.iamClient = iamClient
+	policyAnalyzerClient, err := policyanalyzer.New(iamHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize policy analyzer client, err: " + err.Error())
+	}
+	p.policyAnalyzerClient = policyAnalyzerClient
+	loggingClient, err := logging.New(iamHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize logging client, err: " + err.Error())
+	}
+	p.loggingClient = loggingClient
 	sqlClient, err := sqladmin.New(sqlHTTPClient)
 	if err != nil {
 		return errors.New("Failed to initialize Sql admin client, err: " + err.Error())
 	}
 	p.sqlClient = sqlClient
+	containerClient, err := container.New(computeHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize container client, err: " + err.Error())
+	}
+	p.containerClient = containerClient
+	redisClient, err := redis.New(computeHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize redis client, err: " + err.Error())
+	}
+	p.redisClient = redisClient
+	notebooksClient, err := notebooks.New(computeHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize notebooks client, err: " + err.Error())
+	}
+	p.notebooksClient = notebooksClient
+	artifactRegistryClient, err := artifactregistry.New(computeHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize artifact registry client, err: " + err.Error())
+	}
+	p.artifactRegistryClient = artifactRegistryClient
+	functionsClient, err := cloudfunctions.New(computeHTTPClient)
+	if err != nil {
+		return errors.New("Failed to initialize cloud functions client, err: " + err.Error())
+	}
+	p.functionsClient = functionsClient
 
 	ctx := context.Background()
 	dataprocClient, err := dataproc.NewClusterControllerClient(ctx)
@@ -121,7 +171,12 @@ func (p gcpProvider) GetAccountName() string {
 
 func (p gcpProvider) GetInstances() ([]*types.Instance, error) {
 	log.Debug("[GCP] Fetching instances")
-	return getInstances(p.computeClient.Instances.AggregatedList(p.projectID))
+	instances, err := getInstances(p.computeClient.Instances.AggregatedList(p.projectID))
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(newInstanceOwnerResources(instances))
+	return instances, nil
 }
 
 /**
@@ -135,7 +190,7 @@ func (p gcpProvider) GetInstances() ([]*types.Instance, error) {
  * Then add references to these resources in the MetaData property of the stack, so later we can reference them.
  */
 func (p gcpProvider) GetStacks() ([]*types.Stack, error) {
-	databases, err := p.GetDatabases()
+	databases, err := p.getDatabases(p.sqlClient.Instances.List(p.projectID))
 	if err != nil {
 		log.Error("[GCP] Failed to fetch databases")
 		return nil, err
@@ -283,6 +338,7 @@ func (p gcpProvider) GetStacks() ([]*types.Stack, error) {
 			},
 			Tags: gcpStack.Tags,
 		}
+		aStack.Metadata[types.ResourceCountMetadataKey] = strconv.Itoa(getStackResourceCount(gcpStack))
 		stacks = append(stacks, aStack)
 	}
 	log.Debugf("[GCP] Collected stacks %+v", stacks)
@@ -306,6 +362,15 @@ type GcpStack struct {
 	Tags            types.Tags
 }
 
+// getStackResourceCount returns the number of billable resources in the stack, the network resources are free
+func getStackResourceCount(gcpStack *GcpStack) int {
+	resourceCount := len(gcpStack.InstanceNames) + len(gcpStack.ExternalIpNames)
+	if gcpStack.DatabaseName != "" {
+		resourceCount++
+	}
+	return resourceCount
+}
+
 func (p gcpProvider) getNetworksBySelfLink() (map[string]*compute.Network, error) {
 	networkListCall := p.computeClient.Networks.List(p.projectID)
 	if ctx.ResourceDescription != "" {
@@ -346,7 +411,12 @@ func (p gcpProvider) getFirewallsByNetwork() (map[string]*compute.Firewall, erro
 
 func (p gcpProvider) GetDisks() ([]*types.Disk, error) {
 	log.Debug("[GCP] Fetching disks")
-	return p.getDisks()
+	disks, err := p.getDisks()
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(newDiskOwnerResources(disks))
+	return disks, nil
 }
 
 func (p gcpProvider) DeleteDisks(disks *types.DiskContainer) []error {
@@ -672,6 +742,34 @@ func (p gcpProvider) doAndPollSqlCall(call SqlCall) error {
 	}
 }
 
+type ContainerCall interface {
+	Do(opts ...googleapi.CallOption) (*container.Operation, error)
+}
+
+func (p gcpProvider) doAndPollContainerCall(call ContainerCall) error {
+	operation, err := call.Do()
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("projects/%s/locations/%s/operations/%s", p.projectID, operation.Location, operation.Name)
+
+	for {
+		time.Sleep(3 * time.Second)
+		log.Debugf("[GCP] Polling container operation %s", name)
+		operation, err = p.containerClient.Projects.Locations.Operations.Get(name).Do()
+
+		if err != nil {
+			return err
+		} else if operation.Status == "DONE" {
+			if operation.Error == nil {
+				log.Debugf("[GCP] Polling of container operation %s successfully finished", name)
+				return nil
+			}
+			return fmt.Errorf("[GCP] Polling of container operation %s failed: %s", name, operation.Error.Message)
+		}
+	}
+}
+
 type ComputeCall interface {
 	Do(opts ...googleapi.CallOption) (*compute.Operation, error)
 }
@@ -769,7 +867,9 @@ func (p gcpProvider) StopDatabases(databases *types.DatabaseContainer) []error {
 		go func(database *types.Database) {
 			defer wg.Done()
 
-			if ctx.DryRun {
+			if !database.IsStoppable() {
+				log.Debugf("[GCP] Skipping database: %s, because %s databases cannot be stopped", database.Name, database.Type)
+			} else if ctx.DryRun {
 				log.Infof("[GCP] Dry-run set, instance is not stopped: %s", database.Name)
 			} else {
 				log.Infof("[GCP] Sending request to stop instance %s", database.Name)
@@ -795,15 +895,45 @@ func (p gcpProvider) StopDatabases(databases *types.DatabaseContainer) []error {
 
 func (p gcpProvider) GetAccesses() ([]*types.Access, error) {
 	log.Debug("[GCP] Fetching service accounts")
+	keysLastUsed, err := getKeysLastUsed(p.policyAnalyzerClient.Projects.Locations.ActivityTypes.Activities.Query(
+		"projects/" + p.projectID + "/locations/global/activityTypes/serviceAccountKeyLastAuthentication"))
+	if err != nil {
+		log.Warn("[GCP] The usage of the service account keys is unknown, the Policy Analyzer API must be enabled and the policyanalyzer.serviceAccountKeyLastAuthenticationActivities.query permission is required")
+	}
 	return getAccesses(p.iamClient.Projects.ServiceAccounts.List("projects/"+p.projectID), func(name string) keysListAggregator {
 		return p.iamClient.Projects.ServiceAccounts.Keys.List(name)
-	})
+	}, keysLastUsed)
+}
+
+func (p gcpProvider) DisableAccesses(accesses *types.AccessContainer) []error {
+	log.Debug("[GCP] Disabling service account keys")
+	return updateKeys("disable", func(name string) keyUpdateAggregator {
+		return p.iamClient.Projects.ServiceAccounts.Keys.Disable(name, &iam.DisableServiceAccountKeyRequest{})
+	}, accesses.Get(types.GCP))
+}
+
+func (p gcpProvider) DeleteAccesses(accesses *types.AccessContainer) []error {
+	log.Debug("[GCP] Deleting service account keys")
+	return updateKeys("delete", func(name string) keyUpdateAggregator {
+		return p.iamClient.Projects.ServiceAccounts.Keys.Delete(name)
+	}, accesses.Get(types.GCP))
 }
 
 func (p gcpProvider) GetDatabases() ([]*types.Database, error) {
 	log.Debug("[GCP] Fetching database instances")
 	aggregator := p.sqlClient.Instances.List(p.projectID)
-	return p.getDatabases(aggregator)
+	databases, err := p.getDatabases(aggregator)
+	if err != nil {
+		return nil, err
+	}
+	p.inferOwners(newDatabaseOwnerResources(databases))
+
+	memorystoreInstances, err := p.getMemorystoreInstances()
+	if err != nil {
+		log.Warn("[GCP] Skipping the Memorystore instances, only the Cloud SQL instances are returned")
+		return databases, nil
+	}
+	return append(databases, memorystoreInstances...), nil
 }
 
 type instancesListAggregator interface {
@@ -834,6 +964,65 @@ type keysListAggregator interface {
 	Do(opts ...googleapi.CallOption) (*iam.ListServiceAccountKeysResponse, error)
 }
 
+type keyUpdateAggregator interface {
+	Do(opts ...googleapi.CallOption) (*iam.Empty, error)
+}
+
+// updateKeys executes the given operation (disable or delete) on the service account keys
+func updateKeys(operation string, getAggregator func(string) keyUpdateAggregator, accesses []*types.Access) []error {
+	var errs []error
+	for _, access := range accesses {
+		if ctx.DryRun {
+			log.Infof("[GCP] Dry-run set, service account key is not %sd: %s", operation, access.ID)
+			continue
+		}
+		log.Infof("[GCP] Execute %s on service account key: %s", operation, access.ID)
+		if _, err := getAggregator(access.ID).Do(); err != nil {
+			log.Errorf("[GCP] Unable to %s service account key: %s because: %s", operation, access.ID, err.Error())
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+type keyActivitiesAggregator interface {
+	Pages(context.Context, func(*policyanalyzer.GoogleCloudPolicyanalyzerV1QueryActivityResponse) error) error
+}
+
+type keyLastAuthentication struct {
+	LastAuthenticatedTime string `json:"lastAuthenticatedTime"`
+}
+
+// getKeysLastUsed returns the last authentication time of the service account keys by key ID from the key usage insights.
+// If the insights are not available an error is returned and the usage of the keys is unknown, as they must not be treated as never used.
+func getKeysLastUsed(aggregator keyActivitiesAggregator) (map[string]time.Time, error) {
+	lastUsed := map[string]time.Time{}
+	err := aggregator.Pages(context.Background(), func(resp *policyanalyzer.GoogleCloudPolicyanalyzerV1QueryActivityResponse) error {
+		for _, activity := range resp.Activities {
+			var authentication keyLastAuthentication
+			if err := json.Unmarshal(activity.Activity, &authentication); err != nil {
+				return err
+			}
+			authenticated, err := utils.ConvertTimeRFC3339(authentication.LastAuthenticatedTime)
+			if err != nil {
+				return err
+			}
+			lastUsed[getKeyID(activity.FullResourceName)] = authenticated
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the service account key usage, err: %s", err.Error())
+		return nil, err
+	}
+	log.Debugf("[GCP] Service account key usage: %v", lastUsed)
+	return lastUsed, nil
+}
+
+func getKeyID(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
 type imageListAggregator interface {
 	Do(opts ...googleapi.CallOption) (*compute.ImageList, error)
 }
@@ -897,7 +1086,8 @@ func deleteImages(getAggregator func(string) imageDeleteAggregator, images []*ty
 	return errs
 }
 
-func getAccesses(serviceAccountAggregator serviceAccountsListAggregator, getKeysAggregator func(string) keysListAggregator) ([]*types.Access, error) {
+// getAccesses returns the service account keys, the usage of the keys is unknown if the last used times are not available
+func getAccesses(serviceAccountAggregator serviceAccountsListAggregator, getKeysAggregator func(string) keysListAggregator, keysLastUsed map[string]time.Time) ([]*types.Access, error) {
 	accounts, err := serviceAccountAggregator.Do()
 	if err != nil {
 		return nil, err
@@ -924,12 +1114,24 @@ func getAccesses(serviceAccountAggregator serviceAccountsListAggregator, getKeys
 			if err != nil {
 				return nil, err
 			}
+			var lastUsed *time.Time
+			if authenticated, ok := keysLastUsed[getKeyID(key.Name)]; ok {
+				lastUsed = &authenticated
+			}
+			state := types.Running
+			if key.Disabled {
+				state = types.Disabled
+			}
 			accesses = append(accesses, &types.Access{
-				CloudType: types.GCP,
-				Name:      key.Name,
-				Owner:     account.Email,
-				Created:   validAfter,
-				Tags:      types.Tags{},
+				CloudType:    types.GCP,
+				ID:           key.Name,
+				Name:         key.Name,
+				State:        state,
+				Owner:        account.Email,
+				Created:      validAfter,
+				LastUsed:     lastUsed,
+				UsageUnknown: keysLastUsed == nil,
+				Tags:         types.Tags{},
 			})
 		}
 	}
@@ -1018,6 +1220,15 @@ func getZone(url string) string {
 	return parts[len(parts)-1]
 }
 
+// getLocation returns the location from the name of a resource, e.g. projects/p/locations/us-west1-a/instances/n
+func getLocation(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) < 4 || parts[2] != "locations" {
+		return ""
+	}
+	return parts[3]
+}
+
 func getRegionFromZoneURL(zoneURL *string) string {
 	zoneURLParts := strings.Split(*zoneURL, "/")
 	zone := zoneURLParts[len(zoneURLParts)-1]
@@ -1028,51 +1239,62 @@ func getRegionFromZoneURL(zoneURL *string) string {
 }
 
 func (p gcpProvider) getDisks() ([]*types.Disk, error) {
-	aggregator := p.computeClient.Disks.AggregatedList(p.projectID)
-	disks := make([]*types.Disk, 0)
-	diskList, err := aggregator.Do()
+	var diskLists []*compute.DiskAggregatedList
+	err := p.computeClient.Disks.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.DiskAggregatedList) error {
+		diskLists = append(diskLists, list)
+		return nil
+	})
 	if err != nil {
 		log.Errorf("[GCP] Failed to fetch the available disks, err: %s", err.Error())
 		return nil, err
 	}
-	log.Debugf("[GCP] Processing disks (%d): [%v]", len(diskList.Items), diskList.Items)
-	for _, items := range diskList.Items {
-		for _, gDisk := range items.Disks {
-			creationTimeStamp, err := utils.ConvertTimeRFC3339(gDisk.CreationTimestamp)
-			if err != nil {
-				log.Errorf("[GCP] Failed to get the creation timestamp of disk, err: %s", err.Error())
-				return nil, err
-			}
+	return getDisks(diskLists)
+}
 
-			var region string
-			switch {
-			case len(gDisk.Region) > 0:
-				region = gDisk.Region
-				break
-			case len(gDisk.Zone) > 0:
-				region = getRegionFromZoneURL(&gDisk.Zone)
-				break
-			case len(gDisk.ReplicaZones) > 0:
-				region = getRegionFromZoneURL(&gDisk.ReplicaZones[0])
-				break
-			default:
-				region = "unknown"
-			}
+// getDisks converts the disks of all the pages, the ID is formatted as unsigned, because the IDs of the disks use the
+// whole uint64 range and the source disk IDs of the snapshots are formatted the same way
+func getDisks(diskLists []*compute.DiskAggregatedList) ([]*types.Disk, error) {
+	disks := make([]*types.Disk, 0)
+	for _, diskList := range diskLists {
+		log.Debugf("[GCP] Processing disks (%d): [%v]", len(diskList.Items), diskList.Items)
+		for _, items := range diskList.Items {
+			for _, gDisk := range items.Disks {
+				creationTimeStamp, err := utils.ConvertTimeRFC3339(gDisk.CreationTimestamp)
+				if err != nil {
+					log.Errorf("[GCP] Failed to get the creation timestamp of disk, err: %s", err.Error())
+					return nil, err
+				}
+
+				var region string
+				switch {
+				case len(gDisk.Region) > 0:
+					region = gDisk.Region
+					break
+				case len(gDisk.Zone) > 0:
+					region = getRegionFromZoneURL(&gDisk.Zone)
+					break
+				case len(gDisk.ReplicaZones) > 0:
+					region = getRegionFromZoneURL(&gDisk.ReplicaZones[0])
+					break
+				default:
+					region = "unknown"
+				}
 
-			aDisk := &types.Disk{
-				CloudType: types.GCP,
-				ID:        strconv.Itoa(int(gDisk.Id)),
-				Name:      gDisk.Name,
-				Region:    region,
-				Created:   creationTimeStamp,
-				Size:      gDisk.SizeGb,
-				Type:      gDisk.Type,
-				State:     getDiskStatus(gDisk),
-				Owner:     gDisk.Labels[ctx.OwnerLabel],
-				Metadata:  map[string]string{"zone": getZone(gDisk.Zone)},
-				Tags:      gDisk.Labels,
+				aDisk := &types.Disk{
+					CloudType: types.GCP,
+					ID:        strconv.FormatUint(gDisk.Id, 10),
+					Name:      gDisk.Name,
+					Region:    region,
+					Created:   creationTimeStamp,
+					Size:      gDisk.SizeGb,
+					Type:      gDisk.Type,
+					State:     getDiskStatus(gDisk),
+					Owner:     gDisk.Labels[ctx.OwnerLabel],
+					Metadata:  map[string]string{"zone": getZone(gDisk.Zone)},
+					Tags:      gDisk.Labels,
+				}
+				disks = append(disks, aDisk)
 			}
-			disks = append(disks, aDisk)
 		}
 	}
 	return disks, nil
@@ -1100,12 +1322,15 @@ func (p gcpProvider) getDatabases(aggregator *sqladmin.InstancesListCall) ([]*ty
 			CloudType:    types.GCP,
 			ID:           databaseInstance.Etag,
 			Name:         instanceName,
+			Type:         types.CloudSqlDatabase,
 			Region:       databaseInstance.GceZone,
 			Created:      creationTimeStamp,
 			State:        getDatabaseInstanceStatus(databaseInstance),
 			Owner:        tags[ctx.OwnerLabel],
 			InstanceType: databaseInstance.InstanceType,
+			NodeCount:    1,
 			Tags:         tags,
+			Metadata:     map[string]string{"tier": databaseInstance.Settings.Tier},
 		}
 		databases = append(databases, aDisk)
 	}
@@ -1120,6 +1345,14 @@ func (p gcpProvider) DeleteAlerts(*types.AlertContainer) []error {
 	return []error{errors.New("[GCP] Deleting alerts is not supported yet")}
 }
 
+func (p gcpProvider) GetGateways() ([]*types.Gateway, error) {
+	return nil, errors.New("[GCP] Getting gateways is not supported yet")
+}
+
+func (p gcpProvider) DeleteGateways(*types.GatewayContainer) []error {
+	return []error{errors.New("[GCP] Deleting gateways is not supported yet")}
+}
+
 func (p gcpProvider) GetStorages() ([]*types.Storage, error) {
 	return nil, errors.New("[GCP] Getting storages is not supported yet")
 }
@@ -1214,16 +1447,40 @@ func newCluster(cluster *dataprocpb.Cluster, region string) *types.Cluster {
 	return &types.Cluster{
 		Name:      cluster.ClusterName,
 		Uuid:      cluster.ClusterUuid,
+		Type:      types.DataprocCluster,
 		Created:   cluster.Status.StateStartTime.AsTime(),
 		CloudType: types.GCP,
 		Region:    region,
 		Tags:      cluster.Labels,
 		Owner:     cluster.Labels[ctx.OwnerLabel],
-		Config:    cluster.GetConfig(),
+		Config:    newDataprocClusterConfig(cluster.GetConfig()),
 		State:     getClusterState(cluster.Status.GetState()),
 	}
 }
 
+// newDataprocClusterConfig converts the master, worker and secondary worker groups to node pools
+func newDataprocClusterConfig(config *dataprocpb.ClusterConfig) *types.ClusterConfig {
+	if config == nil {
+		return nil
+	}
+	clusterConfig := &types.ClusterConfig{}
+	groups := map[string]*dataprocpb.InstanceGroupConfig{
+		"master":           config.MasterConfig,
+		"worker":           config.WorkerConfig,
+		"secondary-worker": config.SecondaryWorkerConfig,
+	}
+	for _, name := range []string{"master", "worker", "secondary-worker"} {
+		if group := groups[name]; group != nil {
+			clusterConfig.NodePools = append(clusterConfig.NodePools, &types.NodePool{
+				Name:         name,
+				NodeCount:    int(group.NumInstances),
+				InstanceType: getZone(group.MachineTypeUri),
+			})
+		}
+	}
+	return clusterConfig
+}
+
 func getClusterState(s dataprocpb.ClusterStatus_State) types.State {
 	// convert ClusterSTatus_State to types.State
 	statuses := map[int32]types.State{
@@ -1288,5 +1545,27 @@ func (p gcpProvider) GetClusters() ([]*types.Cluster, error) {
 			clusters = append(clusters, clist...)
 		}
 	}
+
+	gkeClusters, err := p.getGkeClusters()
+	if err != nil {
+		log.Errorf("[GET_CLUSTERS] Skipping the GKE clusters, error listing them: %+v", err)
+	} else {
+		clusters = append(clusters, gkeClusters...)
+	}
+
+	p.inferOwners(newClusterOwnerResources(clusters))
 	return clusters, nil
 }
+
+// StopClusters scales the node pools of the GKE clusters to zero, Dataproc clusters are not stopped
+func (p gcpProvider) StopClusters(clusters *types.ClusterContainer) []error {
+	var gkeClusters []*types.Cluster
+	for _, cluster := range clusters.Get(types.GCP) {
+		if cluster.Type == types.GkeCluster {
+			gkeClusters = append(gkeClusters, cluster)
+		} else {
+			log.Debugf("[GCP] Skipping cluster: %s, because %s clusters cannot be stopped", cluster.Name, cluster.Type)
+		}
+	}
+	return p.stopGkeClusters(gkeClusters)
+}
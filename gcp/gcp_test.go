@@ -1,6 +1,8 @@
 package gcp
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -11,6 +13,7 @@ import (
 	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	iam "google.golang.org/api/iam/v1"
+	policyanalyzer "google.golang.org/api/policyanalyzer/v1"
 )
 
 func TestProviderInit(t *testing.T) {
@@ -29,14 +32,58 @@ func TestGetInstances(t *testing.T) {
 }
 
 func TestGetAccesses(t *testing.T) {
+	lastUsed := time.Now().Add(-time.Hour)
 	accesses, _ := getAccesses(mockServiceAccountsListAggregator{}, func(string) keysListAggregator {
 		return mockKeysListAggregator{}
-	})
+	}, map[string]time.Time{"valid": lastUsed})
 
 	if 1 != len(accesses) {
 		t.Fatalf("Accesses length not equals 1 == %d", len(accesses))
 	}
 	assert.Equal(t, "valid", accesses[0].Name)
+	assert.Equal(t, lastUsed, *accesses[0].LastUsed)
+	assert.False(t, accesses[0].UsageUnknown)
+}
+
+func TestGetAccessesWithUnknownUsage(t *testing.T) {
+	accesses, err := getAccesses(mockServiceAccountsListAggregator{}, func(string) keysListAggregator {
+		return mockKeysListAggregator{}
+	}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(accesses))
+	assert.Nil(t, accesses[0].LastUsed)
+	assert.True(t, accesses[0].UsageUnknown)
+}
+
+func TestUpdateKeys(t *testing.T) {
+	keyChan := make(chan string, 10)
+	getMockAggregator := func(name string) keyUpdateAggregator {
+		keyChan <- name
+		return mockKeyUpdateAggregator{}
+	}
+	accesses := []*types.Access{
+		{CloudType: types.GCP, ID: "projects/p/serviceAccounts/service@account.com/keys/key-id"},
+	}
+
+	errs := updateKeys("disable", getMockAggregator, accesses)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, "projects/p/serviceAccounts/service@account.com/keys/key-id", <-keyChan)
+}
+
+func TestGetKeysLastUsed(t *testing.T) {
+	lastUsed, err := getKeysLastUsed(mockKeyActivitiesAggregator{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]time.Time{"key-id": time.Date(2021, 4, 1, 7, 0, 0, 0, time.UTC)}, lastUsed)
+}
+
+func TestGetKeysLastUsedFails(t *testing.T) {
+	lastUsed, err := getKeysLastUsed(mockKeyActivitiesAggregator{err: errors.New("policy analyzer api is disabled")})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, lastUsed)
 }
 
 func TestDeleteImages(t *testing.T) {
@@ -107,6 +154,31 @@ func (m mockServiceAccountsListAggregator) Do(opts ...googleapi.CallOption) (*ia
 	}, nil
 }
 
+type mockKeyUpdateAggregator struct {
+}
+
+func (m mockKeyUpdateAggregator) Do(opts ...googleapi.CallOption) (*iam.Empty, error) {
+	return &iam.Empty{}, nil
+}
+
+type mockKeyActivitiesAggregator struct {
+	err error
+}
+
+func (m mockKeyActivitiesAggregator) Pages(_ context.Context, f func(*policyanalyzer.GoogleCloudPolicyanalyzerV1QueryActivityResponse) error) error {
+	if m.err != nil {
+		return m.err
+	}
+	return f(&policyanalyzer.GoogleCloudPolicyanalyzerV1QueryActivityResponse{
+		Activities: []*policyanalyzer.GoogleCloudPolicyanalyzerV1Activity{
+			{
+				FullResourceName: "//iam.googleapis.com/projects/project/serviceAccounts/service@account.com/keys/key-id",
+				Activity:         googleapi.RawMessage(`{"lastAuthenticatedTime":"2021-04-01T07:00:00Z","serviceAccountKey":{"serviceAccountId":"1"}}`),
+			},
+		},
+	})
+}
+
 type mockKeysListAggregator struct {
 }
 
@@ -136,3 +208,8 @@ func newTestInstance() *compute.Instance {
 		MachineType:       "n1-standard-8",
 	}
 }
+
+func TestGetStackResourceCount(t *testing.T) {
+	assert.Equal(t, 0, getStackResourceCount(&GcpStack{NetworkName: "n", FirewallName: "f"}))
+	assert.Equal(t, 4, getStackResourceCount(&GcpStack{InstanceNames: []string{"i-1", "i-2"}, ExternalIpNames: []string{"ip"}, DatabaseName: "db"}))
+}
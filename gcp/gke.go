@@ -0,0 +1,223 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	container "google.golang.org/api/container/v1"
+)
+
+// getGkeClusters returns the GKE clusters of all locations. The current size of the node pools is the target size
+// of their managed instance groups, the node pools only know their initial size.
+func (p gcpProvider) getGkeClusters() ([]*types.Cluster, error) {
+	log.Debug("[GCP] Fetching GKE clusters")
+	result, err := p.containerClient.Projects.Locations.Clusters.List(fmt.Sprintf("projects/%s/locations/-", p.projectID)).Do()
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the GKE clusters, err: %s", err.Error())
+		return nil, err
+	}
+	if len(result.MissingZones) > 0 {
+		log.Warnf("[GCP] GKE clusters could not be fetched from zones: %s", result.MissingZones)
+	}
+
+	groupSizes := map[string]int{}
+	gClusters := make([]*container.Cluster, 0)
+	for _, cluster := range result.Clusters {
+		if p.getGroupSizes(cluster, groupSizes) {
+			gClusters = append(gClusters, cluster)
+		}
+	}
+	return getGkeClusters(gClusters, groupSizes)
+}
+
+// getGroupSizes collects the target size of the instance groups of the node pools, a cluster with an instance group
+// that cannot be fetched is skipped as its node count would be unknown
+func (p gcpProvider) getGroupSizes(cluster *container.Cluster, groupSizes map[string]int) bool {
+	for _, pool := range cluster.NodePools {
+		for _, groupURL := range pool.InstanceGroupUrls {
+			zone, name := getInstanceGroupManager(groupURL)
+			manager, err := p.computeClient.InstanceGroupManagers.Get(p.projectID, zone, name).Do()
+			if err != nil {
+				log.Errorf("[GCP] Skipping GKE cluster %s, failed to fetch the instance group of node pool %s, err: %s", cluster.Name, pool.Name, err.Error())
+				return false
+			}
+			groupSizes[groupURL] = int(manager.TargetSize)
+		}
+	}
+	return true
+}
+
+func getGkeClusters(gClusters []*container.Cluster, groupSizes map[string]int) ([]*types.Cluster, error) {
+	log.Debugf("[GCP] Processing GKE clusters (%d)", len(gClusters))
+	clusters := make([]*types.Cluster, 0)
+	for _, gCluster := range gClusters {
+		created, err := utils.ConvertTimeRFC3339(gCluster.CreateTime)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of GKE cluster, err: %s", err.Error())
+			return nil, err
+		}
+		config := &types.ClusterConfig{}
+		for _, gPool := range gCluster.NodePools {
+			pool := &types.NodePool{Name: gPool.Name}
+			for _, groupURL := range gPool.InstanceGroupUrls {
+				pool.NodeCount += groupSizes[groupURL]
+			}
+			if gPool.Config != nil {
+				pool.InstanceType = gPool.Config.MachineType
+			}
+			if gPool.Autoscaling != nil {
+				pool.Autoscaling = gPool.Autoscaling.Enabled
+			}
+			config.NodePools = append(config.NodePools, pool)
+		}
+		clusters = append(clusters, &types.Cluster{
+			Uuid:      gCluster.Id,
+			Name:      gCluster.Name,
+			Type:      types.GkeCluster,
+			Created:   created,
+			CloudType: types.GCP,
+			Region:    gCluster.Location,
+			Tags:      gCluster.ResourceLabels,
+			Owner:     gCluster.ResourceLabels[ctx.OwnerLabel],
+			Config:    config,
+			State:     getGkeClusterState(gCluster.Status, config),
+			Metadata:  map[string]string{"version": gCluster.CurrentMasterVersion},
+		})
+	}
+	return clusters, nil
+}
+
+// getInstanceGroupManager returns the zone and the name of the instance group manager from its URL
+func getInstanceGroupManager(url string) (string, string) {
+	parts := strings.Split(url, "/")
+	if len(parts) < 3 {
+		return "", url
+	}
+	return parts[len(parts)-3], parts[len(parts)-1]
+}
+
+// getGkeClusterState returns Stopped for the running clusters whose node pools are scaled to zero
+func getGkeClusterState(status string, config *types.ClusterConfig) types.State {
+	switch status {
+	case "PROVISIONING":
+		return types.Creating
+	case "RUNNING":
+		if config.IsScaledToZero() {
+			return types.Stopped
+		}
+		return types.Running
+	case "RECONCILING":
+		return types.Updating
+	case "STOPPING":
+		return types.Deleting
+	case "ERROR", "DEGRADED":
+		return types.Error
+	}
+	return types.Unknown
+}
+
+// stopGkeClusters disables the autoscaling of the node pools and scales them to zero, the control plane keeps
+// running. GKE runs one operation at a time on a cluster, so the node pools of a cluster are scaled one by one.
+// The scaling of the node pools is saved in the labels of the cluster first, the cluster is not stopped if the
+// scaling could not be saved.
+func (p gcpProvider) stopGkeClusters(clusters []*types.Cluster) []error {
+	wg := sync.WaitGroup{}
+	wg.Add(len(clusters))
+	errChan := make(chan error)
+
+	for _, c := range clusters {
+		go func(cluster *types.Cluster) {
+			defer wg.Done()
+
+			if cluster.Config == nil {
+				return
+			}
+			var pools []*types.NodePool
+			for _, pool := range cluster.Config.NodePools {
+				if pool.NodeCount == 0 && !pool.Autoscaling {
+					continue
+				}
+				if ctx.DryRun {
+					log.Infof("[GCP] Dry-run set, node pool %s of GKE cluster %s is not scaled to zero", pool.Name, cluster.Name)
+					continue
+				}
+				pools = append(pools, pool)
+			}
+			if len(pools) == 0 {
+				return
+			}
+			clusterName := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", p.projectID, cluster.Region, cluster.Name)
+			if err := p.saveNodePoolScaling(clusterName, pools); err != nil {
+				log.Errorf("[GCP] Failed to save the scaling of the node pools of GKE cluster %s, err: %s", cluster.Name, err)
+				errChan <- err
+				return
+			}
+			for _, pool := range pools {
+				name := fmt.Sprintf("%s/nodePools/%s", clusterName, pool.Name)
+				if pool.Autoscaling {
+					log.Infof("[GCP] Disable autoscaling of node pool %s of GKE cluster %s", pool.Name, cluster.Name)
+					request := &container.SetNodePoolAutoscalingRequest{Autoscaling: &container.NodePoolAutoscaling{Enabled: false}}
+					if err := p.doAndPollContainerCall(p.containerClient.Projects.Locations.Clusters.NodePools.SetAutoscaling(name, request)); err != nil {
+						log.Errorf("[GCP] Failed to disable autoscaling of node pool %s of GKE cluster %s, err: %s", pool.Name, cluster.Name, err)
+						errChan <- err
+						continue
+					}
+				}
+				log.Infof("[GCP] Scale node pool %s of GKE cluster %s to zero", pool.Name, cluster.Name)
+				request := &container.SetNodePoolSizeRequest{NodeCount: 0, ForceSendFields: []string{"NodeCount"}}
+				if err := p.doAndPollContainerCall(p.containerClient.Projects.Locations.Clusters.NodePools.SetSize(name, request)); err != nil {
+					log.Errorf("[GCP] Failed to scale node pool %s of GKE cluster %s to zero, err: %s", pool.Name, cluster.Name, err)
+					errChan <- err
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// saveNodePoolScaling labels the cluster with the current scaling of its node pools
+func (p gcpProvider) saveNodePoolScaling(clusterName string, pools []*types.NodePool) error {
+	gCluster, err := p.containerClient.Projects.Locations.Clusters.Get(clusterName).Do()
+	if err != nil {
+		return err
+	}
+	labels := getScalingLabels(gCluster, pools)
+	log.Infof("[GCP] Save the scaling of the node pools of GKE cluster %s: %v", gCluster.Name, labels)
+	request := &container.SetLabelsRequest{LabelFingerprint: gCluster.LabelFingerprint, ResourceLabels: labels}
+	return p.doAndPollContainerCall(p.containerClient.Projects.Locations.Clusters.SetResourceLabels(clusterName, request))
+}
+
+// getScalingLabels returns the labels of the cluster extended with the scaling of the node pools. The label key is the
+// scaling tag suffixed with the name of the node pool, as the node pools have no labels of their own.
+func getScalingLabels(gCluster *container.Cluster, pools []*types.NodePool) map[string]string {
+	labels := map[string]string{}
+	for k, v := range gCluster.ResourceLabels {
+		labels[k] = v
+	}
+	for _, pool := range pools {
+		scaling := types.NodePoolScaling{Desired: pool.NodeCount, Autoscaling: pool.Autoscaling}
+		for _, gPool := range gCluster.NodePools {
+			if gPool.Name == pool.Name && gPool.Autoscaling != nil {
+				scaling.Min = int(gPool.Autoscaling.MinNodeCount)
+				scaling.Max = int(gPool.Autoscaling.MaxNodeCount)
+			}
+		}
+		labels[types.ScalingTag+"-"+pool.Name] = scaling.String()
+	}
+	return labels
+}
@@ -0,0 +1,90 @@
+package gcp
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	container "google.golang.org/api/container/v1"
+)
+
+func TestGetGkeClusters(t *testing.T) {
+	groupURL := computeURL + "zones/us-west1-a/instanceGroupManagers/gke-pool"
+	gClusters := []*container.Cluster{
+		{
+			Id:             "1",
+			Name:           "running",
+			Location:       "us-west1",
+			Status:         "RUNNING",
+			CreateTime:     "2018-05-25T11:23:23+00:00",
+			ResourceLabels: map[string]string{ctx.OwnerLabel: "owner"},
+			NodePools: []*container.NodePool{
+				{
+					Name:              "pool",
+					Config:            &container.NodeConfig{MachineType: "n1-standard-4"},
+					Autoscaling:       &container.NodePoolAutoscaling{Enabled: true},
+					InstanceGroupUrls: []string{groupURL},
+				},
+			},
+		},
+		{
+			Id:         "2",
+			Name:       "scaled-down",
+			Location:   "us-west1-a",
+			Status:     "RUNNING",
+			CreateTime: "2018-05-25T11:23:23+00:00",
+			NodePools:  []*container.NodePool{{Name: "default-pool"}},
+		},
+	}
+
+	clusters, err := getGkeClusters(gClusters, map[string]int{groupURL: 3})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(clusters))
+	running := clusters[0]
+	assert.Equal(t, types.GkeCluster, running.Type)
+	assert.Equal(t, types.Running, running.State)
+	assert.Equal(t, "owner", running.Owner)
+	assert.Equal(t, "us-west1", running.Region)
+	assert.Equal(t, 3, running.Config.GetNodeCount())
+	assert.Equal(t, []string{"n1-standard-4"}, running.Config.GetInstanceTypes())
+	assert.True(t, running.Config.NodePools[0].Autoscaling)
+	assert.Equal(t, types.Stopped, clusters[1].State)
+}
+
+func TestGetInstanceGroupManager(t *testing.T) {
+	zone, name := getInstanceGroupManager(computeURL + "zones/us-west1-a/instanceGroupManagers/gke-pool")
+
+	assert.Equal(t, "us-west1-a", zone)
+	assert.Equal(t, "gke-pool", name)
+}
+
+func TestGetGkeClusterState(t *testing.T) {
+	config := &types.ClusterConfig{NodePools: []*types.NodePool{{NodeCount: 1}}}
+
+	assert.Equal(t, types.Creating, getGkeClusterState("PROVISIONING", config))
+	assert.Equal(t, types.Running, getGkeClusterState("RUNNING", config))
+	assert.Equal(t, types.Stopped, getGkeClusterState("RUNNING", &types.ClusterConfig{NodePools: []*types.NodePool{{Name: "pool"}}}))
+	assert.Equal(t, types.Running, getGkeClusterState("RUNNING", &types.ClusterConfig{}))
+	assert.Equal(t, types.Error, getGkeClusterState("ERROR", config))
+}
+
+func TestGetScalingLabels(t *testing.T) {
+	gCluster := &container.Cluster{
+		ResourceLabels: map[string]string{ctx.OwnerLabel: "owner"},
+		NodePools: []*container.NodePool{
+			{Name: "autoscaled", Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5}},
+			{Name: "fixed"},
+		},
+	}
+
+	labels := getScalingLabels(gCluster, []*types.NodePool{{Name: "autoscaled", NodeCount: 3, Autoscaling: true}, {Name: "fixed", NodeCount: 2}})
+
+	assert.Equal(t, map[string]string{
+		ctx.OwnerLabel:                   "owner",
+		types.ScalingTag + "-autoscaled": "desired-3_min-1_max-5_autoscaling-on",
+		types.ScalingTag + "-fixed":      "desired-2_min-0_max-0_autoscaling-off",
+	}, labels)
+	assert.Equal(t, map[string]string{ctx.OwnerLabel: "owner"}, gCluster.ResourceLabels)
+}
@@ -0,0 +1,337 @@
+package gcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// targetHealth is the number of registered and healthy targets behind a backend service or target pool
+type targetHealth struct {
+	targets int
+	healthy int
+}
+
+// GetLoadBalancers returns the forwarding rules, which are the billed frontends of the load balancers. The health of
+// a forwarding rule is resolved through its target proxy and url map to the backend services, or to its target pool.
+func (p gcpProvider) GetLoadBalancers() ([]*types.LoadBalancer, error) {
+	log.Debug("[GCP] Fetching forwarding rules")
+	var gRules []*compute.ForwardingRule
+	err := p.computeClient.ForwardingRules.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.ForwardingRuleAggregatedList) error {
+		for _, items := range list.Items {
+			gRules = append(gRules, items.ForwardingRules...)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the regional forwarding rules, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.GlobalForwardingRules.List(p.projectID).Pages(context.Background(), func(list *compute.ForwardingRuleList) error {
+		gRules = append(gRules, list.Items...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the global forwarding rules, err: %s", err.Error())
+		return nil, err
+	}
+
+	targetServices, err := p.getTargetServices()
+	if err != nil {
+		return nil, err
+	}
+	health, err := p.getBackendServiceHealth()
+	if err != nil {
+		return nil, err
+	}
+	poolHealth, err := p.getTargetPoolHealth()
+	if err != nil {
+		return nil, err
+	}
+	for link, h := range poolHealth {
+		health[link] = h
+	}
+	return getLoadBalancers(gRules, targetServices, health)
+}
+
+// getTargetServices returns the backend services of the target proxies
+func (p gcpProvider) getTargetServices() (map[string][]string, error) {
+	urlMapServices := map[string][]string{}
+	err := p.computeClient.UrlMaps.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.UrlMapsAggregatedList) error {
+		for _, items := range list.Items {
+			for _, urlMap := range items.UrlMaps {
+				urlMapServices[urlMap.SelfLink] = getUrlMapServices(urlMap)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the url maps, err: %s", err.Error())
+		return nil, err
+	}
+
+	targetServices := map[string][]string{}
+	err = p.computeClient.TargetHttpProxies.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.TargetHttpProxyAggregatedList) error {
+		for _, items := range list.Items {
+			for _, proxy := range items.TargetHttpProxies {
+				targetServices[proxy.SelfLink] = urlMapServices[proxy.UrlMap]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the target HTTP proxies, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.TargetHttpsProxies.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.TargetHttpsProxyAggregatedList) error {
+		for _, items := range list.Items {
+			for _, proxy := range items.TargetHttpsProxies {
+				targetServices[proxy.SelfLink] = urlMapServices[proxy.UrlMap]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the target HTTPS proxies, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.TargetTcpProxies.List(p.projectID).Pages(context.Background(), func(list *compute.TargetTcpProxyList) error {
+		for _, proxy := range list.Items {
+			targetServices[proxy.SelfLink] = []string{proxy.Service}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the target TCP proxies, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.TargetSslProxies.List(p.projectID).Pages(context.Background(), func(list *compute.TargetSslProxyList) error {
+		for _, proxy := range list.Items {
+			targetServices[proxy.SelfLink] = []string{proxy.Service}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the target SSL proxies, err: %s", err.Error())
+		return nil, err
+	}
+	return targetServices, nil
+}
+
+func getUrlMapServices(urlMap *compute.UrlMap) []string {
+	var services []string
+	if len(urlMap.DefaultService) > 0 {
+		services = append(services, urlMap.DefaultService)
+	}
+	for _, pathMatcher := range urlMap.PathMatchers {
+		if len(pathMatcher.DefaultService) > 0 {
+			services = append(services, pathMatcher.DefaultService)
+		}
+		for _, pathRule := range pathMatcher.PathRules {
+			if len(pathRule.Service) > 0 {
+				services = append(services, pathRule.Service)
+			}
+		}
+	}
+	return services
+}
+
+// getBackendServiceHealth returns the health of the backend services. Serverless network endpoint groups do not
+// report health, they are counted as healthy targets.
+func (p gcpProvider) getBackendServiceHealth() (map[string]targetHealth, error) {
+	var services []*compute.BackendService
+	err := p.computeClient.BackendServices.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.BackendServiceAggregatedList) error {
+		for _, items := range list.Items {
+			services = append(services, items.BackendServices...)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the backend services, err: %s", err.Error())
+		return nil, err
+	}
+
+	health := map[string]targetHealth{}
+	for _, service := range services {
+		serviceHealth := targetHealth{}
+		for _, backend := range service.Backends {
+			reference := &compute.ResourceGroupReference{Group: backend.Group}
+			var groupHealth *compute.BackendServiceGroupHealth
+			if len(service.Region) > 0 {
+				groupHealth, err = p.computeClient.RegionBackendServices.GetHealth(p.projectID, getZone(service.Region), service.Name, reference).Do()
+			} else {
+				groupHealth, err = p.computeClient.BackendServices.GetHealth(p.projectID, service.Name, reference).Do()
+			}
+			if err != nil {
+				log.Errorf("[GCP] Failed to fetch the health of backend service: %s, err: %s", service.Name, err.Error())
+				return nil, err
+			}
+			backendHealth := getTargetHealth(groupHealth.HealthStatus)
+			if backendHealth.targets == 0 && strings.Contains(backend.Group, "/networkEndpointGroups/") {
+				backendHealth = targetHealth{targets: 1, healthy: 1}
+			}
+			serviceHealth.targets += backendHealth.targets
+			serviceHealth.healthy += backendHealth.healthy
+		}
+		health[service.SelfLink] = serviceHealth
+	}
+	return health, nil
+}
+
+func (p gcpProvider) getTargetPoolHealth() (map[string]targetHealth, error) {
+	var pools []*compute.TargetPool
+	err := p.computeClient.TargetPools.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.TargetPoolAggregatedList) error {
+		for _, items := range list.Items {
+			pools = append(pools, items.TargetPools...)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the target pools, err: %s", err.Error())
+		return nil, err
+	}
+
+	health := map[string]targetHealth{}
+	for _, pool := range pools {
+		poolHealth := targetHealth{}
+		for _, instance := range pool.Instances {
+			instanceHealth, err := p.computeClient.TargetPools.GetHealth(p.projectID, getZone(pool.Region), pool.Name, &compute.InstanceReference{Instance: instance}).Do()
+			if err != nil {
+				log.Errorf("[GCP] Failed to fetch the health of target pool: %s, err: %s", pool.Name, err.Error())
+				return nil, err
+			}
+			h := getTargetHealth(instanceHealth.HealthStatus)
+			poolHealth.targets++
+			poolHealth.healthy += h.healthy
+		}
+		health[pool.SelfLink] = poolHealth
+	}
+	return health, nil
+}
+
+func getTargetHealth(statuses []*compute.HealthStatus) targetHealth {
+	health := targetHealth{targets: len(statuses)}
+	for _, status := range statuses {
+		if status.HealthState == "HEALTHY" {
+			health.healthy++
+		}
+	}
+	return health
+}
+
+// getLoadBalancers converts the forwarding rules, a rule is unused if there are no targets behind it and unhealthy if
+// none of its targets are healthy. The
+// state of the rules whose target is not a known proxy, backend service or target pool is unknown, e.g. target
+// instances, Classic VPN gateways, Private Service Connect endpoints and regional TCP proxies.
+func getLoadBalancers(gRules []*compute.ForwardingRule, targetServices map[string][]string, health map[string]targetHealth) ([]*types.LoadBalancer, error) {
+	log.Debugf("[GCP] Processing forwarding rules (%d)", len(gRules))
+	loadBalancers := make([]*types.LoadBalancer, 0)
+	for _, gRule := range gRules {
+		creationTimeStamp, err := utils.ConvertTimeRFC3339(gRule.CreationTimestamp)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of forwarding rule, err: %s", err.Error())
+			return nil, err
+		}
+		region := globalRegion
+		if len(gRule.Region) > 0 {
+			region = getZone(gRule.Region)
+		}
+
+		target := gRule.Target
+		if len(gRule.BackendService) > 0 {
+			target = gRule.BackendService
+		}
+		backends := []string{target}
+		if services, ok := targetServices[target]; ok {
+			backends = services
+		}
+		ruleHealth := targetHealth{}
+		resolved := len(backends) > 0
+		for _, backend := range backends {
+			backendHealth, ok := health[backend]
+			if !ok {
+				resolved = false
+				continue
+			}
+			ruleHealth.targets += backendHealth.targets
+			ruleHealth.healthy += backendHealth.healthy
+		}
+
+		var state types.State
+		switch {
+		case ruleHealth.healthy > 0:
+			state = types.InUse
+		case resolved && ruleHealth.targets > 0:
+			state = types.Unhealthy
+		case resolved:
+			state = types.Unused
+		default:
+			log.Debugf("[GCP] The target of forwarding rule: %s is not resolved: %s", gRule.Name, target)
+			state = types.Unknown
+		}
+		loadBalancers = append(loadBalancers, &types.LoadBalancer{
+			CloudType:      types.GCP,
+			ID:             strconv.FormatUint(gRule.Id, 10),
+			Name:           gRule.Name,
+			Type:           strings.ToLower(gRule.LoadBalancingScheme),
+			Region:         region,
+			Created:        creationTimeStamp,
+			State:          state,
+			Owner:          gRule.Labels[ctx.OwnerLabel],
+			Targets:        ruleHealth.targets,
+			HealthyTargets: ruleHealth.healthy,
+			Metadata:       map[string]string{"ip": gRule.IPAddress, "target": getZone(target)},
+			Tags:           gRule.Labels,
+		})
+	}
+	return loadBalancers, nil
+}
+
+// DeleteLoadBalancers deletes the forwarding rules, the proxies and backend services behind them are not billed
+func (p gcpProvider) DeleteLoadBalancers(loadBalancers *types.LoadBalancerContainer) []error {
+	gcpLoadBalancers := loadBalancers.Get(types.GCP)
+	log.Debugf("[GCP] Deleting forwarding rules: %v", gcpLoadBalancers)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpLoadBalancers))
+	errChan := make(chan error)
+
+	for _, l := range gcpLoadBalancers {
+		go func(loadBalancer *types.LoadBalancer) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, forwarding rule is not deleted: %s", loadBalancer.Name)
+				return
+			}
+			log.Infof("[GCP] Sending request to delete forwarding rule in region %s: %s", loadBalancer.Region, loadBalancer.Name)
+			var err error
+			if loadBalancer.Region == globalRegion {
+				_, err = p.computeClient.GlobalForwardingRules.Delete(p.projectID, loadBalancer.Name).Do()
+			} else {
+				_, err = p.computeClient.ForwardingRules.Delete(p.projectID, loadBalancer.Region, loadBalancer.Name).Do()
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}(l)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,90 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const computeURL = "https://www.googleapis.com/compute/v1/projects/p/"
+
+func TestGetLoadBalancers(t *testing.T) {
+	gRules := []*compute.ForwardingRule{
+		{
+			Id:                  1,
+			Name:                "http",
+			LoadBalancingScheme: "EXTERNAL",
+			Target:              computeURL + "global/targetHttpProxies/proxy",
+			CreationTimestamp:   "2018-05-25T11:23:23.000-07:00",
+		},
+		{
+			Id:                  2,
+			Name:                "internal",
+			LoadBalancingScheme: "INTERNAL",
+			Region:              computeURL + "regions/us-west1",
+			BackendService:      computeURL + "regions/us-west1/backendServices/unhealthy",
+			CreationTimestamp:   "2018-05-25T11:23:23.000-07:00",
+		},
+		{
+			Id:                  3,
+			Name:                "pool",
+			LoadBalancingScheme: "EXTERNAL",
+			Region:              computeURL + "regions/us-west1",
+			Target:              computeURL + "regions/us-west1/targetPools/empty",
+			CreationTimestamp:   "2018-05-25T11:23:23.000-07:00",
+		},
+		{
+			Id:                  4,
+			Name:                "vpn-esp",
+			LoadBalancingScheme: "EXTERNAL",
+			Region:              computeURL + "regions/us-west1",
+			Target:              computeURL + "regions/us-west1/targetVpnGateways/vpn",
+			CreationTimestamp:   "2018-05-25T11:23:23.000-07:00",
+		},
+	}
+	targetServices := map[string][]string{
+		computeURL + "global/targetHttpProxies/proxy": {computeURL + "global/backendServices/healthy", computeURL + "global/backendServices/empty"},
+	}
+	health := map[string]targetHealth{
+		computeURL + "global/backendServices/healthy":             {targets: 2, healthy: 1},
+		computeURL + "regions/us-west1/backendServices/unhealthy": {targets: 2, healthy: 0},
+		computeURL + "regions/us-west1/targetPools/empty":         {},
+	}
+
+	loadBalancers, err := getLoadBalancers(gRules, targetServices, health)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(loadBalancers))
+	assert.Equal(t, "1", loadBalancers[0].ID)
+	assert.Equal(t, "global", loadBalancers[0].Region)
+	assert.Equal(t, "external", loadBalancers[0].Type)
+	assert.Equal(t, types.InUse, loadBalancers[0].State)
+	assert.Equal(t, 1, loadBalancers[0].HealthyTargets)
+	assert.Equal(t, "proxy", loadBalancers[0].Metadata["target"])
+	assert.Equal(t, "us-west1", loadBalancers[1].Region)
+	assert.Equal(t, types.Unhealthy, loadBalancers[1].State)
+	assert.Equal(t, 2, loadBalancers[1].Targets)
+	assert.Equal(t, types.Unused, loadBalancers[2].State)
+	assert.Equal(t, 0, loadBalancers[2].Targets)
+	assert.Equal(t, types.Unknown, loadBalancers[3].State)
+	assert.Equal(t, "vpn", loadBalancers[3].Metadata["target"])
+}
+
+func TestGetUrlMapServices(t *testing.T) {
+	urlMap := &compute.UrlMap{
+		DefaultService: "default",
+		PathMatchers: []*compute.PathMatcher{
+			{DefaultService: "matcher", PathRules: []*compute.PathRule{{Service: "path"}, {}}},
+		},
+	}
+
+	assert.Equal(t, []string{"default", "matcher", "path"}, getUrlMapServices(urlMap))
+}
+
+func TestGetTargetHealth(t *testing.T) {
+	health := getTargetHealth([]*compute.HealthStatus{{HealthState: "HEALTHY"}, {HealthState: "UNHEALTHY"}})
+
+	assert.Equal(t, targetHealth{targets: 2, healthy: 1}, health)
+}
@@ -0,0 +1,81 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	redis "google.golang.org/api/redis/v1"
+)
+
+func (p gcpProvider) getMemorystoreInstances() ([]*types.Database, error) {
+	log.Debug("[GCP] Fetching Memorystore instances")
+	var gInstances []*redis.Instance
+	err := p.redisClient.Projects.Locations.Instances.List(fmt.Sprintf("projects/%s/locations/-", p.projectID)).Pages(context.Background(), func(list *redis.ListInstancesResponse) error {
+		if len(list.Unreachable) > 0 {
+			log.Warnf("[GCP] Memorystore instances could not be fetched from locations: %s", list.Unreachable)
+		}
+		gInstances = append(gInstances, list.Instances...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the Memorystore instances, err: %s", err.Error())
+		return nil, err
+	}
+	return getMemorystoreInstances(gInstances)
+}
+
+// getMemorystoreInstances converts the Redis instances, a Standard tier instance has a primary and replica nodes
+func getMemorystoreInstances(gInstances []*redis.Instance) ([]*types.Database, error) {
+	log.Debugf("[GCP] Processing Memorystore instances (%d)", len(gInstances))
+	databases := make([]*types.Database, 0)
+	for _, gInstance := range gInstances {
+		created, err := utils.ConvertTimeRFC3339(gInstance.CreateTime)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of Memorystore instance, err: %s", err.Error())
+			return nil, err
+		}
+		nodeCount := len(gInstance.Nodes)
+		if nodeCount == 0 {
+			nodeCount = 1
+		}
+		databases = append(databases, &types.Database{
+			CloudType:    types.GCP,
+			ID:           gInstance.Name,
+			Name:         getZone(gInstance.Name),
+			Type:         types.MemorystoreDatabase,
+			Region:       gInstance.LocationId,
+			Created:      created,
+			State:        getMemorystoreInstanceState(gInstance.State),
+			Owner:        gInstance.Labels[ctx.OwnerLabel],
+			InstanceType: strings.ToLower(gInstance.Tier),
+			NodeCount:    nodeCount,
+			Tags:         gInstance.Labels,
+			Metadata: map[string]string{
+				"memorySizeGb": strconv.FormatInt(gInstance.MemorySizeGb, 10),
+				"redisVersion": gInstance.RedisVersion,
+			},
+		})
+	}
+	return databases, nil
+}
+
+// getMemorystoreInstanceState returns the state of the instance, Memorystore instances cannot be stopped
+func getMemorystoreInstanceState(state string) types.State {
+	switch state {
+	case "READY":
+		return types.Running
+	case "CREATING":
+		return types.Creating
+	case "UPDATING", "REPAIRING", "MAINTENANCE", "IMPORTING", "FAILING_OVER":
+		return types.Updating
+	case "DELETING":
+		return types.Deleting
+	}
+	return types.Unknown
+}
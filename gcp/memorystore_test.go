@@ -0,0 +1,46 @@
+package gcp
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	redis "google.golang.org/api/redis/v1"
+)
+
+func TestGetMemorystoreInstances(t *testing.T) {
+	gInstances := []*redis.Instance{
+		{
+			Name:         "projects/p/locations/us-west1/instances/cache",
+			LocationId:   "us-west1-a",
+			State:        "READY",
+			Tier:         "STANDARD_HA",
+			MemorySizeGb: 5,
+			CreateTime:   "2018-05-25T11:23:23+00:00",
+			Labels:       map[string]string{ctx.OwnerLabel: "owner"},
+			Nodes:        []*redis.NodeInfo{{Id: "node-0"}, {Id: "node-1"}},
+		},
+		{
+			Name:       "projects/p/locations/us-west1/instances/basic",
+			State:      "CREATING",
+			Tier:       "BASIC",
+			CreateTime: "2018-05-25T11:23:23+00:00",
+		},
+	}
+
+	databases, err := getMemorystoreInstances(gInstances)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(databases))
+	cache := databases[0]
+	assert.Equal(t, "cache", cache.Name)
+	assert.Equal(t, types.MemorystoreDatabase, cache.Type)
+	assert.Equal(t, "standard_ha", cache.InstanceType)
+	assert.Equal(t, 2, cache.NodeCount)
+	assert.Equal(t, types.Running, cache.State)
+	assert.Equal(t, "owner", cache.Owner)
+	assert.Equal(t, "5", cache.Metadata["memorySizeGb"])
+	assert.Equal(t, 1, databases[1].NodeCount)
+	assert.Equal(t, types.Creating, databases[1].State)
+}
@@ -0,0 +1,108 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type timeSeriesIterator interface {
+	Next() (*monitoringpb.TimeSeries, error)
+}
+
+func (p gcpProvider) GetMetricValues(item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	ctx := context.Background()
+	client, err := monitoring.NewMetricClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return getMetricValues(func(request *monitoringpb.ListTimeSeriesRequest) timeSeriesIterator {
+		return client.ListTimeSeries(ctx, request)
+	}, p.projectID, item, query)
+}
+
+// getMetricValues returns the datapoints of the metric of the item in chronological order
+func getMetricValues(listTimeSeries func(*monitoringpb.ListTimeSeriesRequest) timeSeriesIterator, projectID string, item types.CloudItem, query types.MetricQuery) ([]float64, error) {
+	resourceFilter, err := getMetricResourceFilter(projectID, item)
+	if err != nil {
+		return nil, err
+	}
+	request := &monitoringpb.ListTimeSeriesRequest{
+		Name:   "projects/" + projectID,
+		Filter: fmt.Sprintf("metric.type = %q AND %s", query.Name, resourceFilter),
+		Interval: &monitoringpb.TimeInterval{
+			StartTime: timestamppb.New(query.Start),
+			EndTime:   timestamppb.New(query.End),
+		},
+	}
+	if query.Period > 0 {
+		aligner, err := getAligner(query.Statistic)
+		if err != nil {
+			return nil, err
+		}
+		request.Aggregation = &monitoringpb.Aggregation{AlignmentPeriod: durationpb.New(query.Period), PerSeriesAligner: aligner}
+	}
+	log.Debugf("[GCP] Fetching time series: %s", request.Filter)
+
+	var values []float64
+	timeSeries := listTimeSeries(request)
+	for {
+		series, err := timeSeries.Next()
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		// the points are returned in reverse time order
+		for i := len(series.Points) - 1; i >= 0; i-- {
+			if series.ValueType == metricpb.MetricDescriptor_INT64 {
+				values = append(values, float64(series.Points[i].Value.GetInt64Value()))
+			} else {
+				values = append(values, series.Points[i].Value.GetDoubleValue())
+			}
+		}
+	}
+	return values, nil
+}
+
+func getMetricResourceFilter(projectID string, item types.CloudItem) (string, error) {
+	switch t := item.GetItem().(type) {
+	case types.Instance, types.Notebook:
+		return fmt.Sprintf("metric.label.instance_name = %q", item.GetName()), nil
+	case types.Database:
+		if t.Type == types.MemorystoreDatabase {
+			return fmt.Sprintf("resource.labels.instance_id = %q", t.ID), nil
+		}
+		return fmt.Sprintf("resource.labels.database_id = %q", projectID+":"+t.Name), nil
+	case types.Function:
+		if t.Metadata["environment"] == "GEN_2" {
+			return fmt.Sprintf("resource.labels.service_name = %q AND resource.labels.location = %q", t.Metadata["service"], t.Region), nil
+		}
+		return fmt.Sprintf("resource.labels.function_name = %q AND resource.labels.region = %q", t.Name, t.Region), nil
+	case types.Cluster:
+		if t.Type == types.GkeCluster {
+			return fmt.Sprintf("resource.labels.cluster_name = %q AND resource.labels.location = %q", t.Name, t.Region), nil
+		}
+		return fmt.Sprintf("resource.labels.cluster_name = %q AND resource.labels.region = %q", t.Name, t.Region), nil
+	}
+	return "", fmt.Errorf("metrics are not supported for %s: %s", item.GetType(), item.GetName())
+}
+
+func getAligner(statistic string) (monitoringpb.Aggregation_Aligner, error) {
+	switch statistic {
+	case types.AverageStatistic:
+		return monitoringpb.Aggregation_ALIGN_MEAN, nil
+	case types.SumStatistic:
+		return monitoringpb.Aggregation_ALIGN_SUM, nil
+	}
+	return monitoringpb.Aggregation_ALIGN_NONE, fmt.Errorf("unsupported statistic: %s", statistic)
+}
@@ -0,0 +1,87 @@
+package gcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+type mockTimeSeriesIterator struct {
+	series []*monitoringpb.TimeSeries
+}
+
+func (i *mockTimeSeriesIterator) Next() (*monitoringpb.TimeSeries, error) {
+	if len(i.series) == 0 {
+		return nil, iterator.Done
+	}
+	next := i.series[0]
+	i.series = i.series[1:]
+	return next, nil
+}
+
+func newPoint(value float64) *monitoringpb.Point {
+	return &monitoringpb.Point{Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: value}}}
+}
+
+func TestGetMetricValues(t *testing.T) {
+	var request *monitoringpb.ListTimeSeriesRequest
+	listTimeSeries := func(r *monitoringpb.ListTimeSeriesRequest) timeSeriesIterator {
+		request = r
+		return &mockTimeSeriesIterator{[]*monitoringpb.TimeSeries{
+			{ValueType: metricpb.MetricDescriptor_DOUBLE, Points: []*monitoringpb.Point{newPoint(0.3), newPoint(0.2), newPoint(0.1)}},
+		}}
+	}
+	end := time.Now()
+	query := types.MetricQuery{Name: "compute.googleapis.com/instance/cpu/utilization", Statistic: types.AverageStatistic, Period: time.Hour, Start: end.Add(-time.Hour), End: end}
+
+	values, err := getMetricValues(listTimeSeries, "project-id", &types.Instance{Name: "instance"}, query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{0.1, 0.2, 0.3}, values)
+	assert.Equal(t, "projects/project-id", request.Name)
+	assert.Equal(t, `metric.type = "compute.googleapis.com/instance/cpu/utilization" AND metric.label.instance_name = "instance"`, request.Filter)
+	assert.Equal(t, monitoringpb.Aggregation_ALIGN_MEAN, request.Aggregation.PerSeriesAligner)
+}
+
+func TestGetMetricValuesNotSupported(t *testing.T) {
+	_, err := getMetricValues(nil, "project-id", &types.Access{Name: "access"}, types.MetricQuery{})
+
+	assert.NotNil(t, err)
+}
+
+func TestGetMetricResourceFilter(t *testing.T) {
+	filter, err := getMetricResourceFilter("project-id", &types.Database{Name: "db"})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.database_id = "project-id:db"`, filter)
+
+	filter, err = getMetricResourceFilter("project-id", &types.Cluster{Name: "cluster", Region: "us-west1"})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.cluster_name = "cluster" AND resource.labels.region = "us-west1"`, filter)
+}
+
+func TestGetMetricResourceFilterMemorystore(t *testing.T) {
+	filter, err := getMetricResourceFilter("project-id", &types.Database{ID: "projects/p/locations/us-west1/instances/cache", Type: types.MemorystoreDatabase})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.instance_id = "projects/p/locations/us-west1/instances/cache"`, filter)
+}
+
+func TestGetMetricResourceFilterFunction(t *testing.T) {
+	filter, err := getMetricResourceFilter("project-id", &types.Function{Name: "function", Region: "us-west1", Metadata: map[string]string{"environment": "GEN_1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.function_name = "function" AND resource.labels.region = "us-west1"`, filter)
+
+	filter, err = getMetricResourceFilter("project-id", &types.Function{Name: "function", Region: "us-west1", Metadata: map[string]string{"environment": "GEN_2", "service": "function"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.service_name = "function" AND resource.labels.location = "us-west1"`, filter)
+}
+
+func TestGetMetricResourceFilterGke(t *testing.T) {
+	filter, err := getMetricResourceFilter("project-id", &types.Cluster{Name: "cluster", Type: types.GkeCluster, Region: "us-west1-a"})
+	assert.Nil(t, err)
+	assert.Equal(t, `resource.labels.cluster_name = "cluster" AND resource.labels.location = "us-west1-a"`, filter)
+}
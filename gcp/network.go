@@ -0,0 +1,340 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// defaultNetwork is the name of the network created with the project
+const defaultNetwork = "default"
+
+// sharedVpcHostStatus is the Shared VPC role of the projects whose networks are used by service projects
+const sharedVpcHostStatus = "HOST"
+
+// serverlessConnectorFirewallPrefix is the name prefix of the firewall rules created for Serverless VPC Access connectors
+const serverlessConnectorFirewallPrefix = "aet-"
+
+// GetNetworks returns the VPC networks with the number of instances, forwarding rules, Cloud Routers, VPN gateways,
+// Serverless VPC Access connectors and peerings in them
+func (p gcpProvider) GetNetworks() ([]*types.Network, error) {
+	log.Debug("[GCP] Fetching networks")
+	var gNetworks []*compute.Network
+	err := p.computeClient.Networks.List(p.projectID).Pages(context.Background(), func(list *compute.NetworkList) error {
+		gNetworks = append(gNetworks, list.Items...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the networks, err: %s", err.Error())
+		return nil, err
+	}
+	resourceCounts, err := p.getResourceCountsByNetwork()
+	if err != nil {
+		return nil, err
+	}
+	sharedVpcHost, err := p.isSharedVpcHost()
+	if err != nil {
+		return nil, err
+	}
+	return getNetworks(gNetworks, resourceCounts, sharedVpcHost)
+}
+
+// isSharedVpcHost returns whether the networks of the project are shared with service projects, the resources of the
+// service projects cannot be counted from the host project
+func (p gcpProvider) isSharedVpcHost() (bool, error) {
+	project, err := p.computeClient.Projects.Get(p.projectID).Do()
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the project, err: %s", err.Error())
+		return false, err
+	}
+	return project.XpnProjectStatus == sharedVpcHostStatus, nil
+}
+
+func (p gcpProvider) getResourceCountsByNetwork() (map[string]int, error) {
+	var networkLinks []string
+	err := p.computeClient.Instances.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.InstanceAggregatedList) error {
+		for _, items := range list.Items {
+			for _, instance := range items.Instances {
+				for _, networkInterface := range instance.NetworkInterfaces {
+					networkLinks = append(networkLinks, networkInterface.Network)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the instances, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.ForwardingRules.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.ForwardingRuleAggregatedList) error {
+		for _, items := range list.Items {
+			for _, rule := range items.ForwardingRules {
+				networkLinks = append(networkLinks, rule.Network)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the forwarding rules, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.Routers.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.RouterAggregatedList) error {
+		for _, items := range list.Items {
+			for _, router := range items.Routers {
+				networkLinks = append(networkLinks, router.Network)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the routers, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.TargetVpnGateways.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.TargetVpnGatewayAggregatedList) error {
+		for _, items := range list.Items {
+			for _, gateway := range items.TargetVpnGateways {
+				networkLinks = append(networkLinks, gateway.Network)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the Classic VPN gateways, err: %s", err.Error())
+		return nil, err
+	}
+	err = p.computeClient.VpnGateways.AggregatedList(p.projectID).Pages(context.Background(), func(list *compute.VpnGatewayAggregatedList) error {
+		for _, items := range list.Items {
+			for _, gateway := range items.VpnGateways {
+				networkLinks = append(networkLinks, gateway.Network)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the HA VPN gateways, err: %s", err.Error())
+		return nil, err
+	}
+	var firewalls []*compute.Firewall
+	err = p.computeClient.Firewalls.List(p.projectID).Pages(context.Background(), func(list *compute.FirewallList) error {
+		firewalls = append(firewalls, list.Items...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the firewalls, err: %s", err.Error())
+		return nil, err
+	}
+	networkLinks = append(networkLinks, getServerlessConnectorNetworks(firewalls)...)
+	return getResourceCountsByNetwork(networkLinks), nil
+}
+
+// getServerlessConnectorNetworks returns the network of every Serverless VPC Access connector. The connectors are not
+// compute resources, they are found by the aet-<region>-<connector>-<rule> firewall rules created for them.
+func getServerlessConnectorNetworks(firewalls []*compute.Firewall) []string {
+	connectorNetworks := map[string]string{}
+	for _, firewall := range firewalls {
+		if !strings.HasPrefix(firewall.Name, serverlessConnectorFirewallPrefix) {
+			continue
+		}
+		connector := firewall.Name
+		if i := strings.LastIndex(connector, "-"); i > len(serverlessConnectorFirewallPrefix) {
+			connector = connector[:i]
+		}
+		connectorNetworks[connector] = firewall.Network
+	}
+	var networkLinks []string
+	for _, networkLink := range connectorNetworks {
+		networkLinks = append(networkLinks, networkLink)
+	}
+	return networkLinks
+}
+
+// getResourceCountsByNetwork counts the resources by the self link of their network, external forwarding rules
+// do not belong to any network
+func getResourceCountsByNetwork(networkLinks []string) map[string]int {
+	resourceCounts := map[string]int{}
+	for _, networkLink := range networkLinks {
+		if len(networkLink) > 0 {
+			resourceCounts[networkLink]++
+		}
+	}
+	return resourceCounts
+}
+
+// getNetworks converts the networks, the default network of the project is not collected. The peerings are counted,
+// because the private services access of Cloud SQL, Memorystore and Filestore is a peering too. The resources of the
+// networks of Shared VPC host projects are not counted, as the service projects cannot be seen from the host project.
+func getNetworks(gNetworks []*compute.Network, resourceCounts map[string]int, sharedVpcHost bool) ([]*types.Network, error) {
+	log.Debugf("[GCP] Processing networks (%d)", len(gNetworks))
+	networks := make([]*types.Network, 0)
+	for _, gNetwork := range gNetworks {
+		if gNetwork.Name == defaultNetwork {
+			log.Debugf("[GCP] Skipping default network: %s", gNetwork.Name)
+			continue
+		}
+		creationTimeStamp, err := utils.ConvertTimeRFC3339(gNetwork.CreationTimestamp)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of network, err: %s", err.Error())
+			return nil, err
+		}
+		metadata := map[string]string{"description": gNetwork.Description}
+		if !sharedVpcHost {
+			metadata[types.ResourceCountMetadataKey] = strconv.Itoa(resourceCounts[gNetwork.SelfLink] + len(gNetwork.Peerings))
+		}
+		networks = append(networks, &types.Network{
+			CloudType: types.GCP,
+			ID:        strconv.FormatUint(gNetwork.Id, 10),
+			Name:      gNetwork.Name,
+			Region:    globalRegion,
+			Created:   creationTimeStamp,
+			State:     types.Running,
+			Metadata:  metadata,
+			Tags:      types.Tags{},
+		})
+	}
+	return networks, nil
+}
+
+// DeleteNetworks deletes the firewall rules, the custom routes and the subnets of the networks, then the networks
+func (p gcpProvider) DeleteNetworks(networks *types.NetworkContainer) []error {
+	gNetworks := networks.Get(types.GCP)
+	log.Debugf("[GCP] Delete networks: %v", gNetworks)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gNetworks))
+	errChan := make(chan error)
+
+	for _, n := range gNetworks {
+		go func(network *types.Network) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, network is not deleted: %s", network.Name)
+				return
+			}
+			log.Infof("[GCP] Delete network: %s", network.Name)
+			if err := p.deleteNetwork(network.Name); err != nil {
+				log.Errorf("[GCP] Failed to delete network: %s, err: %s", network.Name, err.Error())
+				errChan <- err
+			}
+		}(n)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// deleteNetwork checks that the network is still empty, then deletes its firewall rules, custom routes and subnets,
+// so the traffic of a network in use is never broken by removing its rules
+func (p gcpProvider) deleteNetwork(networkName string) error {
+	gNetwork, err := p.computeClient.Networks.Get(p.projectID, networkName).Do()
+	if err != nil {
+		return err
+	}
+	resourceCounts, err := p.getResourceCountsByNetwork()
+	if err != nil {
+		return err
+	}
+	sharedVpcHost, err := p.isSharedVpcHost()
+	if err != nil {
+		return err
+	}
+	if err := checkNetworkDeletable(gNetwork, resourceCounts, sharedVpcHost); err != nil {
+		return err
+	}
+
+	var firewalls []*compute.Firewall
+	err = p.computeClient.Firewalls.List(p.projectID).Pages(context.Background(), func(list *compute.FirewallList) error {
+		firewalls = append(firewalls, list.Items...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, firewall := range firewalls {
+		if firewall.Network != gNetwork.SelfLink {
+			continue
+		}
+		log.Debugf("[GCP] Delete firewall: %s of network: %s", firewall.Name, networkName)
+		if err := p.doAndPollComputeCall(p.computeClient.Firewalls.Delete(p.projectID, firewall.Name)); err != nil {
+			return err
+		}
+	}
+
+	var routes []*compute.Route
+	err = p.computeClient.Routes.List(p.projectID).Pages(context.Background(), func(list *compute.RouteList) error {
+		routes = append(routes, list.Items...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, route := range getCustomRoutes(routes, gNetwork.SelfLink) {
+		log.Debugf("[GCP] Delete route: %s of network: %s", route, networkName)
+		if err := p.doAndPollComputeCall(p.computeClient.Routes.Delete(p.projectID, route)); err != nil {
+			return err
+		}
+	}
+
+	// the subnets of auto mode networks are deleted together with the network
+	if !gNetwork.AutoCreateSubnetworks {
+		for _, subnetwork := range gNetwork.Subnetworks {
+			region, name := getSubnetworkRegionAndName(subnetwork)
+			log.Debugf("[GCP] Delete subnet: %s of network: %s", name, networkName)
+			if err := p.doAndPollComputeCall(p.computeClient.Subnetworks.Delete(p.projectID, region, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return p.doAndPollComputeCall(p.computeClient.Networks.Delete(p.projectID, networkName))
+}
+
+// checkNetworkDeletable returns an error if the network has resources or peerings, or it is shared with service projects
+func checkNetworkDeletable(gNetwork *compute.Network, resourceCounts map[string]int, sharedVpcHost bool) error {
+	if sharedVpcHost {
+		return fmt.Errorf("network %s is not deleted, the project is a Shared VPC host", gNetwork.Name)
+	}
+	if count := resourceCounts[gNetwork.SelfLink] + len(gNetwork.Peerings); count > 0 {
+		return fmt.Errorf("network %s is not deleted, it has %d resources or peerings", gNetwork.Name, count)
+	}
+	return nil
+}
+
+// getCustomRoutes returns the names of the routes of the network, except the generated ones that are deleted with
+// the network and its subnets
+func getCustomRoutes(routes []*compute.Route, networkLink string) []string {
+	var names []string
+	for _, route := range routes {
+		if route.Network != networkLink || strings.HasPrefix(route.Name, "default-route-") || len(route.NextHopNetwork) > 0 || len(route.NextHopPeering) > 0 {
+			continue
+		}
+		names = append(names, route.Name)
+	}
+	return names
+}
+
+// getSubnetworkRegionAndName returns the region and the name from the url of a subnet, e.g.
+// https://www.googleapis.com/compute/v1/projects/p/regions/us-west1/subnetworks/s
+func getSubnetworkRegionAndName(url string) (string, string) {
+	parts := strings.Split(url, "/")
+	if len(parts) < 3 {
+		return "", url
+	}
+	return parts[len(parts)-3], parts[len(parts)-1]
+}
@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const networkLink = "https://www.googleapis.com/compute/v1/projects/p/global/networks/n"
+
+func TestGetNetworks(t *testing.T) {
+	gNetworks := []*compute.Network{
+		{Id: 1, Name: "n", SelfLink: networkLink, CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		{Id: 2, Name: "empty", SelfLink: "https://www.googleapis.com/compute/v1/projects/p/global/networks/empty", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		{Id: 3, Name: "default", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		{
+			Id:                4,
+			Name:              "peered",
+			SelfLink:          "https://www.googleapis.com/compute/v1/projects/p/global/networks/peered",
+			CreationTimestamp: "2018-05-25T11:23:23.000-07:00",
+			Peerings:          []*compute.NetworkPeering{{Name: "servicenetworking-googleapis-com"}},
+		},
+	}
+	resourceCounts := getResourceCountsByNetwork([]string{networkLink, networkLink, ""})
+
+	networks, err := getNetworks(gNetworks, resourceCounts, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(networks))
+	assert.Equal(t, "1", networks[0].ID)
+	assert.Equal(t, "global", networks[0].Region)
+	assert.Equal(t, "2", networks[0].Metadata[types.ResourceCountMetadataKey])
+	assert.Equal(t, "empty", networks[1].Name)
+	assert.Equal(t, "0", networks[1].Metadata[types.ResourceCountMetadataKey])
+	assert.Equal(t, "1", networks[2].Metadata[types.ResourceCountMetadataKey])
+}
+
+func TestGetNetworksOfSharedVpcHost(t *testing.T) {
+	gNetworks := []*compute.Network{{Id: 1, Name: "shared", SelfLink: networkLink, CreationTimestamp: "2018-05-25T11:23:23.000-07:00"}}
+
+	networks, err := getNetworks(gNetworks, map[string]int{}, true)
+
+	assert.Nil(t, err)
+	_, counted := networks[0].Metadata[types.ResourceCountMetadataKey]
+	assert.False(t, counted)
+}
+
+func TestGetServerlessConnectorNetworks(t *testing.T) {
+	firewalls := []*compute.Firewall{
+		{Name: "aet-uscentral1-connector-earfw", Network: networkLink},
+		{Name: "aet-uscentral1-connector-egrfw", Network: networkLink},
+		{Name: "allow-ssh", Network: networkLink},
+	}
+
+	assert.Equal(t, []string{networkLink}, getServerlessConnectorNetworks(firewalls))
+}
+
+func TestCheckNetworkDeletable(t *testing.T) {
+	empty := &compute.Network{Name: "empty", SelfLink: networkLink}
+	peered := &compute.Network{Name: "peered", SelfLink: networkLink, Peerings: []*compute.NetworkPeering{{Name: "peering"}}}
+
+	assert.Nil(t, checkNetworkDeletable(empty, map[string]int{}, false))
+	assert.NotNil(t, checkNetworkDeletable(empty, map[string]int{networkLink: 1}, false))
+	assert.NotNil(t, checkNetworkDeletable(peered, map[string]int{}, false))
+	assert.NotNil(t, checkNetworkDeletable(empty, map[string]int{}, true))
+}
+
+func TestGetCustomRoutes(t *testing.T) {
+	routes := []*compute.Route{
+		{Name: "default-route-1", Network: networkLink},
+		{Name: "peering-route-1", Network: networkLink, NextHopPeering: "peering"},
+		{Name: "custom", Network: networkLink},
+		{Name: "other", Network: "https://www.googleapis.com/compute/v1/projects/p/global/networks/other"},
+	}
+
+	assert.Equal(t, []string{"custom"}, getCustomRoutes(routes, networkLink))
+}
+
+func TestGetSubnetworkRegionAndName(t *testing.T) {
+	region, name := getSubnetworkRegionAndName("https://www.googleapis.com/compute/v1/projects/p/regions/us-west1/subnetworks/s")
+
+	assert.Equal(t, "us-west1", region)
+	assert.Equal(t, "s", name)
+}
@@ -0,0 +1,125 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	notebooks "google.golang.org/api/notebooks/v2"
+)
+
+func (p gcpProvider) GetNotebooks() ([]*types.Notebook, error) {
+	log.Debug("[GCP] Fetching Vertex AI Workbench notebooks")
+	var gInstances []*notebooks.Instance
+	err := p.notebooksClient.Projects.Locations.Instances.List(fmt.Sprintf("projects/%s/locations/-", p.projectID)).Pages(context.Background(), func(list *notebooks.ListInstancesResponse) error {
+		if len(list.Unreachable) > 0 {
+			log.Warnf("[GCP] Notebooks could not be fetched from locations: %s", list.Unreachable)
+		}
+		gInstances = append(gInstances, list.Instances...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the notebooks, err: %s", err.Error())
+		return nil, err
+	}
+	return getNotebooks(gInstances)
+}
+
+// getNotebooks converts the Workbench instances, the creator recorded by the service is used if there is no owner label.
+// The legacy user-managed and managed notebooks are retired, only the instances of the notebooks v2 API are listed.
+func getNotebooks(gInstances []*notebooks.Instance) ([]*types.Notebook, error) {
+	log.Debugf("[GCP] Processing notebooks (%d)", len(gInstances))
+	result := make([]*types.Notebook, 0)
+	for _, gInstance := range gInstances {
+		created, err := utils.ConvertTimeRFC3339(gInstance.CreateTime)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of notebook, err: %s", err.Error())
+			return nil, err
+		}
+		lastModified, err := utils.ConvertTimeRFC3339(gInstance.UpdateTime)
+		if err != nil {
+			lastModified = created
+		}
+		machineType := ""
+		if gInstance.GceSetup != nil {
+			machineType = getZone(gInstance.GceSetup.MachineType)
+		}
+		notebook := &types.Notebook{
+			ID:           gInstance.Name,
+			Name:         getZone(gInstance.Name),
+			Type:         types.WorkbenchNotebook,
+			InstanceType: machineType,
+			Created:      created,
+			LastModified: lastModified,
+			State:        getNotebookState(gInstance.State),
+			Owner:        gInstance.Labels[ctx.OwnerLabel],
+			CloudType:    types.GCP,
+			Region:       getLocation(gInstance.Name),
+			Metadata:     map[string]string{"proxyUri": gInstance.ProxyUri},
+			Tags:         gInstance.Labels,
+		}
+		if len(gInstance.Creator) > 0 {
+			notebook.Metadata = setPrincipalEmail(notebook.Metadata, gInstance.Creator)
+			notebook.Owner = getInferredOwner(notebook.Owner, gInstance.Creator)
+		}
+		result = append(result, notebook)
+	}
+	return result, nil
+}
+
+func getNotebookState(state string) types.State {
+	switch state {
+	case "STARTING", "PROVISIONING", "INITIALIZING", "REGISTERING":
+		return types.Starting
+	case "ACTIVE":
+		return types.Running
+	case "STOPPING", "SUSPENDING":
+		return types.Stopping
+	case "STOPPED", "SUSPENDED":
+		return types.Stopped
+	case "UPGRADING":
+		return types.Updating
+	case "DELETED":
+		return types.Terminated
+	}
+	return types.Unknown
+}
+
+func (p gcpProvider) StopNotebooks(notebookContainer *types.NotebookContainer) []error {
+	gcpNotebooks := notebookContainer.Get(types.GCP)
+	log.Debugf("[GCP] Stopping notebooks: %v", gcpNotebooks)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpNotebooks))
+	errChan := make(chan error)
+
+	for _, n := range gcpNotebooks {
+		go func(notebook *types.Notebook) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, notebook is not stopped: %s", notebook.Name)
+				return
+			}
+			log.Infof("[GCP] Sending request to stop notebook in zone %s : %s", notebook.Region, notebook.Name)
+			if _, err := p.notebooksClient.Projects.Locations.Instances.Stop(notebook.ID, &notebooks.StopInstanceRequest{}).Do(); err != nil {
+				errChan <- err
+			}
+		}(n)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
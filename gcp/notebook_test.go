@@ -0,0 +1,46 @@
+package gcp
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	notebooks "google.golang.org/api/notebooks/v2"
+)
+
+func TestGetNotebooks(t *testing.T) {
+	gInstances := []*notebooks.Instance{
+		{
+			Name:       "projects/p/locations/us-west1-a/instances/labelled",
+			GceSetup:   &notebooks.GceSetup{MachineType: computeURL + "zones/us-west1-a/machineTypes/n1-standard-4"},
+			State:      "ACTIVE",
+			CreateTime: "2018-05-25T11:23:23+00:00",
+			UpdateTime: "2018-06-25T11:23:23+00:00",
+			Labels:     map[string]string{ctx.OwnerLabel: "owner"},
+			Creator:    "creator@example.com",
+		},
+		{
+			Name:       "projects/p/locations/us-west1-b/instances/stopped",
+			State:      "STOPPED",
+			CreateTime: "2018-05-25T11:23:23+00:00",
+		},
+	}
+
+	result, err := getNotebooks(gInstances)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result))
+	labelled := result[0]
+	assert.Equal(t, "labelled", labelled.Name)
+	assert.Equal(t, types.WorkbenchNotebook, labelled.Type)
+	assert.Equal(t, "n1-standard-4", labelled.InstanceType)
+	assert.Equal(t, "us-west1-a", labelled.Region)
+	assert.Equal(t, types.Running, labelled.State)
+	assert.Equal(t, "owner", labelled.Owner)
+	assert.Equal(t, "creator@example.com", labelled.Metadata["PrincipalEmail"])
+	assert.True(t, labelled.LastModified.After(labelled.Created))
+	stopped := result[1]
+	assert.Equal(t, types.Stopped, stopped.State)
+	assert.Equal(t, stopped.Created, stopped.LastModified)
+}
@@ -0,0 +1,291 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	logging "google.golang.org/api/logging/v2"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// auditLogLookbackPeriod is the period Cloud Audit Logs keeps the admin activity logs for
+var auditLogLookbackPeriod = 400 * 24 * time.Hour
+
+// ownerResource is a resource without owner whose creator is looked up in the admin activity audit logs
+type ownerResource struct {
+	path       string
+	created    time.Time
+	methodName string
+	setOwner   func(principalEmail string)
+}
+
+type auditLogPayload struct {
+	ResourceName       string `json:"resourceName"`
+	AuthenticationInfo struct {
+		PrincipalEmail string `json:"principalEmail"`
+	} `json:"authenticationInfo"`
+}
+
+func newInstanceOwnerResources(instances []*types.Instance) []*ownerResource {
+	var resources []*ownerResource
+	for _, i := range instances {
+		if len(i.Owner) == 0 {
+			inst := i
+			path := fmt.Sprintf("zones/%s/instances/%s", inst.Metadata["zone"], inst.Name)
+			resources = append(resources, &ownerResource{path, inst.Created, "compute.instances.insert", func(principalEmail string) {
+				inst.Metadata = setPrincipalEmail(inst.Metadata, principalEmail)
+				inst.Owner = getInferredOwner(inst.Owner, principalEmail)
+			}})
+		}
+	}
+	return resources
+}
+
+func newDiskOwnerResources(disks []*types.Disk) []*ownerResource {
+	var resources []*ownerResource
+	for _, d := range disks {
+		if len(d.Owner) == 0 {
+			disk := d
+			path := fmt.Sprintf("zones/%s/disks/%s", disk.Metadata["zone"], disk.Name)
+			methodName := "compute.disks.insert"
+			if len(disk.Metadata["zone"]) == 0 {
+				path = fmt.Sprintf("regions/%s/disks/%s", getZone(disk.Region), disk.Name)
+				methodName = "compute.regionDisks.insert"
+			}
+			resources = append(resources, &ownerResource{path, disk.Created, methodName, func(principalEmail string) {
+				disk.Metadata = setPrincipalEmail(disk.Metadata, principalEmail)
+				disk.Owner = getInferredOwner(disk.Owner, principalEmail)
+			}})
+		}
+	}
+	return resources
+}
+
+func newDatabaseOwnerResources(databases []*types.Database) []*ownerResource {
+	var resources []*ownerResource
+	for _, d := range databases {
+		if len(d.Owner) == 0 {
+			db := d
+			resources = append(resources, &ownerResource{"instances/" + db.Name, db.Created, "cloudsql.instances.create", func(principalEmail string) {
+				db.Metadata = setPrincipalEmail(db.Metadata, principalEmail)
+				db.Owner = getInferredOwner(db.Owner, principalEmail)
+			}})
+		}
+	}
+	return resources
+}
+
+func newClusterOwnerResources(clusters []*types.Cluster) []*ownerResource {
+	var resources []*ownerResource
+	for _, c := range clusters {
+		if len(c.Owner) == 0 {
+			cluster := c
+			path := fmt.Sprintf("regions/%s/clusters/%s", cluster.Region, cluster.Name)
+			methodName := "ClusterController.CreateCluster"
+			if cluster.Type == types.GkeCluster {
+				path = fmt.Sprintf("locations/%s/clusters/%s", cluster.Region, cluster.Name)
+				methodName = "ClusterManager.CreateCluster"
+			}
+			resources = append(resources, &ownerResource{path, cluster.Created, methodName, func(principalEmail string) {
+				cluster.Metadata = setPrincipalEmail(cluster.Metadata, principalEmail)
+				cluster.Owner = getInferredOwner(cluster.Owner, principalEmail)
+			}})
+		}
+	}
+	return resources
+}
+
+func setPrincipalEmail(metadata map[string]string, principalEmail string) map[string]string {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["PrincipalEmail"] = principalEmail
+	return metadata
+}
+
+func getInferredOwner(owner, principalEmail string) string {
+	if len(owner) == 0 && utils.IsInferredOwnerPromoted() {
+		return principalEmail
+	}
+	return owner
+}
+
+type logEntriesAggregator interface {
+	Pages(context.Context, func(*logging.ListLogEntriesResponse) error) error
+}
+
+func (p gcpProvider) inferOwners(resources []*ownerResource) {
+	if !ctx.OwnerInference || len(resources) == 0 {
+		return
+	}
+	inferOwners(func(request *logging.ListLogEntriesRequest) logEntriesAggregator {
+		return p.loggingClient.Entries.List(request)
+	}, p.projectID, utils.GetOwnerCache(), resources)
+}
+
+// inferOwners sets the creators of the resources from the cache or the admin activity audit logs. The creators of
+// all the resources are looked up in a single query instead of one query per resource. The audit logs are optional,
+// if they cannot be read the resources are left without creator.
+func inferOwners(getAggregator func(*logging.ListLogEntriesRequest) logEntriesAggregator, projectID string, cache *utils.OwnerCache, resources []*ownerResource) {
+	var missing []*ownerResource
+	for _, resource := range resources {
+		if owner, ok := cache.Get(getOwnerCacheKey(projectID, resource)); !ok {
+			missing = append(missing, resource)
+		} else if len(owner) > 0 {
+			resource.setOwner(owner)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	creators, err := lookupCreators(getAggregator, projectID, missing)
+	if err != nil {
+		log.Warnf("[GCP] Failed to fetch the admin activity audit logs, err: %s", err.Error())
+		return
+	}
+	for _, resource := range missing {
+		owner := creators[resource.path]
+		cache.Set(getOwnerCacheKey(projectID, resource), owner)
+		if len(owner) > 0 {
+			resource.setOwner(owner)
+		}
+	}
+	cache.Save()
+}
+
+func getOwnerCacheKey(projectID string, resource *ownerResource) string {
+	return "GCP:" + projectID + ":" + resource.path
+}
+
+// lookupCreators returns the principal emails by the path of the resources created in the audit log entries
+func lookupCreators(getAggregator func(*logging.ListLogEntriesRequest) logEntriesAggregator, projectID string, resources []*ownerResource) (map[string]string, error) {
+	now := time.Now()
+	startTime := now
+	methodNames := map[string]bool{}
+	for _, resource := range resources {
+		if resource.created.Add(-time.Hour).Before(startTime) {
+			startTime = resource.created.Add(-time.Hour)
+		}
+		methodNames[resource.methodName] = true
+	}
+	if startTime.Before(now.Add(-auditLogLookbackPeriod)) {
+		startTime = now.Add(-auditLogLookbackPeriod)
+	}
+	var methodFilters []string
+	for methodName := range methodNames {
+		methodFilters = append(methodFilters, fmt.Sprintf("protoPayload.methodName:%q", methodName))
+	}
+	filter := fmt.Sprintf("logName=\"projects/%s/logs/cloudaudit.googleapis.com%%2Factivity\" AND (%s) AND timestamp>=%q",
+		projectID, strings.Join(methodFilters, " OR "), startTime.UTC().Format(time.RFC3339))
+	log.Debugf("[GCP] Looking up the creators in the audit logs: %s", filter)
+
+	creators := map[string]string{}
+	request := &logging.ListLogEntriesRequest{ResourceNames: []string{"projects/" + projectID}, Filter: filter, PageSize: 1000}
+	err := getAggregator(request).Pages(context.Background(), func(resp *logging.ListLogEntriesResponse) error {
+		for _, entry := range resp.Entries {
+			var payload auditLogPayload
+			if err := json.Unmarshal(entry.ProtoPayload, &payload); err != nil {
+				return err
+			}
+			if len(payload.AuthenticationInfo.PrincipalEmail) > 0 {
+				creators[strings.TrimPrefix(payload.ResourceName, "projects/"+projectID+"/")] = payload.AuthenticationInfo.PrincipalEmail
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return creators, nil
+}
+
+// invalidLabelCharacters are the characters not allowed in the label values
+var invalidLabelCharacters = regexp.MustCompile("[^a-z0-9_-]")
+
+// getOwnerLabelValue converts the owner to a valid label value, e.g. john.doe@example.com to john_doe_example_com
+func getOwnerLabelValue(owner string) string {
+	value := invalidLabelCharacters.ReplaceAllString(strings.ToLower(owner), "_")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return value
+}
+
+func getOwnerLabels(labels map[string]string, owner string) map[string]string {
+	ownerLabels := map[string]string{}
+	for k, v := range labels {
+		ownerLabels[k] = v
+	}
+	ownerLabels[ctx.OwnerLabel] = getOwnerLabelValue(owner)
+	return ownerLabels
+}
+
+// TagOwners labels the items with their owner. The instances and disks are fetched again, so the owner label is added
+// to their current labels with the current label fingerprint.
+func (p gcpProvider) TagOwners(items []types.CloudItem) []error {
+	log.Debug("[GCP] Labeling owners")
+	var errs []error
+	for _, item := range items {
+		var call func() error
+		switch t := item.(type) {
+		case *types.Instance:
+			call = func() error {
+				gInstance, err := p.computeClient.Instances.Get(p.projectID, t.Metadata["zone"], t.Name).Do()
+				if err != nil {
+					return err
+				}
+				request := &compute.InstancesSetLabelsRequest{Labels: getOwnerLabels(gInstance.Labels, t.Owner), LabelFingerprint: gInstance.LabelFingerprint}
+				return p.doAndPollComputeCall(p.computeClient.Instances.SetLabels(p.projectID, t.Metadata["zone"], t.Name, request))
+			}
+		case *types.Disk:
+			call = func() error {
+				if zone := t.Metadata["zone"]; len(zone) > 0 {
+					gDisk, err := p.computeClient.Disks.Get(p.projectID, zone, t.Name).Do()
+					if err != nil {
+						return err
+					}
+					return p.doAndPollComputeCall(p.computeClient.Disks.SetLabels(p.projectID, zone, t.Name,
+						&compute.ZoneSetLabelsRequest{Labels: getOwnerLabels(gDisk.Labels, t.Owner), LabelFingerprint: gDisk.LabelFingerprint}))
+				}
+				gDisk, err := p.computeClient.RegionDisks.Get(p.projectID, getZone(t.Region), t.Name).Do()
+				if err != nil {
+					return err
+				}
+				return p.doAndPollComputeCall(p.computeClient.RegionDisks.SetLabels(p.projectID, getZone(t.Region), t.Name,
+					&compute.RegionSetLabelsRequest{Labels: getOwnerLabels(gDisk.Labels, t.Owner), LabelFingerprint: gDisk.LabelFingerprint}))
+			}
+		case *types.Database:
+			instance := &sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{UserLabels: getOwnerLabels(t.Tags, t.Owner)}}
+			call = func() error {
+				return p.doAndPollSqlCall(p.sqlClient.Instances.Patch(p.projectID, t.Name, instance))
+			}
+		case *types.Stack:
+			log.Warnf("[GCP] Stack %s is assembled from labels and has no resource to label, label its instances instead", t.Name)
+			continue
+		default:
+			log.Debugf("[GCP] Labeling owner is not supported for %s: %s", item.GetType(), item.GetName())
+			continue
+		}
+		value := getOwnerLabelValue(item.GetOwner())
+		if ctx.DryRun {
+			log.Infof("[GCP] Dry-run set, %s %s is not labeled with owner %s", item.GetType(), item.GetName(), value)
+			continue
+		}
+		log.Infof("[GCP] Labeling %s %s with owner %s", item.GetType(), item.GetName(), value)
+		if err := call(); err != nil {
+			log.Errorf("[GCP] Failed to label %s %s with owner %s, err: %s", item.GetType(), item.GetName(), value, err)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,117 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	"github.com/stretchr/testify/assert"
+	logging "google.golang.org/api/logging/v2"
+)
+
+type mockLogEntriesAggregator struct {
+	requests *[]*logging.ListLogEntriesRequest
+	request  *logging.ListLogEntriesRequest
+	err      error
+}
+
+func (a mockLogEntriesAggregator) Pages(_ context.Context, f func(*logging.ListLogEntriesResponse) error) error {
+	*a.requests = append(*a.requests, a.request)
+	if a.err != nil {
+		return a.err
+	}
+	return f(&logging.ListLogEntriesResponse{Entries: []*logging.LogEntry{
+		{ProtoPayload: []byte(`{"resourceName":"projects/project-id/zones/us-central1-a/instances/instance","authenticationInfo":{"principalEmail":"creator@example.com"}}`)},
+		{ProtoPayload: []byte(`{"resourceName":"instances/database","authenticationInfo":{"principalEmail":"dba@example.com"}}`)},
+	}})
+}
+
+func newMockLogEntriesAggregator(requests *[]*logging.ListLogEntriesRequest, err error) func(*logging.ListLogEntriesRequest) logEntriesAggregator {
+	return func(request *logging.ListLogEntriesRequest) logEntriesAggregator {
+		return mockLogEntriesAggregator{requests, request, err}
+	}
+}
+
+func newOwnerTestInstances() []*types.Instance {
+	return []*types.Instance{
+		{Name: "instance", Created: time.Now(), Metadata: map[string]string{"zone": "us-central1-a"}},
+		{Name: "other", Created: time.Now(), Metadata: map[string]string{"zone": "us-central1-a"}},
+		{Name: "labeled", Created: time.Now(), Owner: "owner", Metadata: map[string]string{"zone": "us-central1-a"}},
+	}
+}
+
+func TestInferOwners(t *testing.T) {
+	var requests []*logging.ListLogEntriesRequest
+	instances := newOwnerTestInstances()
+	databases := []*types.Database{{Name: "database", Created: time.Now()}}
+	resources := append(newInstanceOwnerResources(instances), newDatabaseOwnerResources(databases)...)
+
+	inferOwners(newMockLogEntriesAggregator(&requests, nil), "project-id", utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json")), resources)
+
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, []string{"projects/project-id"}, requests[0].ResourceNames)
+	assert.Contains(t, requests[0].Filter, `protoPayload.methodName:"compute.instances.insert"`)
+	assert.Contains(t, requests[0].Filter, `protoPayload.methodName:"cloudsql.instances.create"`)
+	assert.Equal(t, "creator@example.com", instances[0].Metadata["PrincipalEmail"])
+	assert.Equal(t, "", instances[0].Owner)
+	assert.Equal(t, "", instances[1].Metadata["PrincipalEmail"])
+	assert.Equal(t, "", instances[2].Metadata["PrincipalEmail"])
+	assert.Equal(t, "dba@example.com", databases[0].Metadata["PrincipalEmail"])
+}
+
+func TestInferOwnersFromCache(t *testing.T) {
+	var requests []*logging.ListLogEntriesRequest
+	cacheFile := filepath.Join(t.TempDir(), "owners.json")
+	inferOwners(newMockLogEntriesAggregator(&requests, nil), "project-id", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(newOwnerTestInstances()))
+	instances := newOwnerTestInstances()
+
+	inferOwners(newMockLogEntriesAggregator(&requests, nil), "project-id", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "creator@example.com", instances[0].Metadata["PrincipalEmail"])
+}
+
+func TestInferOwnersPromoted(t *testing.T) {
+	os.Setenv("INFERRED_OWNER_AS_OWNER", "true")
+	defer os.Unsetenv("INFERRED_OWNER_AS_OWNER")
+	var requests []*logging.ListLogEntriesRequest
+	instances := newOwnerTestInstances()
+
+	inferOwners(newMockLogEntriesAggregator(&requests, nil), "project-id", utils.LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json")), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, "creator@example.com", instances[0].Owner)
+	assert.Equal(t, "owner", instances[2].Owner)
+}
+
+func TestInferOwnersAuditLogsUnavailable(t *testing.T) {
+	var requests []*logging.ListLogEntriesRequest
+	cacheFile := filepath.Join(t.TempDir(), "owners.json")
+	instances := newOwnerTestInstances()
+
+	inferOwners(newMockLogEntriesAggregator(&requests, errors.New("logging api is disabled")), "project-id", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+	inferOwners(newMockLogEntriesAggregator(&requests, nil), "project-id", utils.LoadOwnerCache(cacheFile), newInstanceOwnerResources(instances))
+
+	assert.Equal(t, 2, len(requests))
+	assert.Equal(t, "creator@example.com", instances[0].Metadata["PrincipalEmail"])
+}
+
+func TestGetOwnerLabelValue(t *testing.T) {
+	assert.Equal(t, "john_doe_example_com", getOwnerLabelValue("John.Doe@example.com"))
+	assert.Equal(t, 63, len(getOwnerLabelValue(strings.Repeat("a", 100))))
+}
+
+func TestGetOwnerLabels(t *testing.T) {
+	tags := map[string]string{"key": "value"}
+
+	labels := getOwnerLabels(tags, "creator@example.com")
+
+	assert.Equal(t, map[string]string{"key": "value", ctx.OwnerLabel: "creator_example_com"}, labels)
+	assert.Equal(t, map[string]string{"key": "value"}, tags)
+}
@@ -0,0 +1,106 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/blentz/cloud-haunter/utils"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func (p gcpProvider) GetSnapshots() ([]*types.Snapshot, error) {
+	log.Debug("[GCP] Fetching snapshots")
+	disks, err := p.getDisks()
+	if err != nil {
+		return nil, err
+	}
+	var gSnapshots []*compute.Snapshot
+	err = p.computeClient.Snapshots.List(p.projectID).Pages(context.Background(), func(list *compute.SnapshotList) error {
+		gSnapshots = append(gSnapshots, list.Items...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[GCP] Failed to fetch the snapshots, err: %s", err.Error())
+		return nil, err
+	}
+	return getSnapshots(gSnapshots, getExistingDisks(disks))
+}
+
+// getExistingDisks returns the IDs of the disks, they are compared to the source disk IDs of the snapshots
+func getExistingDisks(disks []*types.Disk) map[string]bool {
+	existingDisks := map[string]bool{}
+	for _, disk := range disks {
+		existingDisks[disk.ID] = true
+	}
+	return existingDisks
+}
+
+// getSnapshots converts the snapshots, a snapshot is orphaned if its source disk no longer exists
+func getSnapshots(gSnapshots []*compute.Snapshot, existingDisks map[string]bool) ([]*types.Snapshot, error) {
+	log.Debugf("[GCP] Processing snapshots (%d)", len(gSnapshots))
+	snapshots := make([]*types.Snapshot, 0)
+	for _, gSnapshot := range gSnapshots {
+		creationTimeStamp, err := utils.ConvertTimeRFC3339(gSnapshot.CreationTimestamp)
+		if err != nil {
+			log.Errorf("[GCP] Failed to get the creation timestamp of snapshot, err: %s", err.Error())
+			return nil, err
+		}
+		state := types.Available
+		if !existingDisks[gSnapshot.SourceDiskId] {
+			state = types.Orphaned
+		}
+		snapshots = append(snapshots, &types.Snapshot{
+			CloudType:  types.GCP,
+			ID:         gSnapshot.SelfLink,
+			Name:       gSnapshot.Name,
+			Region:     strings.Join(gSnapshot.StorageLocations, ","),
+			Created:    creationTimeStamp,
+			Size:       gSnapshot.DiskSizeGb,
+			SourceDisk: getZone(gSnapshot.SourceDisk),
+			State:      state,
+			Owner:      gSnapshot.Labels[ctx.OwnerLabel],
+			Metadata:   map[string]string{"sourceDiskId": gSnapshot.SourceDiskId},
+			Tags:       gSnapshot.Labels,
+		})
+	}
+	return snapshots, nil
+}
+
+func (p gcpProvider) DeleteSnapshots(snapshots *types.SnapshotContainer) []error {
+	gcpSnapshots := snapshots.Get(types.GCP)
+	log.Debugf("[GCP] Deleting snapshots: %v", gcpSnapshots)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(gcpSnapshots))
+	errChan := make(chan error)
+
+	for _, s := range gcpSnapshots {
+		go func(snapshot *types.Snapshot) {
+			defer wg.Done()
+
+			if ctx.DryRun {
+				log.Infof("[GCP] Dry-run set, snapshot is not deleted: %s", snapshot.Name)
+			} else {
+				log.Infof("[GCP] Sending request to delete snapshot: %s", snapshot.Name)
+				if _, err := p.computeClient.Snapshots.Delete(p.projectID, snapshot.Name).Do(); err != nil {
+					errChan <- err
+				}
+			}
+		}(s)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return errs
+}
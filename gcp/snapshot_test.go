@@ -0,0 +1,69 @@
+package gcp
+
+import (
+	"testing"
+
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestGetSnapshots(t *testing.T) {
+	gSnapshots := []*compute.Snapshot{
+		{
+			Name:              "backup",
+			CreationTimestamp: "2018-05-25T11:23:23.000-07:00",
+			DiskSizeGb:        10,
+			SourceDisk:        "https://www.googleapis.com/compute/v1/projects/p/zones/us-west1-a/disks/disk",
+			SourceDiskId:      "1",
+			StorageLocations:  []string{"us"},
+			Labels:            map[string]string{ctx.OwnerLabel: "owner"},
+		},
+		{Name: "orphan", CreationTimestamp: "2018-05-25T11:23:23.000-07:00", SourceDiskId: "2"},
+	}
+
+	snapshots, err := getSnapshots(gSnapshots, map[string]bool{"1": true})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, "backup", snapshots[0].Name)
+	assert.Equal(t, "disk", snapshots[0].SourceDisk)
+	assert.Equal(t, int64(10), snapshots[0].Size)
+	assert.Equal(t, "us", snapshots[0].Region)
+	assert.Equal(t, "owner", snapshots[0].Owner)
+	assert.Equal(t, types.Available, snapshots[0].State)
+	assert.Equal(t, types.Orphaned, snapshots[1].State)
+}
+
+func TestGetSnapshotsInvalidTimestamp(t *testing.T) {
+	_, err := getSnapshots([]*compute.Snapshot{{Name: "snapshot", CreationTimestamp: "yesterday"}}, map[string]bool{})
+
+	assert.NotNil(t, err)
+}
+
+func TestGetSnapshotsOfDisksOnAllPages(t *testing.T) {
+	diskLists := []*compute.DiskAggregatedList{
+		{Items: map[string]compute.DisksScopedList{"zones/us-west1-a": {Disks: []*compute.Disk{
+			{Id: 1, Name: "disk", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		}}}},
+		{Items: map[string]compute.DisksScopedList{"zones/us-west1-a": {Disks: []*compute.Disk{
+			{Id: 9223372036854775809, Name: "large-id", CreationTimestamp: "2018-05-25T11:23:23.000-07:00"},
+		}}}},
+	}
+	gSnapshots := []*compute.Snapshot{
+		{Name: "first", CreationTimestamp: "2018-05-25T11:23:23.000-07:00", SourceDiskId: "1"},
+		{Name: "second", CreationTimestamp: "2018-05-25T11:23:23.000-07:00", SourceDiskId: "9223372036854775809"},
+		{Name: "orphan", CreationTimestamp: "2018-05-25T11:23:23.000-07:00", SourceDiskId: "2"},
+	}
+
+	disks, err := getDisks(diskLists)
+	assert.Nil(t, err)
+	snapshots, err := getSnapshots(gSnapshots, getExistingDisks(disks))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "9223372036854775809", disks[1].ID)
+	assert.Equal(t, types.Available, snapshots[0].State)
+	assert.Equal(t, types.Available, snapshots[1].State)
+	assert.Equal(t, types.Orphaned, snapshots[2].State)
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
 	"github.com/blentz/cloud-haunter/types"
 	"github.com/blentz/cloud-haunter/utils"
 	log "github.com/sirupsen/logrus"
@@ -56,15 +57,27 @@ func (d hipchatDispatcher) Send(op types.OpType, filters []types.FilterType, ite
 	if ctx.DryRun {
 		log.Info("[HIPCHAT] Skipping notification on dry run session")
 	} else {
-		return send(d.room, message, d.client.Room)
+		return send(d.room, message, "green", d.client.Room)
 	}
 	return nil
 }
 
+func (d hipchatDispatcher) SendMessage(message string) error {
+	log.Debugf("[HIPCHAT] Message is: %s", message)
+	if ctx.DryRun {
+		log.Info("[HIPCHAT] Skipping notification on dry run session")
+		return nil
+	}
+	return send(d.room, message, "red", d.client.Room)
+}
+
 func (d *hipchatDispatcher) generateMessage(op types.OpType, filters []types.FilterType, items []types.CloudItem) string {
 	var buffer bytes.Buffer
 	buffer.WriteString("/code\n")
 	buffer.WriteString(fmt.Sprintf("Operation: %s Filters: %s Accounts: %s\n", op, utils.GetFilterNames(filters), utils.GetCloudAccountNames()))
+	if total := pricing.EstimateTotal(items); total.Monthly > 0 {
+		buffer.WriteString(fmt.Sprintf("Estimated waste: %s\n", total))
+	}
 	for _, item := range items {
 		displayTime := item.GetCreated().Format("2006-01-02 15:04:05")
 		switch item.GetItem().(type) {
@@ -74,6 +87,7 @@ func (d *hipchatDispatcher) generateMessage(op types.OpType, filters []types.Fil
 			if len(inst.Metadata) > 0 {
 				msg += fmt.Sprintf(" metadata: %s", inst.Metadata)
 			}
+			msg += getCost(item)
 			msg += "\n"
 			buffer.WriteString(msg)
 		case types.Database:
@@ -82,23 +96,47 @@ func (d *hipchatDispatcher) generateMessage(op types.OpType, filters []types.Fil
 			if len(db.Metadata) > 0 {
 				msg += fmt.Sprintf(" metadata: %s", db.Metadata)
 			}
+			msg += getCost(item)
 			msg += "\n"
 			buffer.WriteString(msg)
 		default:
-			buffer.WriteString(fmt.Sprintf("[%s] %s: %s created: %s owner: %s\n", item.GetCloudType(), item.GetType(), item.GetName(), displayTime, item.GetOwner()))
+			buffer.WriteString(fmt.Sprintf("[%s] %s: %s created: %s owner: %s%s%s%s\n", item.GetCloudType(), item.GetType(), item.GetName(), displayTime, item.GetOwner(), getViolations(item), getIdleMetrics(item), getCost(item)))
 		}
 	}
 	return buffer.String()
 }
 
+// getViolations returns the tag policy violations of the items whose metadata is not printed, the metadata of the
+// instances and databases contains them already
+func getViolations(item types.CloudItem) string {
+	if violations := types.GetMetadata(item)[types.TagPolicyViolationsKey]; len(violations) > 0 {
+		return fmt.Sprintf(" tag violations: %s", violations)
+	}
+	return ""
+}
+
+func getIdleMetrics(item types.CloudItem) string {
+	if measurements := types.GetMetadata(item)[types.IdleMetricsKey]; len(measurements) > 0 {
+		return fmt.Sprintf(" idle: %s", measurements)
+	}
+	return ""
+}
+
+func getCost(item types.CloudItem) string {
+	if cost, ok := pricing.Estimate(item); ok {
+		return fmt.Sprintf(" cost: %s", cost)
+	}
+	return ""
+}
+
 type notificationClient interface {
 	Notification(string, *hipchat.NotificationRequest) (*http.Response, error)
 }
 
-func send(room, message string, client notificationClient) error {
+func send(room, message string, color hipchat.Color, client notificationClient) error {
 	_, err := client.Notification(room, &hipchat.NotificationRequest{
 		Message:       message,
-		Color:         "green",
+		Color:         color,
 		MessageFormat: "text",
 	})
 	return err
@@ -13,7 +13,7 @@ import (
 func TestSend(t *testing.T) {
 	mockClient := mockNotificationClient{make(chan *hipchat.NotificationRequest, 0)}
 
-	go send("room", "message", mockClient)
+	go send("room", "message", "green", mockClient)
 
 	notifReq := <-mockClient.notifReqChan
 
@@ -45,6 +45,71 @@ func TestGenerateMessage(t *testing.T) {
 	assert.Equal(t, "/code\nOperation: getInstances Filters: longrunning Accounts: map[]\n[AWS] instance: instance type: large created: 1970-01-01 00:00:00 owner: owner region: region\n[AWS] access: access created: 1970-01-01 00:00:00 owner: owner\n", message)
 }
 
+func TestGenerateMessageWithCost(t *testing.T) {
+	items := []types.CloudItem{
+		types.Instance{
+			CloudType:    types.AWS,
+			Name:         "instance",
+			Created:      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			Owner:        "owner",
+			Region:       "us-east-1",
+			InstanceType: "m5.large",
+			State:        types.Running,
+		},
+	}
+	op := types.Instances
+	message := dispatcher.generateMessage(op, []types.FilterType{types.LongRunningFilter}, items)
+
+	assert.Equal(t, "/code\nOperation: getInstances Filters: longrunning Accounts: map[]\nEstimated waste: $0.0960/hour, $70.08/month\n[AWS] instance: instance type: m5.large created: 1970-01-01 00:00:00 owner: owner region: us-east-1 cost: $0.0960/hour, $70.08/month\n", message)
+}
+
+func TestGenerateMessageWithViolations(t *testing.T) {
+	items := []types.CloudItem{
+		types.Stack{
+			CloudType: types.AWS,
+			Name:      "stack",
+			Created:   time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			Owner:     "owner",
+			Metadata:  map[string]string{types.TagPolicyViolationsKey: "missing tag: env"},
+		},
+	}
+	message := dispatcher.generateMessage(types.Stacks, []types.FilterType{types.NoncompliantFilter}, items)
+
+	assert.Equal(t, "/code\nOperation: getStacks Filters: noncompliant Accounts: map[]\n[AWS] stack: stack created: 1970-01-01 00:00:00 owner: owner tag violations: missing tag: env\n", message)
+}
+
+func TestGenerateMessageWithInstanceViolations(t *testing.T) {
+	items := []types.CloudItem{
+		types.Instance{
+			CloudType:    types.AWS,
+			Name:         "instance",
+			InstanceType: "large",
+			Created:      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			Owner:        "owner",
+			Region:       "us-east-1",
+			Metadata:     map[string]string{types.TagPolicyViolationsKey: "missing tag: env"},
+		},
+	}
+	message := dispatcher.generateMessage(types.Instances, []types.FilterType{types.NoncompliantFilter}, items)
+
+	assert.Equal(t, "/code\nOperation: getInstances Filters: noncompliant Accounts: map[]\n[AWS] instance: instance type: large created: 1970-01-01 00:00:00 owner: owner region: us-east-1 metadata: map[tagPolicyViolations:missing tag: env]\n", message)
+}
+
+func TestGenerateMessageWithIdleMetrics(t *testing.T) {
+	items := []types.CloudItem{
+		types.Cluster{
+			CloudType: types.GCP,
+			Name:      "cluster",
+			Created:   time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			Owner:     "owner",
+			Metadata:  map[string]string{types.IdleMetricsKey: "cpu p95=0.01 < 0.15"},
+		},
+	}
+	message := dispatcher.generateMessage(types.Clusters, []types.FilterType{types.IdleFilter}, items)
+
+	assert.Equal(t, "/code\nOperation: getClusters Filters: idle Accounts: map[]\n[GCP] cluster: cluster created: 1970-01-01 00:00:00 owner: owner idle: cpu p95=0.01 < 0.15\n", message)
+}
+
 type mockNotificationClient struct {
 	notifReqChan chan *hipchat.NotificationRequest
 }
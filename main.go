@@ -11,7 +11,7 @@ import (
 	_ "github.com/blentz/cloud-haunter/aws"
 	_ "github.com/blentz/cloud-haunter/azure"
 	ctx "github.com/blentz/cloud-haunter/context"
-	_ "github.com/blentz/cloud-haunter/filter"
+	filter "github.com/blentz/cloud-haunter/filter"
 	_ "github.com/blentz/cloud-haunter/gcp"
 	_ "github.com/blentz/cloud-haunter/hipchat"
 	_ "github.com/blentz/cloud-haunter/operation"
@@ -30,7 +30,7 @@ func main() {
 
 	help := flag.Bool("h", false, "print help")
 	opType := flag.String("o", "", "type of operation")
-	filterTypes := flag.String("f", "", "type of filters")
+	filterTypes := flag.String("f", "", "type of filters or filter expression")
 	actionType := flag.String("a", "log", "type of action")
 	cloudTypes := flag.String("c", "", "type of clouds")
 	filterConfigLoc := flag.String("fc", "", "filterConfig YAML")
@@ -53,6 +53,8 @@ func main() {
 	}
 	ctx.IgnoreLabelDisabled = *ignoreLabelDisabled
 	ctx.ExactMatchOwner = *exactMatchOwner
+	ctx.DisabledAccessesIncluded = *actionType == types.DisableAction.String()
+	ctx.OwnerInference = os.Getenv("OWNER_INFERENCE") == "true" || *actionType == types.TagOwnerAction.String()
 
 	if filterConfigLoc != nil && len(*filterConfigLoc) != 0 {
 		var err error
@@ -78,14 +80,9 @@ func main() {
 		panic("Operation is not found.")
 	}
 
-	var filters []types.Filter
-	var filterNames []types.FilterType
-	selectedFilters := utils.SplitListToMap(*filterTypes)
-	for f := range ctx.Filters {
-		if _, ok := selectedFilters[f.String()]; ok {
-			filters = append(filters, ctx.Filters[f])
-			filterNames = append(filterNames, f)
-		}
+	selectedFilter, filterNames, err := filter.ParseExpression(*filterTypes, ctx.Filters)
+	if err != nil {
+		panic("Unable to parse filters: " + err.Error())
 	}
 
 	action := func() types.Action {
@@ -115,8 +112,9 @@ func main() {
 	}
 
 	items := ctx.Operations[*op].Execute(clouds)
-	for _, filter := range filters {
-		items = filter.Execute(items)
+	ctx.OperationItems = items
+	if selectedFilter != nil {
+		items = selectedFilter.Execute(items)
 	}
 	action.Execute(*op, filterNames, items)
 }
@@ -127,6 +125,7 @@ func printHelp() {
    Cloud Haunter
 USAGE:
    ch -o=operation -a=action [-f=filter1,filter2] [-c=cloud1,cloud2]
+   ch -o=operation -a=action [-f="filter1 or (filter2 and not filter3)"] [-c=cloud1,cloud2]
 VERSION:`)
 	println("   " + ctx.Version)
 	println(`
@@ -148,6 +147,8 @@ OPERATIONS:`)
 	println("\t-c AWS")
 	println("\t-c AZURE")
 	println("\t-c GCP")
+	println("FILTER_EXPRESSION:\n\t-f=\"filter1 or (filter2 and not filter3)\", comma is the same as 'and'")
+	println("FILTER_PARAMETERS:\n\t-f=\"longrunning(period=6h),httpurl(path=/health,port=8080)\"")
 	println("FILTER_CONFIG:\n\t-fc=/location/of/filter/config.yml")
 	println("DRY RUN:\n\t-d")
 	println("VERBOSE:\n\t-v")
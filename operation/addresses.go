@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Addresses] = addresses{}
+}
+
+type addresses struct {
+}
+
+func (o addresses) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_ADDRESSES] Collecting addresses on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_ADDRESSES] Failed to collect addresses")
+}
+
+func (o addresses) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		addresses, err := provider.GetAddresses()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(addresses), nil
+	})
+}
+
+func (o addresses) convertToCloudItems(addresses []*types.Address) []types.CloudItem {
+	var items []types.CloudItem
+	for _, address := range addresses {
+		items = append(items, address)
+	}
+	return items
+}
@@ -81,10 +81,22 @@ func (p dummyProvider) StopDatabases(_ *types.DatabaseContainer) (e []error) {
 	return
 }
 
+func (p dummyProvider) StopClusters(*types.ClusterContainer) []error {
+	return nil
+}
+
 func (p dummyProvider) GetAccesses() (a []*types.Access, e error) {
 	return
 }
 
+func (p dummyProvider) DisableAccesses(*types.AccessContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) DeleteAccesses(*types.AccessContainer) []error {
+	return nil
+}
+
 func (p dummyProvider) GetDatabases() ([]*types.Database, error) {
 	return nil, nil
 }
@@ -132,3 +144,75 @@ func (p dummyProvider) CleanupStorages(storageContainer *types.StorageContainer,
 func (p dummyProvider) GetClusters() ([]*types.Cluster, error) {
 	return nil, nil
 }
+
+func (p dummyProvider) GetMetricValues(types.CloudItem, types.MetricQuery) ([]float64, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) TagOwners([]types.CloudItem) []error {
+	return nil
+}
+
+func (p dummyProvider) GetSnapshots() ([]*types.Snapshot, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) DeleteSnapshots(*types.SnapshotContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetAddresses() ([]*types.Address, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) ReleaseAddresses(*types.AddressContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetLoadBalancers() ([]*types.LoadBalancer, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) DeleteLoadBalancers(*types.LoadBalancerContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetGateways() ([]*types.Gateway, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) DeleteGateways(*types.GatewayContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetNotebooks() ([]*types.Notebook, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) StopNotebooks(*types.NotebookContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetContainerImages() ([]*types.ContainerImage, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) CleanupContainerImages(containerImageContainer *types.ContainerImageContainer, retainedImages int) []error {
+	return nil
+}
+
+func (p dummyProvider) GetFunctions() ([]*types.Function, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) DeleteFunctions(*types.FunctionContainer) []error {
+	return nil
+}
+
+func (p dummyProvider) GetNetworks() ([]*types.Network, error) {
+	return nil, nil
+}
+
+func (p dummyProvider) DeleteNetworks(*types.NetworkContainer) []error {
+	return nil
+}
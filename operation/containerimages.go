@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.ContainerImages] = containerImages{}
+}
+
+type containerImages struct {
+}
+
+func (o containerImages) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_CONTAINER_IMAGES] Collecting container images on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_CONTAINER_IMAGES] Failed to collect container images")
+}
+
+func (o containerImages) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		containerImages, err := provider.GetContainerImages()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(containerImages), nil
+	})
+}
+
+func (o containerImages) convertToCloudItems(containerImages []*types.ContainerImage) []types.CloudItem {
+	var items []types.CloudItem
+	for _, containerImage := range containerImages {
+		items = append(items, containerImage)
+	}
+	return items
+}
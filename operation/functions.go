@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Functions] = functions{}
+}
+
+type functions struct {
+}
+
+func (o functions) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_FUNCTIONS] Collecting functions on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_FUNCTIONS] Failed to collect functions")
+}
+
+func (o functions) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		functions, err := provider.GetFunctions()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(functions), nil
+	})
+}
+
+func (o functions) convertToCloudItems(functions []*types.Function) []types.CloudItem {
+	var items []types.CloudItem
+	for _, function := range functions {
+		items = append(items, function)
+	}
+	return items
+}
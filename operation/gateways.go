@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Gateways] = gateways{}
+}
+
+type gateways struct {
+}
+
+func (o gateways) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_GATEWAYS] Collecting gateways on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_GATEWAYS] Failed to collect gateways")
+}
+
+func (o gateways) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		gateways, err := provider.GetGateways()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(gateways), nil
+	})
+}
+
+func (o gateways) convertToCloudItems(gateways []*types.Gateway) []types.CloudItem {
+	var items []types.CloudItem
+	for _, gateway := range gateways {
+		items = append(items, gateway)
+	}
+	return items
+}
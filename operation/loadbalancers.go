@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.LoadBalancers] = loadBalancers{}
+}
+
+type loadBalancers struct {
+}
+
+func (o loadBalancers) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_LOAD_BALANCERS] Collecting load balancers on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_LOAD_BALANCERS] Failed to collect load balancers")
+}
+
+func (o loadBalancers) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		loadBalancers, err := provider.GetLoadBalancers()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(loadBalancers), nil
+	})
+}
+
+func (o loadBalancers) convertToCloudItems(loadBalancers []*types.LoadBalancer) []types.CloudItem {
+	var items []types.CloudItem
+	for _, loadBalancer := range loadBalancers {
+		items = append(items, loadBalancer)
+	}
+	return items
+}
@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Networks] = networks{}
+}
+
+type networks struct {
+}
+
+func (o networks) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_NETWORKS] Collecting networks on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_NETWORKS] Failed to collect networks")
+}
+
+func (o networks) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		networks, err := provider.GetNetworks()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(networks), nil
+	})
+}
+
+func (o networks) convertToCloudItems(networks []*types.Network) []types.CloudItem {
+	var items []types.CloudItem
+	for _, network := range networks {
+		items = append(items, network)
+	}
+	return items
+}
@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Notebooks] = notebooks{}
+}
+
+type notebooks struct {
+}
+
+func (o notebooks) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_NOTEBOOKS] Collecting notebooks on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_NOTEBOOKS] Failed to collect notebooks")
+}
+
+func (o notebooks) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		notebooks, err := provider.GetNotebooks()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(notebooks), nil
+	})
+}
+
+func (o notebooks) convertToCloudItems(notebooks []*types.Notebook) []types.CloudItem {
+	var items []types.CloudItem
+	for _, notebook := range notebooks {
+		items = append(items, notebook)
+	}
+	return items
+}
@@ -0,0 +1,38 @@
+package operation
+
+import (
+	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	ctx.Operations[types.Snapshots] = snapshots{}
+}
+
+type snapshots struct {
+}
+
+func (o snapshots) Execute(clouds []types.CloudType) []types.CloudItem {
+	log.Debugf("[GET_SNAPSHOTS] Collecting snapshots on: [%s]", clouds)
+	itemsChan, errChan := o.collect(clouds)
+	return wait(itemsChan, errChan, "[GET_SNAPSHOTS] Failed to collect snapshots")
+}
+
+func (o snapshots) collect(clouds []types.CloudType) (chan []types.CloudItem, chan error) {
+	return collect(clouds, func(provider types.CloudProvider) ([]types.CloudItem, error) {
+		snapshots, err := provider.GetSnapshots()
+		if err != nil {
+			return nil, err
+		}
+		return o.convertToCloudItems(snapshots), nil
+	})
+}
+
+func (o snapshots) convertToCloudItems(snapshots []*types.Snapshot) []types.CloudItem {
+	var items []types.CloudItem
+	for _, snapshot := range snapshots {
+		items = append(items, snapshot)
+	}
+	return items
+}
@@ -0,0 +1,207 @@
+package pricing
+
+import (
+	_ "embed"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/blentz/cloud-haunter/types"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// HoursPerMonth is the average number of hours in a month used by the cloud providers' pricing pages
+const HoursPerMonth = 730
+
+//go:embed prices.yml
+var embeddedPrices []byte
+
+var prices = loadPrices()
+
+// Cost is the estimated on-demand cost of a cloud item in USD
+type Cost struct {
+	Hourly  float64 `json:"Hourly"`
+	Monthly float64 `json:"Monthly"`
+}
+
+// Add returns the sum of the costs
+func (c Cost) Add(other Cost) Cost {
+	return Cost{c.Hourly + other.Hourly, c.Monthly + other.Monthly}
+}
+
+func (c Cost) String() string {
+	return fmt.Sprintf("$%.4f/hour, $%.2f/month", c.Hourly, c.Monthly)
+}
+
+type priceTable struct {
+	Instances map[types.CloudType]map[string]float64 `yaml:"instances"`
+	Databases map[types.CloudType]map[string]float64 `yaml:"databases"`
+	Disks     map[types.CloudType]map[string]float64 `yaml:"disks"`
+	Regions   map[types.CloudType]map[string]float64 `yaml:"regions"`
+}
+
+func loadPrices() *priceTable {
+	table, err := parsePrices(embeddedPrices)
+	if err != nil {
+		panic("[PRICING] Failed to parse the embedded price table: " + err.Error())
+	}
+	if location := os.Getenv("PRICE_TABLE"); len(location) > 0 {
+		raw, err := ioutil.ReadFile(location)
+		if err != nil {
+			log.Errorf("[PRICING] Failed to read the price table %s, using the embedded one, err: %s", location, err.Error())
+			return table
+		}
+		override, err := parsePrices(raw)
+		if err != nil {
+			log.Errorf("[PRICING] Failed to parse the price table %s, using the embedded one, err: %s", location, err.Error())
+			return table
+		}
+		table.merge(override)
+		log.Infof("[PRICING] Price table loaded from: %s", location)
+	}
+	return table
+}
+
+func parsePrices(raw []byte) (*priceTable, error) {
+	table := &priceTable{}
+	if err := yaml.UnmarshalStrict(raw, table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func (t *priceTable) merge(other *priceTable) {
+	t.Instances = mergePrices(t.Instances, other.Instances)
+	t.Databases = mergePrices(t.Databases, other.Databases)
+	t.Disks = mergePrices(t.Disks, other.Disks)
+	t.Regions = mergePrices(t.Regions, other.Regions)
+}
+
+func mergePrices(base, other map[types.CloudType]map[string]float64) map[types.CloudType]map[string]float64 {
+	if base == nil {
+		base = map[types.CloudType]map[string]float64{}
+	}
+	for cloud, cloudPrices := range other {
+		if base[cloud] == nil {
+			base[cloud] = map[string]float64{}
+		}
+		for key, price := range cloudPrices {
+			base[cloud][key] = price
+		}
+	}
+	return base
+}
+
+// Estimate returns the estimated cost of the item. The second return value is false if the item's type or
+// size is not in the price table.
+func Estimate(item types.CloudItem) (Cost, bool) {
+	return prices.estimate(item)
+}
+
+// EstimateTotal returns the sum of the estimated costs of the items that have a price
+func EstimateTotal(items []types.CloudItem) Cost {
+	total := Cost{}
+	for _, item := range items {
+		if cost, ok := Estimate(item); ok {
+			total = total.Add(cost)
+		}
+	}
+	return total
+}
+
+// EstimateString returns the estimated cost of the item as a string, or 'unknown' if it has no price
+func EstimateString(item types.CloudItem) string {
+	if cost, ok := Estimate(item); ok {
+		return cost.String()
+	}
+	return "unknown"
+}
+
+// estimate returns zero cost for stopped instances and databases, because only their storage is paid for
+func (t *priceTable) estimate(item types.CloudItem) (Cost, bool) {
+	switch i := item.GetItem().(type) {
+	case types.Instance:
+		if isNotRunning(i.State) {
+			return Cost{}, true
+		}
+		return t.hourly(t.Instances, i.CloudType, i.Region, i.InstanceType)
+	case types.Database:
+		if isNotRunning(i.State) {
+			return Cost{}, true
+		}
+		cost, ok := t.hourly(t.Databases, i.CloudType, i.Region, i.InstanceType)
+		if !ok {
+			cost, ok = t.hourly(t.Databases, i.CloudType, i.Region, i.Metadata["tier"])
+		}
+		if ok && i.NodeCount > 1 {
+			cost = Cost{cost.Hourly * float64(i.NodeCount), cost.Monthly * float64(i.NodeCount)}
+		}
+		return cost, ok
+	case types.Disk:
+		return t.monthly(t.Disks, i.CloudType, i.Region, lastSegment(i.Type), float64(i.Size))
+	case types.Cluster:
+		return t.estimateCluster(i)
+	}
+	return Cost{}, false
+}
+
+func (t *priceTable) estimateCluster(cluster types.Cluster) (Cost, bool) {
+	if cluster.Config == nil {
+		return Cost{}, false
+	}
+	total := Cost{}
+	found := false
+	for _, pool := range cluster.Config.NodePools {
+		if pool.NodeCount == 0 {
+			continue
+		}
+		cost, ok := t.hourly(t.Instances, cluster.CloudType, cluster.Region, lastSegment(pool.InstanceType))
+		if !ok {
+			return Cost{}, false
+		}
+		total = total.Add(Cost{cost.Hourly * float64(pool.NodeCount), cost.Monthly * float64(pool.NodeCount)})
+		found = true
+	}
+	return total, found
+}
+
+func (t *priceTable) hourly(table map[types.CloudType]map[string]float64, cloud types.CloudType, region, key string) (Cost, bool) {
+	price, ok := table[cloud][key]
+	if !ok {
+		return Cost{}, false
+	}
+	hourly := price * t.regionMultiplier(cloud, region)
+	return Cost{hourly, hourly * HoursPerMonth}, true
+}
+
+func (t *priceTable) monthly(table map[types.CloudType]map[string]float64, cloud types.CloudType, region, key string, quantity float64) (Cost, bool) {
+	price, ok := table[cloud][key]
+	if !ok {
+		return Cost{}, false
+	}
+	monthly := price * quantity * t.regionMultiplier(cloud, region)
+	return Cost{monthly / HoursPerMonth, monthly}, true
+}
+
+// regionMultiplier looks up the region, or the region of the zone (e.g. us-central1-a), in the region table
+func (t *priceTable) regionMultiplier(cloud types.CloudType, region string) float64 {
+	if multiplier, ok := t.Regions[cloud][region]; ok {
+		return multiplier
+	}
+	if i := strings.LastIndex(region, "-"); i > 0 {
+		if multiplier, ok := t.Regions[cloud][region[:i]]; ok {
+			return multiplier
+		}
+	}
+	return 1
+}
+
+func isNotRunning(state types.State) bool {
+	return state == types.Stopped || state == types.Terminated
+}
+
+func lastSegment(uri string) string {
+	return uri[strings.LastIndex(uri, "/")+1:]
+}
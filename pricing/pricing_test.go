@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/blentz/cloud-haunter/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPriceTable() *priceTable {
+	return &priceTable{
+		Instances: map[types.CloudType]map[string]float64{types.AWS: {"m5.large": 0.1}, types.GCP: {"n1-standard-4": 0.2}},
+		Databases: map[types.CloudType]map[string]float64{types.GCP: {"db-n1-standard-1": 0.1}},
+		Disks:     map[types.CloudType]map[string]float64{types.GCP: {"pd-ssd": 0.2}},
+		Regions:   map[types.CloudType]map[string]float64{types.AWS: {"eu-west-1": 2}, types.GCP: {"europe-west1": 1.5}},
+	}
+}
+
+func TestEmbeddedPrices(t *testing.T) {
+	cost, ok := Estimate(&types.Instance{CloudType: types.AWS, InstanceType: "m5.large", Region: "us-east-1", State: types.Running})
+
+	assert.True(t, ok)
+	assert.Equal(t, 0.096, cost.Hourly)
+}
+
+func TestEstimateInstanceWithRegion(t *testing.T) {
+	cost, ok := newTestPriceTable().estimate(&types.Instance{CloudType: types.AWS, InstanceType: "m5.large", Region: "eu-west-1", State: types.Running})
+
+	assert.True(t, ok)
+	assert.InDelta(t, 0.2, cost.Hourly, 0.00001)
+	assert.InDelta(t, 0.2*HoursPerMonth, cost.Monthly, 0.00001)
+}
+
+func TestEstimateStoppedInstance(t *testing.T) {
+	cost, ok := newTestPriceTable().estimate(&types.Instance{CloudType: types.AWS, InstanceType: "m5.large", State: types.Stopped})
+
+	assert.True(t, ok)
+	assert.Equal(t, Cost{}, cost)
+}
+
+func TestEstimateUnknownType(t *testing.T) {
+	_, ok := newTestPriceTable().estimate(&types.Instance{CloudType: types.AWS, InstanceType: "x9.huge", State: types.Running})
+
+	assert.False(t, ok)
+}
+
+func TestEstimateDatabaseByTier(t *testing.T) {
+	cost, ok := newTestPriceTable().estimate(&types.Database{CloudType: types.GCP, InstanceType: "CLOUD_SQL_INSTANCE", Region: "europe-west1-b", Metadata: map[string]string{"tier": "db-n1-standard-1"}})
+
+	assert.True(t, ok)
+	assert.InDelta(t, 0.15, cost.Hourly, 0.00001)
+}
+
+func TestEstimateDatabaseNodes(t *testing.T) {
+	cost, ok := Estimate(&types.Database{CloudType: types.AWS, InstanceType: "ra3.xlplus", NodeCount: 2, Region: "us-east-1", State: types.Running})
+
+	assert.True(t, ok)
+	assert.InDelta(t, 2.172, cost.Hourly, 0.00001)
+}
+
+func TestEstimateDisk(t *testing.T) {
+	cost, ok := newTestPriceTable().estimate(&types.Disk{CloudType: types.GCP, Type: "https://www.googleapis.com/compute/v1/projects/p/zones/z/diskTypes/pd-ssd", Size: 100})
+
+	assert.True(t, ok)
+	assert.InDelta(t, 20, cost.Monthly, 0.00001)
+}
+
+func TestEstimateCluster(t *testing.T) {
+	cluster := &types.Cluster{CloudType: types.GCP, Config: &types.ClusterConfig{NodePools: []*types.NodePool{
+		{Name: "master", NodeCount: 1, InstanceType: "n1-standard-4"},
+		{Name: "worker", NodeCount: 2, InstanceType: "zones/z/machineTypes/n1-standard-4"},
+	}}}
+
+	cost, ok := newTestPriceTable().estimate(cluster)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 0.6, cost.Hourly, 0.00001)
+}
+
+func TestMergePrices(t *testing.T) {
+	table := newTestPriceTable()
+
+	table.merge(&priceTable{Instances: map[types.CloudType]map[string]float64{types.AWS: {"m5.large": 0.5, "m5.xlarge": 1}, types.AZURE: {"Standard_B1s": 0.01}}})
+
+	assert.Equal(t, map[string]float64{"m5.large": 0.5, "m5.xlarge": 1}, table.Instances[types.AWS])
+	assert.Equal(t, map[string]float64{"Standard_B1s": 0.01}, table.Instances[types.AZURE])
+	assert.Equal(t, map[string]float64{"n1-standard-4": 0.2}, table.Instances[types.GCP])
+}
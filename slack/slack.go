@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	ctx "github.com/blentz/cloud-haunter/context"
+	"github.com/blentz/cloud-haunter/pricing"
 	"github.com/blentz/cloud-haunter/types"
 	"github.com/blentz/cloud-haunter/utils"
 	log "github.com/sirupsen/logrus"
@@ -76,6 +77,15 @@ func (d slackDispatcher) Send(op types.OpType, filters []types.FilterType, items
 	return nil
 }
 
+func (d slackDispatcher) SendMessage(text string) error {
+	message := slackMessage{Attachments: []attachment{{Color: RedColor, Text: text}}}
+	if ctx.DryRun {
+		log.Infof("[SLACK] Skipping notification on dry run session, message: %s", text)
+		return nil
+	}
+	return d.send(message)
+}
+
 func (d slackDispatcher) send(message slackMessage) error {
 	json, err := utils.CovertJsonToString(message)
 	if err != nil {
@@ -95,6 +105,9 @@ func (d slackDispatcher) send(message slackMessage) error {
 func (d slackDispatcher) generateMessage(op types.OpType, filters []types.FilterType, items []types.CloudItem) slackMessage {
 	message := slackMessage{}
 	message.Text = fmt.Sprintf("*Operation*: %s *Filters*: %s *Accounts*: %s\n", op, utils.GetFilterNames(filters), utils.GetCloudAccountNames())
+	if total := pricing.EstimateTotal(items); total.Monthly > 0 {
+		message.Text += fmt.Sprintf("*Estimated waste*: %s\n", total)
+	}
 
 	itemsPerOwner := map[string][]types.CloudItem{}
 	color := GreenColor
@@ -136,6 +149,7 @@ func (d slackDispatcher) generateMessage(op types.OpType, filters []types.Filter
 				if len(inst.Metadata) > 0 {
 					msg += fmt.Sprintf(" metadata: %s", inst.Metadata)
 				}
+				msg += getCost(item)
 				msg += "\n"
 				buffer.WriteString(msg)
 			case types.Database:
@@ -144,15 +158,19 @@ func (d slackDispatcher) generateMessage(op types.OpType, filters []types.Filter
 				if len(db.Metadata) > 0 {
 					msg += fmt.Sprintf(" metadata: %s", db.Metadata)
 				}
+				msg += getCost(item)
 				msg += "\n"
 				buffer.WriteString(msg)
 			case types.Cluster:
 				clust := item.GetItem().(types.Cluster)
 				msg := fmt.Sprintf("*[%s]* *%s*: %s *state*: %s *created*: %s *region*: %s", item.GetCloudType(), item.GetType(), item.GetName(), clust.State, displayTime, clust.Region)
+				msg += getViolations(item)
+				msg += getIdleMetrics(item)
+				msg += getCost(item)
 				msg += "\n"
 				buffer.WriteString(msg)
 			default:
-				buffer.WriteString(fmt.Sprintf("*[%s]* *%s*: %s\n", item.GetCloudType(), item.GetType(), item.GetName()))
+				buffer.WriteString(fmt.Sprintf("*[%s]* *%s*: %s%s%s%s\n", item.GetCloudType(), item.GetType(), item.GetName(), getViolations(item), getIdleMetrics(item), getCost(item)))
 			}
 		}
 
@@ -164,3 +182,26 @@ func (d slackDispatcher) generateMessage(op types.OpType, filters []types.Filter
 
 	return message
 }
+
+// getViolations returns the tag policy violations of the items whose metadata is not printed, the metadata of the
+// instances and databases contains them already
+func getViolations(item types.CloudItem) string {
+	if violations := types.GetMetadata(item)[types.TagPolicyViolationsKey]; len(violations) > 0 {
+		return fmt.Sprintf(" *tag violations*: %s", violations)
+	}
+	return ""
+}
+
+func getIdleMetrics(item types.CloudItem) string {
+	if measurements := types.GetMetadata(item)[types.IdleMetricsKey]; len(measurements) > 0 {
+		return fmt.Sprintf(" *idle*: %s", measurements)
+	}
+	return ""
+}
+
+func getCost(item types.CloudItem) string {
+	if cost, ok := pricing.Estimate(item); ok {
+		return fmt.Sprintf(" *cost*: %s", cost)
+	}
+	return ""
+}
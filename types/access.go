@@ -2,13 +2,39 @@ package types
 
 import "time"
 
-// Access cloud object used to authenticate against the cloud provider
+// AccessContainer holds the accesses of the cloud providers for the provider calls
+type AccessContainer struct {
+	accesses []*Access
+}
+
+// Get returns the accesses of the cloud provider
+func (c *AccessContainer) Get(cloudType CloudType) []*Access {
+	items := []*Access{}
+	for _, item := range c.accesses {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// NewAccessContainer creates a container of the accesses
+func NewAccessContainer(accesses []*Access) *AccessContainer {
+	return &AccessContainer{accesses}
+}
+
+// Access cloud object used to authenticate against the cloud provider. The ID is the full AWS access key ID, so it is
+// kept out of the output, the Name is its masked form.
 type Access struct {
-	Name      string    `json:"Name"`
-	Owner     string    `json:"Owner"`
-	Created   time.Time `json:"Created"`
-	CloudType CloudType `json:"CloudType"`
-	Tags      Tags      `json:"Tags"`
+	ID           string     `json:"-"`
+	Name         string     `json:"Name"`
+	Owner        string     `json:"Owner"`
+	Created      time.Time  `json:"Created"`
+	LastUsed     *time.Time `json:"LastUsed"`
+	UsageUnknown bool       `json:"UsageUnknown"`
+	State        State      `json:"State"`
+	CloudType    CloudType  `json:"CloudType"`
+	Tags         Tags       `json:"Tags"`
 }
 
 // GetName returns the name of the access cloud object
@@ -44,6 +70,14 @@ func (a Access) GetType() string {
 	return "access"
 }
 
+// GetLastActivity returns the last time the access cloud object was used, or the creation date if it was never used
+func (a Access) GetLastActivity() time.Time {
+	if a.LastUsed != nil && a.LastUsed.After(a.Created) {
+		return *a.LastUsed
+	}
+	return a.Created
+}
+
 func (a Access) GetTags() Tags {
 	return a.Tags
 }
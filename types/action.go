@@ -25,6 +25,12 @@ const (
 
 	// CleanupAction cleans up the cloud item  if the item supports such operation
 	CleanupAction = ActionType("cleanup")
+
+	// DisableAction disables the cloud item and deletes it after a grace period if the item supports such operation
+	DisableAction = ActionType("disable")
+
+	// TagOwnerAction writes the inferred creator of the cloud item into its owner label if the item supports such operation
+	TagOwnerAction = ActionType("tagowner")
 )
 
 // Action to execute on the cloud items
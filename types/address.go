@@ -0,0 +1,69 @@
+package types
+
+import "time"
+
+type AddressContainer struct {
+	addresses []*Address
+}
+
+func (c *AddressContainer) Get(cloudType CloudType) []*Address {
+	items := []*Address{}
+	for _, item := range c.addresses {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewAddressContainer(addresses []*Address) *AddressContainer {
+	return &AddressContainer{addresses}
+}
+
+// Address represents the reserved public IP addresses
+type Address struct {
+	ID        string            `json:"Id"`
+	Name      string            `json:"Name"`
+	IP        string            `json:"IP"`
+	Created   time.Time         `json:"Created"`
+	State     State             `json:"State"`
+	Owner     string            `json:"Owner"`
+	CloudType CloudType         `json:"CloudType"`
+	Region    string            `json:"Region"`
+	Metadata  map[string]string `json:"Metadata"`
+	Tags      Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the address
+func (a Address) GetName() string {
+	return a.Name
+}
+
+// GetOwner returns the owner of the address
+func (a Address) GetOwner() string {
+	return a.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (a Address) GetCloudType() CloudType {
+	return a.CloudType
+}
+
+// GetCreated returns the creation time of the address
+func (a Address) GetCreated() time.Time {
+	return a.Created
+}
+
+// GetItem returns the address struct itself
+func (a Address) GetItem() interface{} {
+	return a
+}
+
+// GetType returns the address's string representation
+func (a Address) GetType() string {
+	return "address"
+}
+
+func (a Address) GetTags() Tags {
+	return a.Tags
+}
@@ -31,6 +31,8 @@ type CloudProvider interface {
 	TerminateStacks(*StackContainer) []error
 	DeleteAlerts(*AlertContainer) []error
 	GetAccesses() ([]*Access, error)
+	DisableAccesses(*AccessContainer) []error
+	DeleteAccesses(*AccessContainer) []error
 	GetDatabases() ([]*Database, error)
 	GetDisks() ([]*Disk, error)
 	DeleteDisks(*DiskContainer) []error
@@ -41,4 +43,36 @@ type CloudProvider interface {
 	GetStorages() ([]*Storage, error)
 	CleanupStorages(storageContainer *StorageContainer, retentionDays int) []error
 	GetClusters() ([]*Cluster, error)
+	StopClusters(*ClusterContainer) []error
+	TagOwners([]CloudItem) []error
+	GetMetricValues(CloudItem, MetricQuery) ([]float64, error)
+	GetSnapshots() ([]*Snapshot, error)
+	DeleteSnapshots(*SnapshotContainer) []error
+	GetAddresses() ([]*Address, error)
+	ReleaseAddresses(*AddressContainer) []error
+	GetLoadBalancers() ([]*LoadBalancer, error)
+	DeleteLoadBalancers(*LoadBalancerContainer) []error
+	GetGateways() ([]*Gateway, error)
+	DeleteGateways(*GatewayContainer) []error
+	GetNotebooks() ([]*Notebook, error)
+	StopNotebooks(*NotebookContainer) []error
+	GetContainerImages() ([]*ContainerImage, error)
+	CleanupContainerImages(containerImageContainer *ContainerImageContainer, retainedImages int) []error
+	GetFunctions() ([]*Function, error)
+	DeleteFunctions(*FunctionContainer) []error
+	GetNetworks() ([]*Network, error)
+	DeleteNetworks(*NetworkContainer) []error
+}
+
+// InferredOwnerMetadataKeys are the metadata keys of the creators inferred from the audit logs of the cloud providers
+var InferredOwnerMetadataKeys = []string{"IAMUser", "PrincipalEmail", "Caller"}
+
+// GetInferredOwner returns the creator inferred from the audit logs, or empty if it is unknown
+func GetInferredOwner(metadata map[string]string) string {
+	for _, key := range InferredOwnerMetadataKeys {
+		if owner := metadata[key]; len(owner) > 0 {
+			return owner
+		}
+	}
+	return ""
 }
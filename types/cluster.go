@@ -1,9 +1,25 @@
 package types
 
 import (
+	"fmt"
 	"time"
+)
+
+const (
+	// DataprocCluster is the type of the GCP Dataproc clusters
+	DataprocCluster = "dataproc"
+
+	// EmrCluster is the type of the AWS EMR clusters
+	EmrCluster = "emr"
+
+	// EksCluster is the type of the AWS EKS clusters
+	EksCluster = "eks"
 
-	dataprocpb "google.golang.org/genproto/googleapis/cloud/dataproc/v1"
+	// GkeCluster is the type of the GCP GKE clusters
+	GkeCluster = "gke"
+
+	// AksCluster is the type of the Azure AKS clusters
+	AksCluster = "aks"
 )
 
 type ClusterContainer struct {
@@ -24,17 +40,89 @@ func NewClusterContainer(clusters []*Cluster) *ClusterContainer {
 	return &ClusterContainer{clusters}
 }
 
-// Cluster represents the Hadoop-based clusters on the cloud providers
+// Cluster represents the Hadoop-based (Dataproc, EMR) and Kubernetes (EKS, GKE, AKS) clusters on the cloud providers
 type Cluster struct {
-	Uuid      string                    `json:"ClusterUuid"`
-	Name      string                    `json:"ClusterName"`
-	Created   time.Time                 `json:"Created"`
-	Tags      map[string]string         `json:"Tags"`
-	Owner     string                    `json:"Owner"`
-	CloudType CloudType                 `json:"CloudType"`
-	State     State                     `json:"State"`
-	Region    string                    `json:"Region"`
-	Config    *dataprocpb.ClusterConfig `json:"ClusterConfig"`
+	Uuid      string            `json:"ClusterUuid"`
+	Name      string            `json:"ClusterName"`
+	Type      string            `json:"Type"`
+	Created   time.Time         `json:"Created"`
+	Tags      map[string]string `json:"Tags"`
+	Owner     string            `json:"Owner"`
+	CloudType CloudType         `json:"CloudType"`
+	State     State             `json:"State"`
+	Region    string            `json:"Region"`
+	Config    *ClusterConfig    `json:"ClusterConfig"`
+	Metadata  map[string]string `json:"Metadata"`
+}
+
+// ClusterConfig is the provider-neutral node layout of the cluster
+type ClusterConfig struct {
+	NodePools []*NodePool `json:"NodePools"`
+}
+
+// NodePool is a group of nodes of the same instance type, e.g. a Dataproc worker group or a Kubernetes node pool
+type NodePool struct {
+	Name         string `json:"Name"`
+	NodeCount    int    `json:"NodeCount"`
+	InstanceType string `json:"InstanceType"`
+	Autoscaling  bool   `json:"Autoscaling"`
+	System       bool   `json:"System"`
+}
+
+// ScalingTag is the tag of the node pools that keeps their scaling from before the stop action scaled them to zero.
+// GKE node pools have no labels, on GCP it is a label of the cluster suffixed with the name of the node pool.
+const ScalingTag = "cloud-haunter-scaling"
+
+// NodePoolScaling is the scaling of a Kubernetes node pool that is restored by hand after the cluster was stopped
+type NodePoolScaling struct {
+	Desired     int
+	Min         int
+	Max         int
+	Autoscaling bool
+}
+
+// String returns the scaling in a format that is a valid tag and label value on all cloud providers,
+// e.g. desired-3_min-1_max-5_autoscaling-on
+func (s NodePoolScaling) String() string {
+	autoscaling := "off"
+	if s.Autoscaling {
+		autoscaling = "on"
+	}
+	return fmt.Sprintf("desired-%d_min-%d_max-%d_autoscaling-%s", s.Desired, s.Min, s.Max, autoscaling)
+}
+
+// GetNodeCount returns the number of nodes in all node pools
+func (c *ClusterConfig) GetNodeCount() int {
+	count := 0
+	for _, pool := range c.NodePools {
+		count += pool.NodeCount
+	}
+	return count
+}
+
+// IsScaledToZero returns whether the cluster has node pools and all of them are scaled to zero. A cluster without
+// node pools may run its nodes elsewhere, e.g. on self-managed nodes or Fargate, so it is not scaled to zero.
+func (c *ClusterConfig) IsScaledToZero() bool {
+	return len(c.NodePools) > 0 && c.GetNodeCount() == 0
+}
+
+// GetInstanceTypes returns the distinct instance types of the node pools
+func (c *ClusterConfig) GetInstanceTypes() []string {
+	var instanceTypes []string
+	seen := map[string]bool{}
+	for _, pool := range c.NodePools {
+		if len(pool.InstanceType) > 0 && !seen[pool.InstanceType] {
+			seen[pool.InstanceType] = true
+			instanceTypes = append(instanceTypes, pool.InstanceType)
+		}
+	}
+	return instanceTypes
+}
+
+// IsStoppable returns true for the Kubernetes clusters, their node pools can be scaled to zero. EMR and Dataproc
+// clusters cannot be stopped.
+func (c Cluster) IsStoppable() bool {
+	return c.Type == EksCluster || c.Type == GkeCluster || c.Type == AksCluster
 }
 
 // GetName returns the name of the cluster
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodePoolScalingString(t *testing.T) {
+	assert.Equal(t, "desired-3_min-1_max-5_autoscaling-on", NodePoolScaling{Desired: 3, Min: 1, Max: 5, Autoscaling: true}.String())
+	assert.Equal(t, "desired-2_min-0_max-0_autoscaling-off", NodePoolScaling{Desired: 2}.String())
+}
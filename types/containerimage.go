@@ -0,0 +1,97 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+type ContainerImageContainer struct {
+	containerImages []*ContainerImage
+}
+
+func (c *ContainerImageContainer) Get(cloudType CloudType) []*ContainerImage {
+	items := []*ContainerImage{}
+	for _, item := range c.containerImages {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetOutdated returns the tagged images of the cloud that are not among the retainedImages most recently pushed ones
+// of their repository. Untagged images are never returned, as the platform images of a multi-arch image are untagged too.
+func (c *ContainerImageContainer) GetOutdated(cloudType CloudType, retainedImages int) []*ContainerImage {
+	repositories := map[string][]*ContainerImage{}
+	for _, item := range c.Get(cloudType) {
+		if len(item.ImageTags) == 0 {
+			continue
+		}
+		key := item.Region + "/" + item.Repository
+		repositories[key] = append(repositories[key], item)
+	}
+	items := []*ContainerImage{}
+	for _, images := range repositories {
+		sort.SliceStable(images, func(i, j int) bool {
+			return images[i].Created.After(images[j].Created)
+		})
+		if len(images) > retainedImages {
+			items = append(items, images[retainedImages:]...)
+		}
+	}
+	return items
+}
+
+func NewContainerImageContainer(containerImages []*ContainerImage) *ContainerImageContainer {
+	return &ContainerImageContainer{containerImages}
+}
+
+// ContainerImage represents the images pushed to the container registries
+type ContainerImage struct {
+	ID         string            `json:"Id"`
+	Name       string            `json:"Name"`
+	Repository string            `json:"Repository"`
+	ImageTags  []string          `json:"ImageTags"`
+	Created    time.Time         `json:"Created"`
+	LastPulled time.Time         `json:"LastPulled"`
+	Size       int64             `json:"Size"`
+	Owner      string            `json:"Owner"`
+	CloudType  CloudType         `json:"CloudType"`
+	Region     string            `json:"Region"`
+	Metadata   map[string]string `json:"Metadata"`
+	Tags       Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the container image
+func (img ContainerImage) GetName() string {
+	return img.Name
+}
+
+// GetOwner returns the owner of the container image
+func (img ContainerImage) GetOwner() string {
+	return img.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (img ContainerImage) GetCloudType() CloudType {
+	return img.CloudType
+}
+
+// GetCreated returns the push time of the container image
+func (img ContainerImage) GetCreated() time.Time {
+	return img.Created
+}
+
+// GetItem returns the container image struct itself
+func (img ContainerImage) GetItem() interface{} {
+	return img
+}
+
+// GetType returns the container image's string representation
+func (img ContainerImage) GetType() string {
+	return "containerimage"
+}
+
+func (img ContainerImage) GetTags() Tags {
+	return img.Tags
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOutdatedContainerImages(t *testing.T) {
+	now := time.Now()
+	container := NewContainerImageContainer([]*ContainerImage{
+		{CloudType: AWS, Name: "app:1", Repository: "app", Region: "eu-west-1", ImageTags: []string{"1"}, Created: now.Add(-3 * time.Hour)},
+		{CloudType: AWS, Name: "app:3", Repository: "app", Region: "eu-west-1", ImageTags: []string{"3"}, Created: now.Add(-1 * time.Hour)},
+		{CloudType: AWS, Name: "app:2", Repository: "app", Region: "eu-west-1", ImageTags: []string{"2"}, Created: now.Add(-2 * time.Hour)},
+		{CloudType: AWS, Name: "app@untagged", Repository: "app", Region: "eu-west-1", Created: now.Add(-4 * time.Hour)},
+		{CloudType: AWS, Name: "app:other-region", Repository: "app", Region: "us-east-1", ImageTags: []string{"1"}, Created: now.Add(-4 * time.Hour)},
+		{CloudType: GCP, Name: "app:gcp", Repository: "app", Region: "eu-west-1", ImageTags: []string{"1"}, Created: now.Add(-4 * time.Hour)},
+	})
+
+	outdated := container.GetOutdated(AWS, 2)
+
+	assert.Equal(t, 1, len(outdated))
+	assert.Equal(t, "app:1", outdated[0].Name)
+}
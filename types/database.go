@@ -2,6 +2,23 @@ package types
 
 import "time"
 
+const (
+	// RdsDatabase is the type of the AWS RDS instances
+	RdsDatabase = "rds"
+
+	// ElastiCacheDatabase is the type of the AWS ElastiCache clusters
+	ElastiCacheDatabase = "elasticache"
+
+	// RedshiftDatabase is the type of the AWS Redshift clusters
+	RedshiftDatabase = "redshift"
+
+	// CloudSqlDatabase is the type of the GCP Cloud SQL instances
+	CloudSqlDatabase = "cloudsql"
+
+	// MemorystoreDatabase is the type of the GCP Memorystore for Redis instances
+	MemorystoreDatabase = "memorystore"
+)
+
 type DatabaseContainer struct {
 	databases []*Database
 }
@@ -24,16 +41,23 @@ func NewDatabaseContainer(databases []*Database) *DatabaseContainer {
 type Database struct {
 	ID           string            `json:"Id"`
 	Name         string            `json:"Name"`
+	Type         string            `json:"Type"`
 	Created      time.Time         `json:"Created"`
 	Owner        string            `json:"Owner"`
 	Tags         Tags              `json:"Tags"`
 	InstanceType string            `json:"InstanceType"`
+	NodeCount    int               `json:"NodeCount"`
 	State        State             `json:"State"`
 	CloudType    CloudType         `json:"CloudType"`
 	Region       string            `json:"Region"`
 	Metadata     map[string]string `json:"Metadata"`
 }
 
+// IsStoppable returns false for the in-memory caches, that can only be deleted, not stopped
+func (d Database) IsStoppable() bool {
+	return d.Type != ElastiCacheDatabase && d.Type != MemorystoreDatabase
+}
+
 // GetName returns the name of the database
 func (d Database) GetName() string {
 	return d.Name
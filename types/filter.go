@@ -10,6 +10,9 @@ const (
 	// OldAccessFilter filters the cloud access objects that are created a long time
 	OldAccessFilter = FilterType("oldaccess")
 
+	// UnusedAccessFilter filters the cloud access objects that are not used for a long time
+	UnusedAccessFilter = FilterType("unusedaccess")
+
 	// StoppedFilter filters the cloud items that's state is stopped
 	StoppedFilter = FilterType("stopped")
 
@@ -45,6 +48,15 @@ const (
 
 	// IdleFilter filters the items that have been idle
 	IdleFilter = FilterType("idle")
+
+	// CostlyFilter filters the items that's estimated cost is above a threshold
+	CostlyFilter = FilterType("costly")
+
+	// NoncompliantFilter filters the items that's tags violate the tag policy
+	NoncompliantFilter = FilterType("noncompliant")
+
+	// EmptyFilter filters the stacks and networks that contain no billable resources
+	EmptyFilter = FilterType("empty")
 )
 
 // FilterConfigType inclusive or exclusive filter type
@@ -63,6 +75,12 @@ type Filter interface {
 	Execute([]CloudItem) []CloudItem
 }
 
+// FilterParams key-value parameters of a filter, e.g. longrunning(period=6h)
+type FilterParams map[string]string
+
+// FilterConstructor creates a filter from its parameters
+type FilterConstructor func(FilterParams) (Filter, error)
+
 func (f FilterType) String() string {
 	return string(f)
 }
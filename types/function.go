@@ -0,0 +1,93 @@
+package types
+
+import "time"
+
+const (
+	// LambdaFunction is the type of the AWS Lambda functions
+	LambdaFunction = "lambda"
+
+	// CloudFunction is the type of the GCP Cloud Functions
+	CloudFunction = "cloudfunction"
+
+	// AzureFunction is the type of the Azure function apps
+	AzureFunction = "azurefunction"
+)
+
+type FunctionContainer struct {
+	functions []*Function
+}
+
+func (c *FunctionContainer) Get(cloudType CloudType) []*Function {
+	items := []*Function{}
+	for _, item := range c.functions {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewFunctionContainer(functions []*Function) *FunctionContainer {
+	return &FunctionContainer{functions}
+}
+
+// Function represents the serverless functions, the invocations are counted since the start of the lookback window
+type Function struct {
+	ID           string            `json:"Id"`
+	Name         string            `json:"Name"`
+	Type         string            `json:"Type"`
+	Runtime      string            `json:"Runtime"`
+	MemorySize   int64             `json:"MemorySize"`
+	Created      time.Time         `json:"Created"`
+	LastModified time.Time         `json:"LastModified"`
+	Invocations  int64             `json:"Invocations"`
+	State        State             `json:"State"`
+	Owner        string            `json:"Owner"`
+	CloudType    CloudType         `json:"CloudType"`
+	Region       string            `json:"Region"`
+	Metadata     map[string]string `json:"Metadata"`
+	Tags         Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the function
+func (f Function) GetName() string {
+	return f.Name
+}
+
+// GetOwner returns the owner of the function
+func (f Function) GetOwner() string {
+	return f.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (f Function) GetCloudType() CloudType {
+	return f.CloudType
+}
+
+// GetCreated returns the creation time of the function
+func (f Function) GetCreated() time.Time {
+	return f.Created
+}
+
+// GetItem returns the function struct itself
+func (f Function) GetItem() interface{} {
+	return f
+}
+
+// GetType returns the function's string representation
+func (f Function) GetType() string {
+	return "function"
+}
+
+func (f Function) GetTags() Tags {
+	return f.Tags
+}
+
+// GetInvocationState returns Unused for the functions that were not invoked since the start of the lookback window,
+// the ones modified since then are InUse as they did not have the chance to be invoked
+func GetInvocationState(invocations int64, lastModified, start time.Time) State {
+	if invocations > 0 || lastModified.After(start) {
+		return InUse
+	}
+	return Unused
+}
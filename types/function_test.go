@@ -0,0 +1,16 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInvocationState(t *testing.T) {
+	start := time.Now().Add(-720 * time.Hour)
+
+	assert.Equal(t, Unused, GetInvocationState(0, start.Add(-time.Hour), start))
+	assert.Equal(t, InUse, GetInvocationState(1, start.Add(-time.Hour), start))
+	assert.Equal(t, InUse, GetInvocationState(0, start.Add(time.Hour), start))
+}
@@ -0,0 +1,78 @@
+package types
+
+import "time"
+
+const (
+	// NatGateway is the type of the NAT gateways
+	NatGateway = "natgateway"
+
+	// VpcEndpoint is the type of the interface VPC endpoints
+	VpcEndpoint = "vpcendpoint"
+)
+
+type GatewayContainer struct {
+	gateways []*Gateway
+}
+
+func (c *GatewayContainer) Get(cloudType CloudType) []*Gateway {
+	items := []*Gateway{}
+	for _, item := range c.gateways {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewGatewayContainer(gateways []*Gateway) *GatewayContainer {
+	return &GatewayContainer{gateways}
+}
+
+// Gateway represents the network resources billed by the hour, e.g. NAT gateways and interface VPC endpoints
+type Gateway struct {
+	ID        string            `json:"Id"`
+	Name      string            `json:"Name"`
+	Type      string            `json:"Type"`
+	Created   time.Time         `json:"Created"`
+	State     State             `json:"State"`
+	Owner     string            `json:"Owner"`
+	CloudType CloudType         `json:"CloudType"`
+	Region    string            `json:"Region"`
+	VpcID     string            `json:"VpcId"`
+	Metadata  map[string]string `json:"Metadata"`
+	Tags      Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the gateway
+func (g Gateway) GetName() string {
+	return g.Name
+}
+
+// GetOwner returns the owner of the gateway
+func (g Gateway) GetOwner() string {
+	return g.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (g Gateway) GetCloudType() CloudType {
+	return g.CloudType
+}
+
+// GetCreated returns the creation time of the gateway
+func (g Gateway) GetCreated() time.Time {
+	return g.Created
+}
+
+// GetItem returns the gateway struct itself
+func (g Gateway) GetItem() interface{} {
+	return g
+}
+
+// GetType returns the kind of the gateway (natgateway, vpcendpoint), so idle policies can differ per kind
+func (g Gateway) GetType() string {
+	return g.Type
+}
+
+func (g Gateway) GetTags() Tags {
+	return g.Tags
+}
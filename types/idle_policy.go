@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// IdleMetricsKey is the metadata key of the metrics measured by the idle filter
+	IdleMetricsKey = "idleMetrics"
+
+	// AndCombination judges an item idle if all of its metrics are below the threshold
+	AndCombination = "AND"
+
+	// OrCombination judges an item idle if any of its metrics is below the threshold
+	OrCombination = "OR"
+)
+
+// IdlePolicy defines what an idle item is: the metrics of the last lookback period compared to the thresholds.
+// Items created in the lookback period are never idle.
+type IdlePolicy struct {
+	Lookback    time.Duration               `yaml:"lookback"`
+	Combination string                      `yaml:"combination"`
+	Metrics     map[CloudType][]*IdleMetric `yaml:"metrics"`
+}
+
+// IdleMetric is below the threshold if the given percentile of its datapoints is lower than the threshold. The
+// datapoints are aggregated by the statistic in each period, e.g. the hourly sum of the network bytes. The metric
+// is measured on the items of the type, instances by default. The type of a cluster metric is either "cluster" or
+// the type of the clusters, e.g. "emr".
+type IdleMetric struct {
+	Name       string        `yaml:"name"`
+	Type       string        `yaml:"type"`
+	Statistic  string        `yaml:"statistic"`
+	Period     time.Duration `yaml:"period"`
+	Percentile float64       `yaml:"percentile"`
+	Threshold  float64       `yaml:"threshold"`
+}
+
+// Validate checks the policy and sets the default values: AND combination, instance type, 95th percentile, average
+// statistic and hourly period
+func (p *IdlePolicy) Validate() error {
+	if p.Lookback <= 0 {
+		return fmt.Errorf("invalid lookback: %s", p.Lookback)
+	}
+	p.Combination = strings.ToUpper(p.Combination)
+	switch p.Combination {
+	case "":
+		p.Combination = AndCombination
+	case AndCombination, OrCombination:
+	default:
+		return fmt.Errorf("invalid combination: %s, allowed: %s, %s", p.Combination, AndCombination, OrCombination)
+	}
+	for cloud, metrics := range p.Metrics {
+		for _, metric := range metrics {
+			if metric == nil || len(metric.Name) == 0 {
+				return fmt.Errorf("metric without name on %s", cloud)
+			}
+			if len(metric.Type) == 0 {
+				metric.Type = "instance"
+			}
+			if metric.Percentile == 0 {
+				metric.Percentile = 0.95
+			}
+			if metric.Percentile < 0 || metric.Percentile > 1 {
+				return fmt.Errorf("invalid percentile of metric %s: %f", metric.Name, metric.Percentile)
+			}
+			switch metric.Statistic {
+			case "":
+				metric.Statistic = AverageStatistic
+			case AverageStatistic, SumStatistic:
+			default:
+				return fmt.Errorf("invalid statistic of metric %s: %s", metric.Name, metric.Statistic)
+			}
+			if metric.Period == 0 {
+				metric.Period = time.Hour
+			}
+			if metric.Period < 0 {
+				return fmt.Errorf("invalid period of metric %s: %s", metric.Name, metric.Period)
+			}
+		}
+	}
+	return nil
+}
+
+// GetMetrics returns the metrics of the items on the cloud with any of the types
+func (p *IdlePolicy) GetMetrics(cloud CloudType, itemTypes ...string) []*IdleMetric {
+	var metrics []*IdleMetric
+	for _, metric := range p.Metrics[cloud] {
+		for _, itemType := range itemTypes {
+			if metric.Type == itemType {
+				metrics = append(metrics, metric)
+				break
+			}
+		}
+	}
+	return metrics
+}
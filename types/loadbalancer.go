@@ -0,0 +1,71 @@
+package types
+
+import "time"
+
+type LoadBalancerContainer struct {
+	loadBalancers []*LoadBalancer
+}
+
+func (c *LoadBalancerContainer) Get(cloudType CloudType) []*LoadBalancer {
+	items := []*LoadBalancer{}
+	for _, item := range c.loadBalancers {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewLoadBalancerContainer(loadBalancers []*LoadBalancer) *LoadBalancerContainer {
+	return &LoadBalancerContainer{loadBalancers}
+}
+
+// LoadBalancer represents the load balancers (ALB/NLB/ELB, forwarding rules, Azure load balancers)
+type LoadBalancer struct {
+	ID             string            `json:"Id"`
+	Name           string            `json:"Name"`
+	Type           string            `json:"Type"`
+	Created        time.Time         `json:"Created"`
+	State          State             `json:"State"`
+	Owner          string            `json:"Owner"`
+	CloudType      CloudType         `json:"CloudType"`
+	Region         string            `json:"Region"`
+	Targets        int               `json:"Targets"`
+	HealthyTargets int               `json:"HealthyTargets"`
+	Metadata       map[string]string `json:"Metadata"`
+	Tags           Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the load balancer
+func (l LoadBalancer) GetName() string {
+	return l.Name
+}
+
+// GetOwner returns the owner of the load balancer
+func (l LoadBalancer) GetOwner() string {
+	return l.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (l LoadBalancer) GetCloudType() CloudType {
+	return l.CloudType
+}
+
+// GetCreated returns the creation time of the load balancer
+func (l LoadBalancer) GetCreated() time.Time {
+	return l.Created
+}
+
+// GetItem returns the load balancer struct itself
+func (l LoadBalancer) GetItem() interface{} {
+	return l
+}
+
+// GetType returns the load balancer's string representation
+func (l LoadBalancer) GetType() string {
+	return "loadbalancer"
+}
+
+func (l LoadBalancer) GetTags() Tags {
+	return l.Tags
+}
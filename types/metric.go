@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+const (
+	// AverageStatistic aggregates the datapoints of a period by their average
+	AverageStatistic = "Average"
+
+	// SumStatistic aggregates the datapoints of a period by their sum
+	SumStatistic = "Sum"
+)
+
+// MetricQuery selects the datapoints of a metric of a cloud item between the start and end time. The datapoints are
+// aggregated by the statistic in each period, if the period is 0 the raw datapoints are returned if the cloud allows.
+type MetricQuery struct {
+	Name      string
+	Statistic string
+	Period    time.Duration
+	Start     time.Time
+	End       time.Time
+}
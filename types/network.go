@@ -0,0 +1,68 @@
+package types
+
+import "time"
+
+type NetworkContainer struct {
+	networks []*Network
+}
+
+func (c *NetworkContainer) Get(cloudType CloudType) []*Network {
+	items := []*Network{}
+	for _, item := range c.networks {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewNetworkContainer(networks []*Network) *NetworkContainer {
+	return &NetworkContainer{networks}
+}
+
+// Network represents the virtual networks (VPC) that are left around after the resources in them are deleted
+type Network struct {
+	ID        string            `json:"Id"`
+	Name      string            `json:"Name"`
+	Created   time.Time         `json:"Created"`
+	State     State             `json:"State"`
+	Owner     string            `json:"Owner"`
+	CloudType CloudType         `json:"CloudType"`
+	Region    string            `json:"Region"`
+	Metadata  map[string]string `json:"Metadata"`
+	Tags      Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the network
+func (n Network) GetName() string {
+	return n.Name
+}
+
+// GetOwner returns the owner of the network
+func (n Network) GetOwner() string {
+	return n.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (n Network) GetCloudType() CloudType {
+	return n.CloudType
+}
+
+// GetCreated returns the creation time of the network
+func (n Network) GetCreated() time.Time {
+	return n.Created
+}
+
+// GetItem returns the network struct itself
+func (n Network) GetItem() interface{} {
+	return n
+}
+
+// GetType returns the type representation of the network
+func (n Network) GetType() string {
+	return "network"
+}
+
+func (n Network) GetTags() Tags {
+	return n.Tags
+}
@@ -0,0 +1,80 @@
+package types
+
+import "time"
+
+const (
+	// SageMakerNotebook is the type of the AWS SageMaker notebook instances
+	SageMakerNotebook = "sagemaker"
+
+	// WorkbenchNotebook is the type of the GCP Vertex AI Workbench instances
+	WorkbenchNotebook = "workbench"
+)
+
+type NotebookContainer struct {
+	notebooks []*Notebook
+}
+
+func (c *NotebookContainer) Get(cloudType CloudType) []*Notebook {
+	items := []*Notebook{}
+	for _, item := range c.notebooks {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewNotebookContainer(notebooks []*Notebook) *NotebookContainer {
+	return &NotebookContainer{notebooks}
+}
+
+// Notebook represents the managed notebook instances of the machine learning services, e.g. SageMaker notebook
+// instances and Vertex AI Workbench notebooks
+type Notebook struct {
+	ID           string            `json:"Id"`
+	Name         string            `json:"Name"`
+	Type         string            `json:"Type"`
+	InstanceType string            `json:"InstanceType"`
+	Created      time.Time         `json:"Created"`
+	LastModified time.Time         `json:"LastModified"`
+	State        State             `json:"State"`
+	Owner        string            `json:"Owner"`
+	CloudType    CloudType         `json:"CloudType"`
+	Region       string            `json:"Region"`
+	Metadata     map[string]string `json:"Metadata"`
+	Tags         Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the notebook
+func (n Notebook) GetName() string {
+	return n.Name
+}
+
+// GetOwner returns the owner of the notebook
+func (n Notebook) GetOwner() string {
+	return n.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (n Notebook) GetCloudType() CloudType {
+	return n.CloudType
+}
+
+// GetCreated returns the creation time of the notebook
+func (n Notebook) GetCreated() time.Time {
+	return n.Created
+}
+
+// GetItem returns the notebook struct itself
+func (n Notebook) GetItem() interface{} {
+	return n
+}
+
+// GetType returns the notebook string representation
+func (n Notebook) GetType() string {
+	return "notebook"
+}
+
+func (n Notebook) GetTags() Tags {
+	return n.Tags
+}
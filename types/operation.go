@@ -30,6 +30,30 @@ const (
 
 	// Clusters operation to return all clusters
 	Clusters = OpType("getClusters")
+
+	// Snapshots operation to return all disk snapshots
+	Snapshots = OpType("getSnapshots")
+
+	// Addresses operation to return all reserved public IP addresses
+	Addresses = OpType("getAddresses")
+
+	// LoadBalancers operation to return all load balancers
+	LoadBalancers = OpType("getLoadBalancers")
+
+	// Gateways operation to return all NAT gateways and interface VPC endpoints
+	Gateways = OpType("getGateways")
+
+	// Notebooks operation to return all machine learning notebook instances
+	Notebooks = OpType("getNotebooks")
+
+	// ContainerImages operation to return all the images of the container registries
+	ContainerImages = OpType("getContainerImages")
+
+	// Functions operation to return all serverless functions
+	Functions = OpType("getFunctions")
+
+	// Networks operation to return all the non-default virtual networks
+	Networks = OpType("getNetworks")
 )
 
 // OpType type of the operation
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+type SnapshotContainer struct {
+	snapshots []*Snapshot
+}
+
+func (c *SnapshotContainer) Get(cloudType CloudType) []*Snapshot {
+	items := []*Snapshot{}
+	for _, item := range c.snapshots {
+		if item.CloudType == cloudType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func NewSnapshotContainer(snapshots []*Snapshot) *SnapshotContainer {
+	return &SnapshotContainer{snapshots}
+}
+
+// Snapshot represents the point-in-time copies of the disks
+type Snapshot struct {
+	ID         string            `json:"Id"`
+	Name       string            `json:"Name"`
+	Created    time.Time         `json:"Created"`
+	State      State             `json:"State"`
+	Owner      string            `json:"Owner"`
+	CloudType  CloudType         `json:"CloudType"`
+	Region     string            `json:"Region"`
+	Size       int64             `json:"Size"`
+	SourceDisk string            `json:"SourceDisk"`
+	Metadata   map[string]string `json:"Metadata"`
+	Tags       Tags              `json:"Tags"`
+}
+
+// GetName returns the name of the snapshot
+func (s Snapshot) GetName() string {
+	return s.Name
+}
+
+// GetOwner returns the owner of the snapshot
+func (s Snapshot) GetOwner() string {
+	return s.Owner
+}
+
+// GetCloudType returns the type of the cloud
+func (s Snapshot) GetCloudType() CloudType {
+	return s.CloudType
+}
+
+// GetCreated returns the creation time of the snapshot
+func (s Snapshot) GetCreated() time.Time {
+	return s.Created
+}
+
+// GetItem returns the snapshot struct itself
+func (s Snapshot) GetItem() interface{} {
+	return s
+}
+
+// GetType returns the snapshot's string representation
+func (s Snapshot) GetType() string {
+	return "snapshot"
+}
+
+func (s Snapshot) GetTags() Tags {
+	return s.Tags
+}
@@ -1,6 +1,25 @@
 package types
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
+
+// ResourceCountMetadataKey is the metadata key of the number of billable resources in a stack or a network
+const ResourceCountMetadataKey = "resourceCount"
+
+// GetResourceCount returns the number of billable resources from the metadata, or false if it is unknown
+func GetResourceCount(metadata map[string]string) (int, bool) {
+	value, ok := metadata[ResourceCountMetadataKey]
+	if !ok {
+		return 0, false
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
 
 type StackContainer struct {
 	stacks []*Stack
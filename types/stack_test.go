@@ -0,0 +1,19 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResourceCount(t *testing.T) {
+	count, ok := GetResourceCount(map[string]string{ResourceCountMetadataKey: "3"})
+	assert.True(t, ok)
+	assert.Equal(t, 3, count)
+
+	_, ok = GetResourceCount(map[string]string{})
+	assert.False(t, ok)
+
+	_, ok = GetResourceCount(map[string]string{ResourceCountMetadataKey: "n/a"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// TagPolicyViolationsKey is the metadata key of the tag policy violations of the items
+const TagPolicyViolationsKey = "tagPolicyViolations"
+
+// TagPolicy is the mandatory tag schema of the cloud items
+type TagPolicy struct {
+	Required  []string             `yaml:"required"`
+	Tags      map[string]*TagRule  `yaml:"tags"`
+	Overrides []*TagPolicyOverride `yaml:"overrides"`
+}
+
+// TagRule restricts the value of a tag to the allowed values and/or the values matching the pattern
+type TagRule struct {
+	Values  []string `yaml:"values"`
+	Pattern string   `yaml:"pattern"`
+	pattern *regexp.Regexp
+}
+
+// TagPolicyOverride changes the policy of the items on the given clouds and of the given types, empty matches all.
+// The required tags replace the default ones if set, the tag rules are merged into the default ones.
+type TagPolicyOverride struct {
+	Clouds   []CloudType         `yaml:"clouds"`
+	Types    []string            `yaml:"types"`
+	Required []string            `yaml:"required"`
+	Tags     map[string]*TagRule `yaml:"tags"`
+}
+
+// Compile validates the patterns of the tag rules
+func (p *TagPolicy) Compile() error {
+	if err := compileTagRules(p.Tags); err != nil {
+		return err
+	}
+	for _, override := range p.Overrides {
+		if err := compileTagRules(override.Tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileTagRules(rules map[string]*TagRule) error {
+	for key, rule := range rules {
+		if rule == nil || len(rule.Pattern) == 0 {
+			continue
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern of tag %s: %s", key, err)
+		}
+		rule.pattern = pattern
+	}
+	return nil
+}
+
+// Violations returns the missing tags and the tags with values not allowed by the policy
+func (p *TagPolicy) Violations(item CloudItem) []string {
+	required, rules := p.getPolicy(item.GetCloudType(), item.GetType())
+	tags := item.GetTags()
+	var violations []string
+	for _, key := range required {
+		if len(tags[key]) == 0 {
+			violations = append(violations, "missing tag: "+key)
+		}
+	}
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if value, ok := tags[key]; ok && len(value) > 0 && !rules[key].allows(value) {
+			violations = append(violations, fmt.Sprintf("invalid value of tag %s: %s", key, value))
+		}
+	}
+	return violations
+}
+
+func (p *TagPolicy) getPolicy(cloud CloudType, itemType string) ([]string, map[string]*TagRule) {
+	required := p.Required
+	rules := map[string]*TagRule{}
+	for key, rule := range p.Tags {
+		rules[key] = rule
+	}
+	for _, override := range p.Overrides {
+		if !override.matches(cloud, itemType) {
+			continue
+		}
+		if override.Required != nil {
+			required = override.Required
+		}
+		for key, rule := range override.Tags {
+			rules[key] = rule
+		}
+	}
+	return required, rules
+}
+
+func (o *TagPolicyOverride) matches(cloud CloudType, itemType string) bool {
+	cloudMatches := len(o.Clouds) == 0
+	for _, c := range o.Clouds {
+		if c == cloud {
+			cloudMatches = true
+		}
+	}
+	return cloudMatches && (len(o.Types) == 0 || containsString(o.Types, itemType))
+}
+
+func (r *TagRule) allows(value string) bool {
+	if r == nil {
+		return true
+	}
+	if len(r.Values) > 0 && containsString(r.Values, value) {
+		return true
+	}
+	if r.pattern != nil && r.pattern.MatchString(value) {
+		return true
+	}
+	return len(r.Values) == 0 && r.pattern == nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
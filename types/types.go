@@ -46,6 +46,18 @@ const (
 
 	// Idle state of the cloud item
 	Idle = State("idle")
+
+	// Disabled state of the cloud item
+	Disabled = State("disabled")
+
+	// Available state of the cloud item
+	Available = State("available")
+
+	// Unhealthy state of the cloud item whose registered targets are not healthy
+	Unhealthy = State("unhealthy")
+
+	// Orphaned state of the cloud item whose source no longer exists
+	Orphaned = State("orphaned")
 )
 
 // State string representation of the cloud item
@@ -76,4 +88,82 @@ type CloudItem interface {
 type Dispatcher interface {
 	GetName() string
 	Send(op OpType, filters []FilterType, items []CloudItem) error
+	SendMessage(message string) error
+}
+
+// GetMetadata returns the metadata of the cloud item, or nil if the type of the item has no metadata
+func GetMetadata(item CloudItem) map[string]string {
+	switch t := item.GetItem().(type) {
+	case Instance:
+		return t.Metadata
+	case Disk:
+		return t.Metadata
+	case Database:
+		return t.Metadata
+	case Stack:
+		return t.Metadata
+	case Alert:
+		return t.Metadata
+	case Cluster:
+		return t.Metadata
+	case Snapshot:
+		return t.Metadata
+	case Address:
+		return t.Metadata
+	case LoadBalancer:
+		return t.Metadata
+	case Gateway:
+		return t.Metadata
+	case Notebook:
+		return t.Metadata
+	case ContainerImage:
+		return t.Metadata
+	case Function:
+		return t.Metadata
+	case Network:
+		return t.Metadata
+	}
+	return nil
+}
+
+// SetMetadata sets the metadata of the cloud item, returns false if the type of the item has no metadata
+func SetMetadata(item CloudItem, key, value string) bool {
+	var metadata *map[string]string
+	switch t := item.(type) {
+	case *Instance:
+		metadata = &t.Metadata
+	case *Disk:
+		metadata = &t.Metadata
+	case *Database:
+		metadata = &t.Metadata
+	case *Stack:
+		metadata = &t.Metadata
+	case *Alert:
+		metadata = &t.Metadata
+	case *Cluster:
+		metadata = &t.Metadata
+	case *Snapshot:
+		metadata = &t.Metadata
+	case *Address:
+		metadata = &t.Metadata
+	case *LoadBalancer:
+		metadata = &t.Metadata
+	case *Gateway:
+		metadata = &t.Metadata
+	case *Notebook:
+		metadata = &t.Metadata
+	case *ContainerImage:
+		metadata = &t.Metadata
+	case *Function:
+		metadata = &t.Metadata
+	case *Network:
+		metadata = &t.Metadata
+	default:
+		return false
+	}
+	if *metadata == nil {
+		*metadata = map[string]string{}
+	}
+	(*metadata)[key] = value
+	return true
 }
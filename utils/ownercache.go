@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// OwnerCacheMissTTL is the time after a resource without a known creator is looked up again in the audit logs
+var OwnerCacheMissTTL = 24 * time.Hour
+
+// OwnerCacheEntry is the creator of a resource found in the audit logs, empty if it was not found
+type OwnerCacheEntry struct {
+	Owner   string    `json:"Owner"`
+	Checked time.Time `json:"Checked"`
+}
+
+// OwnerCache stores the creators of the cloud resources on disk, so the audit logs are not queried on every run
+type OwnerCache struct {
+	location string
+	mutex    sync.Mutex
+	entries  map[string]OwnerCacheEntry
+	modified bool
+}
+
+// GetOwnerCacheLocation returns the location of the cache file from the OWNER_CACHE_FILE environment variable
+// or the default one in the user's cache directory
+func GetOwnerCacheLocation() string {
+	if location := os.Getenv("OWNER_CACHE_FILE"); len(location) > 0 {
+		return location
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "owners.json"
+	}
+	return filepath.Join(cacheDir, "cloud-haunter", "owners.json")
+}
+
+// IsInferredOwnerPromoted returns whether the creator found in the audit logs should be used as the owner
+func IsInferredOwnerPromoted() bool {
+	return os.Getenv("INFERRED_OWNER_AS_OWNER") == "true"
+}
+
+var ownerCache *OwnerCache
+var ownerCacheOnce sync.Once
+
+// GetOwnerCache returns the cache of the process, it is loaded once, so the providers running concurrently share the
+// entries instead of overwriting the ones of each other when saving
+func GetOwnerCache() *OwnerCache {
+	ownerCacheOnce.Do(func() {
+		ownerCache = LoadOwnerCache(GetOwnerCacheLocation())
+	})
+	return ownerCache
+}
+
+// LoadOwnerCache loads the cache from the location, an unreadable cache is treated as empty
+func LoadOwnerCache(location string) *OwnerCache {
+	cache := &OwnerCache{location: location, entries: map[string]OwnerCacheEntry{}}
+	raw, err := ioutil.ReadFile(location)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("[UTIL] Failed to read the owner cache %s, err: %s", location, err)
+		}
+		return cache
+	}
+	if err := json.Unmarshal(raw, &cache.entries); err != nil {
+		log.Warnf("[UTIL] Failed to parse the owner cache %s, err: %s", location, err)
+	}
+	return cache
+}
+
+// Get returns the cached creator of the resource. The second return value is false if the resource has to be
+// looked up in the audit logs, because it is not cached or it was not found recently.
+func (c *OwnerCache) Get(key string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || (len(entry.Owner) == 0 && entry.Checked.Add(OwnerCacheMissTTL).Before(time.Now())) {
+		return "", false
+	}
+	return entry.Owner, true
+}
+
+// Set stores the creator of the resource, empty if it was not found in the audit logs
+func (c *OwnerCache) Set(key, owner string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = OwnerCacheEntry{owner, time.Now()}
+	c.modified = true
+}
+
+// Save writes the cache to its location if it was modified. The file is replaced in one step, so a concurrent reader
+// never sees a partially written cache.
+func (c *OwnerCache) Save() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.modified {
+		return
+	}
+	raw, _ := json.MarshalIndent(c.entries, "", "  ")
+	if err := os.MkdirAll(filepath.Dir(c.location), 0700); err != nil {
+		log.Warnf("[UTIL] Failed to create the directory of the owner cache %s, err: %s", c.location, err)
+		return
+	}
+	if err := ioutil.WriteFile(c.location+".tmp", raw, 0600); err != nil {
+		log.Warnf("[UTIL] Failed to write the owner cache %s, err: %s", c.location, err)
+		return
+	}
+	if err := os.Rename(c.location+".tmp", c.location); err != nil {
+		log.Warnf("[UTIL] Failed to replace the owner cache %s, err: %s", c.location, err)
+		return
+	}
+	c.modified = false
+}
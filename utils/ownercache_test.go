@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerCache(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "cache", "owners.json")
+	cache := LoadOwnerCache(location)
+	cache.Set("found", "owner")
+	cache.Set("not-found", "")
+	cache.Save()
+
+	loaded := LoadOwnerCache(location)
+
+	owner, ok := loaded.Get("found")
+	assert.True(t, ok)
+	assert.Equal(t, "owner", owner)
+	owner, ok = loaded.Get("not-found")
+	assert.True(t, ok)
+	assert.Equal(t, "", owner)
+	_, ok = loaded.Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestOwnerCacheMissExpires(t *testing.T) {
+	cache := LoadOwnerCache(filepath.Join(t.TempDir(), "owners.json"))
+	cache.entries["not-found"] = OwnerCacheEntry{Checked: time.Now().Add(-OwnerCacheMissTTL).Add(-time.Second)}
+	cache.entries["found"] = OwnerCacheEntry{Owner: "owner", Checked: time.Now().Add(-OwnerCacheMissTTL).Add(-time.Second)}
+
+	_, ok := cache.Get("not-found")
+	assert.False(t, ok)
+	_, ok = cache.Get("found")
+	assert.True(t, ok)
+}
+
+func TestOwnerCacheSavedOnlyIfModified(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "owners.json")
+	cache := LoadOwnerCache(location)
+	cache.Save()
+
+	_, err := os.Stat(location)
+	assert.True(t, os.IsNotExist(err))
+
+	cache.Set("found", "owner")
+	cache.Save()
+
+	_, err = os.Stat(location)
+	assert.Nil(t, err)
+}
+
+func TestGetOwnerCacheIsShared(t *testing.T) {
+	os.Setenv("OWNER_CACHE_FILE", filepath.Join(t.TempDir(), "owners.json"))
+	defer os.Unsetenv("OWNER_CACHE_FILE")
+
+	GetOwnerCache().Set("AWS:region:i-1", "aws-owner")
+	GetOwnerCache().Set("GCP:project:instances/gcp", "gcp-owner")
+	GetOwnerCache().Save()
+
+	loaded := LoadOwnerCache(GetOwnerCache().location)
+	owner, _ := loaded.Get("AWS:region:i-1")
+	assert.Equal(t, "aws-owner", owner)
+	owner, _ = loaded.Get("GCP:project:instances/gcp")
+	assert.Equal(t, "gcp-owner", owner)
+}
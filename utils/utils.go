@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -107,6 +108,40 @@ func LoadFilterConfigV2(location string) (*types.FilterConfigV2, error) {
 	return configV2, nil
 }
 
+// LoadTagPolicy loads and unmarshalls the tag policy YAML
+func LoadTagPolicy(location string) (*types.TagPolicy, error) {
+	raw, err := ioutil.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	policy := &types.TagPolicy{}
+	if err = yaml.UnmarshalStrict(raw, policy); err != nil {
+		return nil, err
+	}
+	if err = policy.Compile(); err != nil {
+		return nil, err
+	}
+	log.Debugf("[UTIL] Tag policy loaded:\n%s", raw)
+	return policy, nil
+}
+
+// LoadIdlePolicy loads and unmarshalls the idle policy YAML
+func LoadIdlePolicy(location string) (*types.IdlePolicy, error) {
+	raw, err := ioutil.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	policy := &types.IdlePolicy{}
+	if err = yaml.UnmarshalStrict(raw, policy); err != nil {
+		return nil, err
+	}
+	if err = policy.Validate(); err != nil {
+		return nil, err
+	}
+	log.Debugf("[UTIL] Idle policy loaded:\n%s", raw)
+	return policy, nil
+}
+
 // GetCloudAccountNames returns the name of the configured cloud accounts
 func GetCloudAccountNames() map[types.CloudType]string {
 	var accounts = make(map[types.CloudType]string)
@@ -163,3 +198,17 @@ func GetHumanReadableFileSize(b int64) string {
 	return fmt.Sprintf("%.1f %cB",
 		float64(b)/float64(div), "kMGTPE"[exp])
 }
+
+// GetInvocationLookback returns the period the invocations of the functions are counted in from the
+// INVOCATION_LOOKBACK environment variable, 720h by default
+func GetInvocationLookback() time.Duration {
+	lookback := 720 * time.Hour
+	if value := os.Getenv("INVOCATION_LOOKBACK"); len(value) > 0 {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Failed to parse INVOCATION_LOOKBACK, err: %s", err)
+		}
+		lookback = parsed
+	}
+	return lookback
+}
@@ -106,3 +106,29 @@ func TestSplitListToMap(t *testing.T) {
 func TestSplitListToMapEmpty(t *testing.T) {
 	assert.Equal(t, map[string]bool{}, SplitListToMap(""))
 }
+
+func TestLoadTagPolicy(t *testing.T) {
+	policy, err := LoadTagPolicy("testdata/tagPolicy.yml")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"owner", "cost-center", "env"}, policy.Required)
+	assert.Equal(t, []string{"dev", "test", "prod"}, policy.Tags["env"].Values)
+	assert.Equal(t, 2, len(policy.Overrides))
+}
+
+func TestLoadIdlePolicy(t *testing.T) {
+	policy, err := LoadIdlePolicy("testdata/idlePolicy.yml")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 14*24*time.Hour, policy.Lookback)
+	assert.Equal(t, types.OrCombination, policy.Combination)
+	assert.Equal(t, 2, len(policy.Metrics[types.AWS]))
+	assert.Equal(t, time.Hour, policy.Metrics[types.AWS][0].Period)
+	assert.Equal(t, 0.95, policy.Metrics[types.AWS][0].Percentile)
+	assert.Equal(t, 0.99, policy.Metrics[types.AWS][1].Percentile)
+	assert.Equal(t, float64(1048576), policy.Metrics[types.GCP][0].Threshold)
+	assert.Equal(t, "instance", policy.Metrics[types.GCP][0].Type)
+	assert.Equal(t, types.AverageStatistic, policy.Metrics[types.GCP][1].Statistic)
+	assert.Equal(t, time.Hour, policy.Metrics[types.GCP][1].Period)
+	assert.Equal(t, "database", policy.Metrics[types.GCP][2].Type)
+}